@@ -0,0 +1,143 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package batch runs a playlist of cartridges without a GUI. Each cartridge
+// is run for a fixed number of frames and the frame hash at the end of the run
+// is collected into a Report, along with any error that occurred.
+//
+// A Report is useful for spotting changes in emulation across a large
+// collection of ROMs without having to create a regression entry for each one.
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/setup"
+)
+
+// Result of running a single cartridge.
+type Result struct {
+	// the name of the cartridge as given by the loader
+	Name string
+
+	// the frame hash of the last frame completed by the television. will be
+	// zero if Err is not nil
+	FrameHash uint64
+
+	// any error that occurred while attaching or running the cartridge
+	Err error
+}
+
+// Status returns "ok" or the error message if the cartridge failed.
+func (res Result) Status() string {
+	if res.Err != nil {
+		return res.Err.Error()
+	}
+	return "ok"
+}
+
+func (res Result) String() string {
+	return fmt.Sprintf("%s: %016x %s", res.Name, res.FrameHash, res.Status())
+}
+
+// Report is the outcome of a batch run. There is one entry in Results for each
+// loader, in the order the loaders were supplied.
+type Report struct {
+	Results []Result
+}
+
+// Failures returns the number of cartridges that did not run successfully.
+func (rep Report) Failures() int {
+	var n int
+	for _, res := range rep.Results {
+		if res.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (rep Report) String() string {
+	s := strings.Builder{}
+	for _, res := range rep.Results {
+		s.WriteString(res.String())
+		s.WriteString("\n")
+	}
+	s.WriteString(fmt.Sprintf("%d cartridges, %d failed", len(rep.Results), rep.Failures()))
+	return s.String()
+}
+
+// Run each cartridge in the list of loaders for the specified number of
+// frames, using the TV specification. The loaders are not closed by Run().
+//
+// An error in one cartridge does not prevent the remaining cartridges from
+// being run. The error is recorded in the Result for that cartridge instead.
+func Run(loaders []cartridgeloader.Loader, spec string, numFrames int) (Report, error) {
+	if numFrames <= 0 {
+		return Report{}, fmt.Errorf("batch: number of frames must be greater than zero")
+	}
+
+	rep := Report{
+		Results: make([]Result, 0, len(loaders)),
+	}
+
+	for _, cartload := range loaders {
+		res := Result{Name: cartload.Name}
+		res.FrameHash, res.Err = run(cartload, spec, numFrames)
+		if res.Err != nil {
+			res.FrameHash = 0
+		}
+		rep.Results = append(rep.Results, res)
+	}
+
+	return rep, nil
+}
+
+// run a single cartridge and return the frame hash at the end of the run.
+func run(cartload cartridgeloader.Loader, spec string, numFrames int) (uint64, error) {
+	tv, err := television.NewTelevision(spec)
+	if err != nil {
+		return 0, fmt.Errorf("batch: %w", err)
+	}
+	defer tv.End()
+
+	// no need to run at the normal speed
+	tv.SetFPSCap(false)
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("batch: %w", err)
+	}
+
+	// the same cartridge should produce the same frame hash every time
+	vcs.Env.Normalise()
+
+	err = setup.AttachCartridge(vcs, cartload, true)
+	if err != nil {
+		return 0, fmt.Errorf("batch: %w", err)
+	}
+
+	err = vcs.RunForFrameCount(numFrames, nil)
+	if err != nil {
+		return 0, fmt.Errorf("batch: %w", err)
+	}
+
+	return tv.FrameHash(), nil
+}
@@ -0,0 +1,87 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package batch_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/batch"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a minimal 4k ROM that produces a 259 scanline frame. the step value is added
+// to the background color on every scanline
+func stripesROM(step uint8) []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta VSYNC
+		0x85, 0x02, // f004 sta WSYNC
+		0x85, 0x02, // f006 sta WSYNC
+		0x85, 0x02, // f008 sta WSYNC
+		0xa9, 0x00, // f00a lda #$00
+		0x85, 0x00, // f00c sta VSYNC
+		0x18,       // f00e clc
+		0x85, 0x09, // f00f sta COLUBK
+		0x85, 0x02, // f011 sta WSYNC
+		0x69, step, // f013 adc #step
+		0xc9, 0x00, // f015 cmp #$00
+		0xd0, 0xf6, // f017 bne $f00f
+		0x4c, 0x00, 0xf0, // f019 jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestBatch(t *testing.T) {
+	prefs.DisableSaving = true
+
+	var loaders []cartridgeloader.Loader
+	for _, n := range []string{"one", "two"} {
+		step := uint8(1)
+		if n == "two" {
+			step = 2
+		}
+		cartload, err := cartridgeloader.NewLoaderFromData(n, stripesROM(step), "4K", "", nil)
+		test.DemandSuccess(t, err)
+		defer cartload.Close()
+		loaders = append(loaders, cartload)
+	}
+
+	_, err := batch.Run(loaders, "NTSC", 0)
+	test.ExpectFailure(t, err)
+
+	rep, err := batch.Run(loaders, "NTSC", 10)
+	test.DemandSuccess(t, err)
+	test.DemandEquality(t, len(rep.Results), 2)
+	test.ExpectEquality(t, rep.Failures(), 0)
+
+	for i, res := range rep.Results {
+		test.ExpectEquality(t, res.Name, loaders[i].Name)
+		test.ExpectEquality(t, res.Status(), "ok")
+		test.ExpectSuccess(t, res.FrameHash != 0)
+	}
+
+	// the two cartridges draw different frames
+	test.ExpectSuccess(t, rep.Results[0].FrameHash != rep.Results[1].FrameHash)
+
+	// running the playlist again produces the same report
+	again, err := batch.Run(loaders, "NTSC", 10)
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, again.String(), rep.String())
+}
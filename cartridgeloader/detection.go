@@ -0,0 +1,66 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cartridgeloader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Candidate is a mapper that might be suitable for the cartridge data, along
+// with a score indicating how strong the evidence is for that mapper. The
+// score is between 0 and 100.
+type Candidate struct {
+	Mapping string
+	Score   int
+}
+
+// the level below which a detection is considered to be uncertain
+const uncertainConfidence = 75
+
+// Detection is the result of the automatic detection of the cartridge mapper.
+type Detection struct {
+	// the mapper that has been chosen
+	Mapping string
+
+	// the confidence in the chosen mapper. the value is between 0 and 100
+	Confidence int
+
+	// all mappers that were considered, including the chosen mapper, ordered
+	// by score with the highest score first
+	Candidates []Candidate
+}
+
+// IsUncertain returns true if the confidence in the chosen mapper is low
+// enough that the user should be asked to confirm the choice.
+func (d Detection) IsUncertain() bool {
+	return d.Mapping != "" && d.Confidence < uncertainConfidence
+}
+
+func (d Detection) String() string {
+	if d.Mapping == "" {
+		return "no detection"
+	}
+
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("%s (confidence %d%%)", d.Mapping, d.Confidence))
+	for _, c := range d.Candidates {
+		if c.Mapping != d.Mapping {
+			s.WriteString(fmt.Sprintf(" %s=%d", c.Mapping, c.Score))
+		}
+	}
+	return s.String()
+}
@@ -62,6 +62,14 @@ type Loader struct {
 	// empty string or "AUTO" indicates automatic fingerprinting
 	Mapping string
 
+	// the result of automatic fingerprinting. the result is filled in when the
+	// cartridge is attached to the emulation and is only meaningful if the
+	// Mapping field is empty or "AUTO"
+	//
+	// it is a pointer so that the result is shared between copies of the
+	// Loader
+	Detection *Detection
+
 	// startup bank of cartridge
 	Bank string
 
@@ -104,6 +112,21 @@ type Properties interface {
 // sentinal error for when it is attempted to create a loader with no filename
 var NoFilename = errors.New("no filename")
 
+// sentinal errors returned when a cartridge cannot be attached. the errors will
+// usually be wrapped with additional information so errors.Is() should be
+// used to test for them
+var (
+	// the mapper is unknown or could not be determined
+	UnsupportedMapper = errors.New("unsupported mapper")
+
+	// the amount of cartridge data is not suitable for the mapper
+	WrongROMSize = errors.New("wrong number of bytes in the cartridge data")
+
+	// the cartridge data is not a valid ELF file or is an ELF file that is not
+	// supported
+	MalformedELF = errors.New("malformed ELF")
+)
+
 // NewLoaderFromFilename is the preferred method of initialisation for the
 // Loader type when loading data from a filename.
 //
@@ -143,9 +166,10 @@ func NewLoaderFromFilename(filename string, mapping string, bank string, props P
 	}
 
 	ld := Loader{
-		Filename: filename,
-		Mapping:  mapping,
-		Bank:     bank,
+		Filename:  filename,
+		Mapping:   mapping,
+		Bank:      bank,
+		Detection: &Detection{},
 	}
 
 	// decide what mapping to use if the requested mapping is AUTO
@@ -219,15 +243,16 @@ func NewLoaderFromData(name string, data []byte, mapping string, bank string, pr
 	}
 
 	ld := Loader{
-		Filename: name,
-		Mapping:  mapping,
-		Bank:     bank,
-		preload:  preloadLimit(data),
-		data:     bytes.NewReader(data),
-		HashSHA1: fmt.Sprintf("%x", sha1.Sum(data)),
-		HashMD5:  fmt.Sprintf("%x", md5.Sum(data)),
-		size:     len(data),
-		embedded: true,
+		Filename:  name,
+		Mapping:   mapping,
+		Bank:      bank,
+		Detection: &Detection{},
+		preload:   preloadLimit(data),
+		data:      bytes.NewReader(data),
+		HashSHA1:  fmt.Sprintf("%x", sha1.Sum(data)),
+		HashMD5:   fmt.Sprintf("%x", md5.Sum(data)),
+		size:      len(data),
+		embedded:  true,
 	}
 
 	// decide on the name for this cartridge
@@ -200,6 +200,58 @@ type CartCoProcBus interface {
 	CoProcExecutionState() CoProcExecutionState
 }
 
+// CartCoProcPreferences is implemented by coprocessors that can apply changes
+// to the preferences immediately rather than waiting for the next execution
+type CartCoProcPreferences interface {
+	UpdatePrefs()
+}
+
+// CartCoProcStepper is implemented by coprocessors that can execute a single
+// instruction on request
+type CartCoProcStepper interface {
+	// execute a single instruction from the current PC address. returns the
+	// number of cycles consumed
+	StepInstruction() (float32, error)
+}
+
+// CartCoProcCycles is implemented by coprocessors that can report the number of
+// cycles consumed by the most recent execution of the coprocessor program
+type CartCoProcCycles interface {
+	// the number of cycles consumed by the most recent execution. the value
+	// is the same as the value used to synchronise the coprocessor with the
+	// VCS
+	LastRunCycles() float32
+}
+
+// CartCoProcSeeder is implemented by coprocessors that allow the general
+// registers to be set before the next execution of the coprocessor program
+type CartCoProcSeeder interface {
+	// set the general register to the value. returns an error if the
+	// register cannot be seeded
+	SeedRegister(register int, value uint32) error
+}
+
+// CartCoProcStatus is implemented by coprocessors with a status register
+// containing condition flags that can be inspected and changed
+type CartCoProcStatus interface {
+	// returns the condition flags as a string. an upper case letter indicates
+	// that the flag is set and a lower case letter that it is clear
+	StatusFlags() string
+
+	// sets or clears the named condition flag. returns an error if the flag
+	// is not recognised or cannot be changed
+	SetStatusFlag(flag string, set bool) error
+}
+
+// CartCoProcResetter is implemented by cartridge mappers where the
+// coprocessor can be reset independently of the rest of the VCS
+type CartCoProcResetter interface {
+	// reset the registers of the coprocessor to the values given by the
+	// cartridge's reset vectors. the memory of the coprocessor and the state
+	// of the VCS are not affected
+	ResetCoProc() error
+}
+
 // CartCoProcRelocatable is implemented by cartridge mappers where coprocessor
 // programs can be located anywhere in the coprcessor's memory
 type CartCoProcRelocatable interface {
@@ -250,7 +302,11 @@ type CartCoProcDeveloper interface {
 	// instructs developer implementation to accumulate profiling data. there
 	// can be many calls to profiling profiling for every call to start
 	// profiling
-	ProcessProfiling()
+	//
+	// the cycles argument is the number of coprocessor cycles consumed since
+	// the previous call to ProcessProfiling(). the value is always available,
+	// even when there is no profiling data
+	ProcessProfiling(cycles float32)
 
 	// called whenever the ARM yields to the VCS. it communicates the address of
 	// the most recent instruction and the reason for the yield
@@ -261,6 +317,21 @@ type CartCoProcDeveloper interface {
 type CoProcYield struct {
 	Type  CoProcYieldType
 	Error error
+
+	// the address of the most recently executed instruction at the time of
+	// the yield
+	Addr uint32
+}
+
+func (y CoProcYield) String() string {
+	t := string(y.Type)
+	if y.Type == YieldProgramEnded {
+		t = "Program Ended"
+	}
+	if y.Error != nil && y.Error.Error() != "" {
+		return fmt.Sprintf("%s at %08x: %s", t, y.Addr, y.Error.Error())
+	}
+	return fmt.Sprintf("%s at %08x", t, y.Addr)
 }
 
 // CoProcYieldType specifies the type of yield.
@@ -60,11 +60,15 @@ type Developer struct {
 
 	cart Cartridge
 
+	// the files used to load the source. see ReloadSource()
+	romFile string
+	elfFile string
+
 	// information about the source code to the program. can be nil.
 	// note that source is checked for nil outside the sourceLock. this is
 	// performance reasons (not need to acquire the lock if source is nil).
 	// however, this does mean we should be careful if reassigning the source
-	// field (but that doesn't happen)
+	// field (which only happens in ReloadSource())
 	source     *dwarf.Source
 	sourceLock sync.Mutex
 
@@ -82,6 +86,9 @@ type Developer struct {
 	breakNextInstruction bool
 	breakAddress         uint32
 
+	// breakpoints have been disabled by the user. see DisableBreakpoints()
+	breakpointsDisabled bool
+
 	// profiler instance. measures cycles counts for executed address
 	profiler coprocessor.CartCoProcProfiler
 
@@ -93,6 +100,16 @@ type Developer struct {
 
 	// keeps track of the previous line in profiling scan. see processProfiling()
 	prevProfileLine *dwarf.SourceLine
+
+	// number of coprocessor cycles consumed on each scanline. see
+	// CyclesPerScanline()
+	scanlineCycles     scanlineCycles
+	scanlineCyclesLock sync.Mutex
+
+	// called whenever the coprocessor makes an illegal memory access. see
+	// SetIllegalAccessHook()
+	illegalAccessHook     func(IllegalAccess)
+	illegalAccessHookLock sync.Mutex
 }
 
 // NewDeveloper is the preferred method of initialisation for the Developer type.
@@ -135,6 +152,8 @@ func (dev *Developer) AttachCartridge(cart Cartridge, romFile string, elfFile st
 		return nil
 	}
 	dev.cart = cart
+	dev.romFile = romFile
+	dev.elfFile = elfFile
 
 	// we always set the developer for the cartridge even if we have no source.
 	// some developer functions don't require source code to be useful
@@ -164,6 +183,61 @@ func (dev *Developer) AttachCartridge(cart Cartridge, romFile string, elfFile st
 	return nil
 }
 
+// ReloadSource loads the source for the attached cartridge again. Useful if the
+// ELF file has been rebuilt since the cartridge was attached.
+//
+// Profiling information is preserved for functions that exist in both the
+// previous and the new source. If the layout of the program has changed then
+// the breakpoints are cleared. Returns true if the layout has changed.
+func (dev *Developer) ReloadSource() (bool, error) {
+	if dev.cart == nil {
+		return false, fmt.Errorf("developer: no coprocessor to reload source for")
+	}
+
+	t := time.Now()
+
+	src, err := dwarf.NewSource(dev.romFile, dev.cart, dev.elfFile)
+	if err != nil {
+		return false, fmt.Errorf("developer: %w", err)
+	}
+
+	layoutChanged := dev.swapSource(src)
+	logger.Logf(logger.Allow, "developer", "DWARF reloaded in %s", time.Since(t))
+
+	return layoutChanged, nil
+}
+
+// swapSource replaces the current source with the new source, preserving
+// profiling information where possible. returns true if the layout of the
+// program has changed
+func (dev *Developer) swapSource(src *dwarf.Source) bool {
+	dev.sourceLock.Lock()
+	defer dev.sourceLock.Unlock()
+
+	var layoutChanged bool
+	if dev.source != nil {
+		layoutChanged = src.CopyProfiling(dev.source)
+	}
+
+	dev.source = src
+	dev.prevBreakpointCheck = nil
+	dev.prevProfileLine = nil
+
+	dev.callstackLock.Lock()
+	dev.callstack = callstack.NewCallStack()
+	dev.callstackLock.Unlock()
+
+	// breakpoints are address based and will not be meaningful if the layout
+	// of the program has changed
+	if layoutChanged {
+		dev.breakpointsLock.Lock()
+		dev.breakpoints = breakpoints.NewBreakpoints()
+		dev.breakpointsLock.Unlock()
+	}
+
+	return layoutChanged
+}
+
 // HighAddress implements the coprocessor.CartCoProcDeveloper interface.
 func (dev *Developer) HighAddress() uint32 {
 	if dev.source == nil {
@@ -217,6 +291,10 @@ const maxWaitUpdateTime = 60 // in frames
 
 // NewFrame implements the television.FrameTrigger interface.
 func (dev *Developer) NewFrame(frameInfo television.FrameInfo) error {
+	dev.scanlineCyclesLock.Lock()
+	dev.scanlineCycles.newFrame()
+	dev.scanlineCyclesLock.Unlock()
+
 	// only update FrameCycles if new frame was caused by a VSYNC or we've
 	// waited long enough since the last update
 	dev.framesSinceLastUpdate++
@@ -302,24 +380,108 @@ func (dev *Developer) OnYield(addr uint32, yield coprocessor.CoProcYield) {
 	})
 }
 
+// enableBreakpoints in the coprocessor as appropriate for the emulation state
+// and whether the user has disabled breakpoints
+func (dev *Developer) enableBreakpoints(state govern.State) {
+	if dev.cart == nil {
+		return
+	}
+
+	dev.breakpointsLock.Lock()
+	disabled := dev.breakpointsDisabled
+	dev.breakpointsLock.Unlock()
+
+	dev.cart.GetCoProcBus().GetCoProc().BreakpointsEnable(!disabled && state != govern.Rewinding)
+}
+
+// DisableBreakpoints prevents the coprocessor from halting on breakpoints.
+// The breakpoints themselves are not changed and will take effect again once
+// they have been re-enabled
+func (dev *Developer) DisableBreakpoints(disable bool) {
+	dev.breakpointsLock.Lock()
+	dev.breakpointsDisabled = disable
+	dev.breakpointsLock.Unlock()
+
+	dev.enableBreakpoints(dev.emulation.State())
+}
+
+// BreakpointsDisabled returns true if breakpoints have been disabled with
+// DisableBreakpoints()
+func (dev *Developer) BreakpointsDisabled() bool {
+	dev.breakpointsLock.Lock()
+	defer dev.breakpointsLock.Unlock()
+	return dev.breakpointsDisabled
+}
+
+// IllegalAccess describes an illegal memory access made by the coprocessor.
+type IllegalAccess struct {
+	Category faults.Category
+	Event    string
+
+	// the address being accessed and the address of the instruction making
+	// the access
+	AccessAddr uint32
+	PC         uint32
+
+	// the source line for the PC address. will be nil if there is no source
+	// or if the PC address does not have a source line
+	Line *dwarf.SourceLine
+}
+
+// SetIllegalAccessHook sets the function that is called the first time the
+// coprocessor makes a specific illegal memory access. The function is called
+// after the PC address has been resolved to a source line. This allows a
+// front-end to show the offending code immediately.
+//
+// The function is called from the emulation goroutine. A nil value removes
+// the hook.
+func (dev *Developer) SetIllegalAccessHook(hook func(IllegalAccess)) {
+	dev.illegalAccessHookLock.Lock()
+	defer dev.illegalAccessHookLock.Unlock()
+	dev.illegalAccessHook = hook
+}
+
 // MemoryFault implements the coprocessor.CartCoProcDeveloper interface.
 func (dev *Developer) MemoryFault(event string, fault faults.Category, instructionAddr uint32, accessAddr uint32) {
 	dev.faultsLock.Lock()
-	defer dev.faultsLock.Unlock()
+	first := dev.faults.NewEntry(fault, event, instructionAddr, accessAddr)
+	dev.faultsLock.Unlock()
 
-	dev.faults.NewEntry(fault, event, instructionAddr, accessAddr)
+	// the same fault will often be repeated many times. the source line and
+	// the hook only need to know about the first occurrence
+	if !first {
+		return
+	}
+
+	ill := IllegalAccess{
+		Category:   fault,
+		Event:      event,
+		AccessAddr: accessAddr,
+		PC:         instructionAddr,
+	}
+
+	dev.BorrowSource(func(src *dwarf.Source) {
+		if src == nil {
+			return
+		}
+		ill.Line = src.FindSourceLine(instructionAddr)
+		if ill.Line != nil {
+			ill.Line.IllegalAccess = true
+		}
+	})
+
+	dev.illegalAccessHookLock.Lock()
+	hook := dev.illegalAccessHook
+	dev.illegalAccessHookLock.Unlock()
+
+	if hook != nil {
+		hook(ill)
+	}
 }
 
 // SetEmulationState is called by the emulation whenever state changes
 func (dev *Developer) SetEmulationState(state govern.State) {
-	if dev.cart != nil {
-		switch state {
-		case govern.Rewinding:
-			dev.cart.GetCoProcBus().GetCoProc().BreakpointsEnable(false)
-		default:
-			dev.cart.GetCoProcBus().GetCoProc().BreakpointsEnable(true)
-		}
-	}
+	dev.enableBreakpoints(state)
 
 	dev.BorrowSource(func(src *dwarf.Source) {
 		dev.yieldStateLock.Lock()
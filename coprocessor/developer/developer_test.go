@@ -0,0 +1,348 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package developer
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/breakpoints"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/faults"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/profiling"
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm/architecture"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// newTestSource creates a minimal Source instance containing the named
+// functions. each function occupies a 16 byte range starting at the origin
+func newTestSource(origin uint64, names ...string) *dwarf.Source {
+	src := &dwarf.Source{
+		Functions: make(map[string]*dwarf.SourceFunction),
+	}
+	for _, n := range names {
+		src.Functions[n] = &dwarf.SourceFunction{
+			Name:  n,
+			Range: []dwarf.SourceRange{{Start: origin, End: origin + 15}},
+		}
+		src.FunctionNames = append(src.FunctionNames, n)
+		origin += 16
+	}
+	return src
+}
+
+func TestSourceReload(t *testing.T) {
+	dev := Developer{
+		breakpoints: breakpoints.NewBreakpoints(),
+	}
+	dev.swapSource(newTestSource(0x1000, "main", "update"))
+
+	dev.source.Functions["main"].NumCalls.Call(profiling.FocusScreen)
+	dev.source.Functions["main"].NumCalls.NewFrame(false)
+	dev.source.Functions["main"].Kernel = profiling.FocusScreen
+	dev.breakpoints.ToggleBreakpoint(&dwarf.SourceLine{BreakAddresses: []uint32{0x1000}})
+
+	// new function added at the end of the program. layout of existing
+	// functions is unchanged
+	layoutChanged := dev.swapSource(newTestSource(0x1000, "main", "update", "draw"))
+	test.ExpectFailure(t, layoutChanged)
+	test.ExpectEquality(t, len(dev.source.Functions), 3)
+	_, ok := dev.source.Functions["draw"]
+	test.ExpectSuccess(t, ok)
+
+	// profiling and breakpoints are preserved
+	test.ExpectEquality(t, dev.source.Functions["main"].NumCalls.Screen.FrameCount, float32(1))
+	test.ExpectEquality(t, dev.source.Functions["main"].Kernel, profiling.FocusScreen)
+	test.ExpectSuccess(t, dev.breakpoints.Check(0x1000))
+
+	// new function inserted at the start of the program changes the layout
+	layoutChanged = dev.swapSource(newTestSource(0x1000, "init", "main", "update", "draw"))
+	test.ExpectSuccess(t, layoutChanged)
+	test.ExpectEquality(t, len(dev.source.Functions), 4)
+	_, ok = dev.source.Functions["init"]
+	test.ExpectSuccess(t, ok)
+
+	// profiling for moved functions is not preserved and breakpoints are cleared
+	test.ExpectEquality(t, dev.source.Functions["main"].NumCalls.Screen.FrameCount, float32(0))
+	test.ExpectFailure(t, dev.breakpoints.Check(0x1000))
+}
+
+// testTV implements the TV interface and the environment.Television interface
+type testTV struct {
+	coords coords.TelevisionCoords
+}
+
+func (tv *testTV) GetSpecID() string                    { return "NTSC" }
+func (tv *testTV) GetReqSpecID() string                 { return "NTSC" }
+func (tv *testTV) SetRotation(_ specification.Rotation) {}
+
+func (tv *testTV) GetFrameInfo() television.FrameInfo {
+	return television.FrameInfo{}
+}
+
+func (tv *testTV) GetCoords() coords.TelevisionCoords {
+	return tv.coords
+}
+
+func (tv *testTV) GetLastSignal() signal.SignalAttributes {
+	return signal.SignalAttributes{}
+}
+
+// testEmulation implements the Emulation interface
+type testEmulation struct{}
+
+func (_ testEmulation) State() govern.State {
+	return govern.Running
+}
+
+// testMemory implements the arm.SharedMemory interface with a single block of
+// flash memory containing the program and a single block of SRAM for the stack
+type testMemory struct {
+	mmap  architecture.Map
+	flash []byte
+	sram  []byte
+}
+
+// the program is loaded into flash memory at this offset
+const testProgramOffset = 0x100
+
+func newTestMemory(mmap architecture.Map, program []uint16) *testMemory {
+	mem := &testMemory{
+		mmap:  mmap,
+		flash: make([]byte, 0x1000),
+		sram:  make([]byte, 0x1000),
+	}
+	for i, op := range program {
+		binary.LittleEndian.PutUint16(mem.flash[testProgramOffset+i*2:], op)
+	}
+	return mem
+}
+
+func (mem *testMemory) MapAddress(addr uint32, write bool, executing bool) (*[]byte, uint32) {
+	if addr >= mem.mmap.FlashOrigin && addr < mem.mmap.FlashOrigin+uint32(len(mem.flash)) {
+		return &mem.flash, mem.mmap.FlashOrigin
+	}
+	if addr >= mem.mmap.SRAMOrigin && addr < mem.mmap.SRAMOrigin+uint32(len(mem.sram)) {
+		return &mem.sram, mem.mmap.SRAMOrigin
+	}
+	return nil, 0
+}
+
+func (mem *testMemory) ResetVectors() (uint32, uint32, uint32) {
+	return mem.mmap.SRAMOrigin + uint32(len(mem.sram)) - 4, mem.mmap.FlashOrigin + uint32(len(mem.flash)) - 4, mem.mmap.FlashOrigin + testProgramOffset
+}
+
+func (mem *testMemory) IsExecutable(addr uint32) bool {
+	return addr >= mem.mmap.FlashOrigin && addr < mem.mmap.FlashOrigin+uint32(len(mem.flash))
+}
+
+func TestCyclesPerScanline(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv := &testTV{}
+	env, err := environment.NewEnvironment(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// a loop with a length that depends on the value of R0
+	mmap := architecture.NewMap(architecture.Harmony)
+	arm := arm.NewARM(env, mmap, newTestMemory(mmap, []uint16{
+		0x3801, // SUB R0, #1
+		0xd1fd, // BNE to the SUB instruction
+		0x4770, // BX LR
+	}), nil)
+
+	// the developer has no source and so there is no profiling data for the
+	// executed instructions
+	dev := NewDeveloper(testEmulation{}, tv)
+	arm.SetDeveloper(&dev)
+	test.ExpectEquality(t, dev.Profiling(), nil)
+
+	// run the coprocessor program with the loop length on the scanline. the
+	// program runs more than once on some scanlines
+	workload := []struct {
+		scanline int
+		loops    uint32
+	}{
+		{scanline: 10, loops: 10},
+		{scanline: 10, loops: 5},
+		{scanline: 40, loops: 100},
+		{scanline: 200, loops: 1},
+	}
+
+	expected := make(map[int]float32)
+	var total float32
+	for _, w := range workload {
+		tv.coords.Scanline = w.scanline
+		test.DemandSuccess(t, arm.SeedRegister(0, w.loops))
+
+		arm.StartProfiling()
+		yld, cycles := arm.Run()
+		test.DemandEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+		arm.ProcessProfiling()
+
+		expected[w.scanline] += cycles
+		total += cycles
+	}
+
+	// longer loops take more cycles
+	test.ExpectEquality(t, expected[40] > expected[10], true)
+	test.ExpectEquality(t, expected[10] > expected[200], true)
+
+	// no cycles are available until the end of the frame
+	var sum float32
+	for _, c := range dev.CyclesPerScanline() {
+		sum += c
+	}
+	test.ExpectEquality(t, sum, float32(0))
+
+	test.DemandSuccess(t, dev.NewFrame(television.FrameInfo{FromVSYNC: true}))
+
+	cycles := dev.CyclesPerScanline()
+	for scanline, c := range expected {
+		test.ExpectEquality(t, cycles[scanline], c)
+	}
+
+	// per scanline totals should be the same as the total for the workload
+	for _, c := range cycles {
+		sum += c
+	}
+	test.ExpectEquality(t, sum, total)
+
+	// cycles for the next frame start from zero
+	test.DemandSuccess(t, dev.NewFrame(television.FrameInfo{FromVSYNC: true}))
+	sum = 0
+	for _, c := range dev.CyclesPerScanline() {
+		sum += c
+	}
+	test.ExpectEquality(t, sum, float32(0))
+}
+
+// testCoProc records the most recent call to BreakpointsEnable()
+type testCoProc struct {
+	coprocessor.CartCoProc
+	breakpointsEnabled bool
+}
+
+func (cp *testCoProc) BreakpointsEnable(enable bool) {
+	cp.breakpointsEnabled = enable
+}
+
+// testCoProcBus implements the coprocessor.CartCoProcBus interface
+type testCoProcBus struct {
+	coprocessor.CartCoProcBus
+	coproc *testCoProc
+}
+
+func (bus *testCoProcBus) GetCoProc() coprocessor.CartCoProc {
+	return bus.coproc
+}
+
+// testCartridge implements the Cartridge interface
+type testCartridge struct {
+	bus *testCoProcBus
+}
+
+func (cart *testCartridge) GetCoProcBus() coprocessor.CartCoProcBus {
+	return cart.bus
+}
+
+func (cart *testCartridge) GetStaticBus() mapper.CartStaticBus {
+	return nil
+}
+
+func TestDisableBreakpoints(t *testing.T) {
+	coproc := &testCoProc{}
+	dev := NewDeveloper(testEmulation{}, &testTV{})
+	dev.cart = &testCartridge{bus: &testCoProcBus{coproc: coproc}}
+
+	dev.SetEmulationState(govern.Running)
+	test.ExpectSuccess(t, coproc.breakpointsEnabled)
+
+	dev.DisableBreakpoints(true)
+	test.ExpectSuccess(t, dev.BreakpointsDisabled())
+	test.ExpectFailure(t, coproc.breakpointsEnabled)
+
+	// breakpoints remain disabled after a change in emulation state
+	dev.SetEmulationState(govern.Paused)
+	test.ExpectFailure(t, coproc.breakpointsEnabled)
+	dev.SetEmulationState(govern.Running)
+	test.ExpectFailure(t, coproc.breakpointsEnabled)
+
+	dev.DisableBreakpoints(false)
+	test.ExpectFailure(t, dev.BreakpointsDisabled())
+	test.ExpectSuccess(t, coproc.breakpointsEnabled)
+
+	// breakpoints are always disabled while rewinding
+	dev.SetEmulationState(govern.Rewinding)
+	test.ExpectFailure(t, coproc.breakpointsEnabled)
+}
+
+func TestIllegalAccess(t *testing.T) {
+	dev := NewDeveloper(testEmulation{}, &testTV{})
+	dev.faults = faults.NewFaults()
+
+	dev.source = newTestSource(0x1000, "main")
+	main := dev.source.Functions["main"]
+	ln := &dwarf.SourceLine{Function: main, LineNumber: 10}
+	dev.source.LinesByAddress = map[uint64]*dwarf.SourceLine{
+		0x1004: ln,
+	}
+
+	var ill []IllegalAccess
+	dev.SetIllegalAccessHook(func(i IllegalAccess) {
+		ill = append(ill, i)
+	})
+
+	// illegal access from an instruction with a source line
+	dev.MemoryFault("read", faults.NullDereference, 0x1004, 0x00000000)
+	test.DemandEquality(t, len(ill), 1)
+	test.ExpectEquality(t, ill[0].PC, uint32(0x1004))
+	test.ExpectEquality(t, ill[0].AccessAddr, uint32(0x00000000))
+	test.ExpectEquality(t, ill[0].Category, faults.NullDereference)
+	test.ExpectEquality(t, ill[0].Line, ln)
+	test.ExpectSuccess(t, ln.IllegalAccess)
+
+	// illegal access from an instruction without a source line
+	dev.MemoryFault("write", faults.IllegalAddress, 0x1008, 0xdeadbeef)
+	test.DemandEquality(t, len(ill), 2)
+	test.ExpectEquality(t, ill[1].Line, nil)
+
+	// a repeat of an earlier access is counted but the hook is not called
+	dev.MemoryFault("read", faults.NullDereference, 0x1004, 0x00000000)
+	test.ExpectEquality(t, len(ill), 2)
+
+	// both accesses have been logged as faults
+	dev.BorrowFaults(func(flt *faults.Faults) {
+		test.DemandEquality(t, len(flt.Log), 2)
+		test.ExpectEquality(t, flt.Log[0].Count, 2)
+		test.ExpectEquality(t, flt.Log[1].Count, 1)
+	})
+
+	// removing the hook
+	dev.SetIllegalAccessHook(nil)
+	dev.MemoryFault("read", faults.NullDereference, 0x100c, 0x00000000)
+	test.ExpectEquality(t, len(ill), 2)
+}
@@ -0,0 +1,64 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"debug/dwarf"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// newTestCompileUnit creates a compile unit with the name and producer
+// attributes and the specified number of children. an empty string means that
+// the attribute is not present
+func newTestCompileUnit(name string, producer string, numChildren int) *compileUnit {
+	e := &dwarf.Entry{
+		Tag: dwarf.TagCompileUnit,
+	}
+	if name != "" {
+		e.Field = append(e.Field, dwarf.Field{Attr: dwarf.AttrName, Val: name, Class: dwarf.ClassString})
+	}
+	if producer != "" {
+		e.Field = append(e.Field, dwarf.Field{Attr: dwarf.AttrProducer, Val: producer, Class: dwarf.ClassString})
+	}
+
+	cu := &compileUnit{
+		unit:     e,
+		children: make(map[dwarf.Offset]*dwarf.Entry),
+	}
+	for i := range numChildren {
+		cu.children[dwarf.Offset(i+1)] = &dwarf.Entry{Offset: dwarf.Offset(i + 1)}
+	}
+
+	return cu
+}
+
+func TestListCompileUnits(t *testing.T) {
+	src := &Source{}
+	src.compileUnits = append(src.compileUnits,
+		newTestCompileUnit("main.c", "GNU C17 12.2.1 -mthumb -O2", 12),
+		newTestCompileUnit("sprites.c", "GNU C17 12.2.1 -mthumb -O0 -g", 3),
+		newTestCompileUnit("", "", 0),
+	)
+
+	s := &strings.Builder{}
+	src.ListCompileUnits(s)
+	test.ExpectEquality(t, s.String(), "main.c (GNU C17 12.2.1 -mthumb -O2) 12 children\n"+
+		"sprites.c (GNU C17 12.2.1 -mthumb -O0 -g) 3 children\n"+
+		"unnamed (unknown producer) 0 children\n")
+}
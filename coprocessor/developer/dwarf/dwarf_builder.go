@@ -519,11 +519,14 @@ func (bld *build) resolveVariableDeclaration(v *dwarf.Entry, t *dwarf.Entry, src
 	}
 	files := lr.Files()
 
-	file := src.Files[files[declFile].Name]
-	if file == nil {
+	if src.Files[files[declFile].Name] == nil {
+		return nil, nil
+	}
+	varb.DeclLine, err = src.sourceLine(files[declFile].Name, declLine)
+	if err != nil {
+		logger.Logf(logger.Allow, "dwarf", "variable %s: %v", varb.Name, err)
 		return nil, nil
 	}
-	varb.DeclLine = file.Content.Lines[declLine-1]
 
 	return &varb, nil
 }
@@ -987,9 +990,14 @@ func (bld *build) buildFunctions(src *Source, addressAdjustment uint64) error {
 			return nil, fmt.Errorf("no file named %s", filename)
 		}
 
+		declLine, err := src.sourceLine(filename, linenum)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %w", name, err)
+		}
+
 		fn := &SourceFunction{
 			Name:             name,
-			DeclLine:         src.Files[filename].Content.Lines[linenum-1],
+			DeclLine:         declLine,
 			framebaseLoclist: framebase,
 		}
 
@@ -1146,7 +1154,8 @@ func (bld *build) buildFunctions(src *Source, addressAdjustment uint64) error {
 
 				fn, err := resolve(av)
 				if err != nil {
-					return err
+					logger.Log(logger.Allow, "dwarf", err)
+					return nil
 				}
 
 				// start/end address of function
@@ -0,0 +1,102 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Sentinal error to indicate that the program in the ELF file is not the
+// program in the cartridge. The DWARF data will not be reliable in this case
+var MismatchedELF = errors.New("ELF does not match the cartridge")
+
+// the name of the section that contains the build-id (if it exists)
+const buildIDSection = ".note.gnu.build-id"
+
+// peeker is the part of the coprocessor interface required by checkELF()
+type peeker interface {
+	Peek(addr uint32) (uint32, bool)
+}
+
+// elfCheckSection is a section of the ELF file that should also be found in
+// the cartridge memory
+type elfCheckSection struct {
+	name   string
+	origin uint32
+	data   []byte
+}
+
+// elfCheckSections returns the list of sections in the ELF file that should be
+// found in the cartridge memory. origins are adjusted by the address
+// adjustment value in the same way as the origins used for disassembly
+func elfCheckSections(ef *elf.File, addressAdjustment uint64) ([]elfCheckSection, error) {
+	var sections []elfCheckSection
+
+	for _, sec := range ef.Sections {
+		// the build-id section is included if it is loaded into memory
+		// alongside the program, which means that it will also be in the
+		// cartridge
+		if sec.Flags&elf.SHF_EXECINSTR != elf.SHF_EXECINSTR {
+			if sec.Name != buildIDSection || sec.Flags&elf.SHF_ALLOC != elf.SHF_ALLOC {
+				continue // for loop
+			}
+		}
+
+		data, err := sec.Data()
+		if err != nil {
+			return nil, fmt.Errorf("dwarf: %w", err)
+		}
+
+		sections = append(sections, elfCheckSection{
+			name:   sec.Name,
+			origin: uint32(sec.Addr + addressAdjustment),
+			data:   data,
+		})
+	}
+
+	return sections, nil
+}
+
+// checkELF compares the sections from the ELF file with the cartridge memory.
+// It returns an error wrapping MismatchedELF if the data is different
+func checkELF(sections []elfCheckSection, mem peeker) error {
+	for _, sec := range sections {
+		// read section data from cartridge memory. coprocessor memory is
+		// read a word at a time so the last word may include bytes that are
+		// not part of the section
+		cart := make([]byte, 0, len(sec.data)+3)
+		for addr := sec.origin; addr < sec.origin+uint32(len(sec.data)); addr += 4 {
+			v, ok := mem.Peek(addr)
+			if !ok {
+				return fmt.Errorf("%w: %s section at %08x is outside of cartridge memory", MismatchedELF, sec.name, addr)
+			}
+			cart = binary.LittleEndian.AppendUint32(cart, v)
+		}
+		cart = cart[:len(sec.data)]
+
+		elfHash := crc32.ChecksumIEEE(sec.data)
+		cartHash := crc32.ChecksumIEEE(cart)
+		if elfHash != cartHash {
+			return fmt.Errorf("%w: %s section has checksum %08x but cartridge has %08x", MismatchedELF, sec.name, elfHash, cartHash)
+		}
+	}
+
+	return nil
+}
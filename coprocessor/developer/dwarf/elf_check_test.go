@@ -0,0 +1,70 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// testMemory implements the peeker interface
+type testMemory struct {
+	origin uint32
+	data   []byte
+}
+
+func (mem testMemory) Peek(addr uint32) (uint32, bool) {
+	addr -= mem.origin
+	if addr >= uint32(len(mem.data)-3) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(mem.data[addr:]), true
+}
+
+func TestCheckELF(t *testing.T) {
+	mem := testMemory{
+		origin: 0x20000800,
+		data:   []byte{0x00, 0xb5, 0x01, 0x20, 0x00, 0xbd, 0x70, 0x47, 0xff, 0xff, 0xff, 0xff},
+	}
+
+	// section matches the cartridge memory. the length of the section is not
+	// a multiple of four
+	sections := []elfCheckSection{
+		{name: ".text", origin: 0x20000800, data: []byte{0x00, 0xb5, 0x01, 0x20, 0x00, 0xbd}},
+	}
+	test.ExpectSuccess(t, checkELF(sections, mem))
+
+	// section from a different build of the program
+	sections = []elfCheckSection{
+		{name: ".text", origin: 0x20000800, data: []byte{0x00, 0xb5, 0x02, 0x20, 0x00, 0xbd}},
+	}
+	err := checkELF(sections, mem)
+	test.ExpectFailure(t, err)
+	test.ExpectSuccess(t, errors.Is(err, MismatchedELF))
+	test.ExpectSuccess(t, strings.Contains(err.Error(), ".text section has checksum"))
+
+	// section outside of cartridge memory
+	sections = []elfCheckSection{
+		{name: ".text", origin: 0x20001000, data: []byte{0x00, 0xb5, 0x01, 0x20}},
+	}
+	err = checkELF(sections, mem)
+	test.ExpectSuccess(t, errors.Is(err, MismatchedELF))
+	test.ExpectSuccess(t, strings.Contains(err.Error(), "outside of cartridge memory"))
+}
@@ -0,0 +1,68 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// testCoProc implements the Peek() function of the coprocessor.CartCoProc
+// interface. the other functions are not required
+type testCoProc struct {
+	coprocessor.CartCoProc
+	mem testMemory
+}
+
+func (cp testCoProc) Peek(addr uint32) (uint32, bool) {
+	return cp.mem.Peek(addr)
+}
+
+func TestGlobals(t *testing.T) {
+	cp := testCoProc{
+		mem: testMemory{
+			origin: 0x40000000,
+			data:   []byte{0x2a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+	}
+
+	sec, err := newLoclistSection([]uint8{0x00}, binary.LittleEndian, cp)
+	test.DemandSuccess(t, err)
+
+	varb := &SourceVariable{
+		Name: "score",
+		Type: &SourceType{Name: "int", Size: 4},
+	}
+	varb.loclist, err = sec.newLoclistFromSingleOperator(varb, []uint8{0x03, 0x00, 0x00, 0x00, 0x40})
+	test.DemandSuccess(t, err)
+
+	src := &Source{}
+	src.SortedGlobals.Variables = append(src.SortedGlobals.Variables, varb)
+	src.UpdateGlobalVariables()
+
+	s := &strings.Builder{}
+	src.ListGlobals(s)
+	test.ExpectEquality(t, s.String(), "40000000 score int 4 bytes\n")
+
+	test.ExpectEquality(t, src.FindGlobal("lives"), (*SourceVariable)(nil))
+	g := src.FindGlobal("score")
+	test.DemandEquality(t, g, varb)
+	test.ExpectEquality(t, g.Value(), uint32(42))
+}
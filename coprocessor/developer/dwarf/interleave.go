@@ -0,0 +1,70 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"slices"
+)
+
+// findInterleavedLines sets the Interleaved field of every source line that
+// has an instruction from another source line between its first and last
+// instruction
+func findInterleavedLines(src *Source) {
+	for _, ln := range src.SortedLines.Lines {
+		ln.Interleaved = false
+		if len(ln.Instruction) < 2 {
+			continue // for loop
+		}
+
+		ins := slices.Clone(ln.Instruction)
+		slices.SortFunc(ins, func(a, b *SourceInstruction) int {
+			return int(a.Addr) - int(b.Addr)
+		})
+
+		for i := 1; i < len(ins) && !ln.Interleaved; i++ {
+			for addr := uint64(ins[i-1].Addr) + uint64(ins[i-1].size); addr < uint64(ins[i].Addr); addr++ {
+				if o, ok := src.LinesByAddress[addr]; ok && o != ln {
+					ln.Interleaved = true
+					break // for loop
+				}
+			}
+		}
+	}
+}
+
+// InterleavedLines returns the source lines that have the Interleaved field
+// set, in the same order as SortedLines. Also returns the interleaved lines as a
+// percentage of all lines that have instructions.
+func (src *Source) InterleavedLines() ([]*SourceLine, float32) {
+	var lines []*SourceLine
+	var count int
+
+	for _, ln := range src.SortedLines.Lines {
+		if len(ln.Instruction) == 0 {
+			continue // for loop
+		}
+		count++
+		if ln.Interleaved {
+			lines = append(lines, ln)
+		}
+	}
+
+	if count == 0 {
+		return lines, 0
+	}
+
+	return lines, float32(len(lines)) / float32(count) * 100
+}
@@ -0,0 +1,61 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestInterleavedLines(t *testing.T) {
+	sf := &SourceFile{Filename: "main.c", ShortFilename: "main.c"}
+	for i := range 4 {
+		sf.Content.Lines = append(sf.Content.Lines, &SourceLine{File: sf, LineNumber: i + 1})
+	}
+
+	src := &Source{
+		Instructions:   make(map[uint64]*SourceInstruction),
+		LinesByAddress: make(map[uint64]*SourceLine),
+	}
+	src.SortedLines.Lines = sf.Content.Lines
+
+	// the instructions for line 1 are split by an instruction from line 2.
+	// line 4 has no instructions and so is not included in the percentage
+	for i, n := range []int{1, 2, 1, 3, 3} {
+		ln := sf.Content.Lines[n-1]
+		ins := &SourceInstruction{
+			Addr: uint32(0x1000 + i*2),
+			size: 2,
+			Line: ln,
+		}
+		ln.Instruction = append(ln.Instruction, ins)
+		src.Instructions[uint64(ins.Addr)] = ins
+		src.LinesByAddress[uint64(ins.Addr)] = ln
+	}
+
+	findInterleavedLines(src)
+
+	lines, pct := src.InterleavedLines()
+	test.ExpectEquality(t, fmt.Sprintf("%.2f", pct), "33.33")
+	test.DemandEquality(t, len(lines), 1)
+	test.ExpectEquality(t, lines[0].LineNumber, 1)
+
+	test.ExpectEquality(t, sf.Content.Lines[1].Interleaved, false)
+	test.ExpectEquality(t, sf.Content.Lines[2].Interleaved, false)
+	test.ExpectEquality(t, sf.Content.Lines[3].Interleaved, false)
+}
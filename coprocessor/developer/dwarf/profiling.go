@@ -16,6 +16,10 @@
 package dwarf
 
 import (
+	"fmt"
+	"io"
+	"slices"
+
 	"github.com/jetsetilly/gopher2600/coprocessor/developer/profiling"
 )
 
@@ -65,3 +69,90 @@ func (src *Source) ResetProfiling() {
 	}
 	src.Cycles.Reset()
 }
+
+// CopyProfiling copies the function profiling information from another Source
+// instance. Only functions with the same name and the same address ranges in
+// both instances are copied. Useful when the source has been reloaded.
+//
+// Returns true if the layout of any function with the same name has changed.
+func (src *Source) CopyProfiling(prev *Source) bool {
+	var layoutChanged bool
+
+	for name, fn := range src.Functions {
+		p, ok := prev.Functions[name]
+		if !ok {
+			continue // for loop
+		}
+
+		if !sameRanges(fn.Range, p.Range) {
+			layoutChanged = true
+			continue // for loop
+		}
+
+		fn.Kernel = p.Kernel
+		fn.Cycles = p.Cycles
+		fn.CumulativeCycles = p.CumulativeCycles
+		fn.NumCalls = p.NumCalls
+		fn.CyclesPerCall = p.CyclesPerCall
+		fn.OptimisedCallStack = p.OptimisedCallStack
+	}
+
+	return layoutChanged
+}
+
+// WriteFunctionProfile writes the functions that used the most coprocessor
+// cycles in the most recent frame to io.Writer. Each function is listed with
+// its share of the cycles used by the program during the frame. Functions that
+// did not execute in the frame are not listed.
+//
+// No more than top functions will be listed.
+func (src *Source) WriteFunctionProfile(output io.Writer, top int) {
+	sorted := SortedFunctions{
+		Functions: slices.Clone(src.SortedFunctions.Functions),
+	}
+	sorted.Sort(SortFunctionsFrameCycles, false, true, true, profiling.FocusAll)
+
+	for i, fn := range sorted.Functions {
+		if i >= top {
+			break
+		}
+		cy := fn.Cycles.Overall.CyclesProgram
+		if !cy.FrameValid {
+			break
+		}
+		output.Write([]byte(fmt.Sprintf("%6.2f%%  %s\n", cy.FrameLoad, fn.Name)))
+	}
+}
+
+// WriteLineProfile is the same as WriteFunctionProfile but for individual lines
+// of source. Each line is listed with the short filename, line number and the
+// function the line is part of.
+func (src *Source) WriteLineProfile(output io.Writer, top int) {
+	sorted := SortedLines{
+		Lines: slices.Clone(src.SortedLines.Lines),
+	}
+	sorted.Sort(SortLinesFrameCycles, true, true, true, profiling.FocusAll)
+
+	for i, ln := range sorted.Lines {
+		if i >= top {
+			break
+		}
+		cy := ln.Cycles.Overall.CyclesProgram
+		if !cy.FrameValid {
+			break
+		}
+		output.Write([]byte(fmt.Sprintf("%6.2f%%  %s:%d  %s\n", cy.FrameLoad, ln.File.ShortFilename, ln.LineNumber, ln.Function.Name)))
+	}
+}
+
+func sameRanges(a []SourceRange, b []SourceRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/profiling"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestWriteProfile(t *testing.T) {
+	sf := newTestFile("/home/user/project/main.c", "main.c", 3)
+
+	src := &dwarf.Source{
+		Functions: make(map[string]*dwarf.SourceFunction),
+	}
+
+	// one line for each function. the idle function does not execute
+	workload := []struct {
+		name   string
+		cycles float32
+	}{
+		{name: "main", cycles: 100},
+		{name: "kernel", cycles: 300},
+		{name: "idle", cycles: 0},
+	}
+
+	for i, w := range workload {
+		fn := &dwarf.SourceFunction{Name: w.name, DeclLine: sf.Content.Lines[i]}
+		ln := sf.Content.Lines[i]
+		ln.Function = fn
+
+		src.Functions[fn.Name] = fn
+		src.SortedFunctions.Functions = append(src.SortedFunctions.Functions, fn)
+		src.SortedLines.Lines = append(src.SortedLines.Lines, ln)
+
+		if w.cycles > 0 {
+			fn.Cycles.Cycle(w.cycles, profiling.FocusAll)
+			ln.Cycles.Cycle(w.cycles, profiling.FocusAll)
+			src.Cycles.Cycle(w.cycles, profiling.FocusAll)
+		}
+	}
+
+	src.NewFrame(false)
+
+	s := &strings.Builder{}
+	src.WriteFunctionProfile(s, 10)
+	test.ExpectEquality(t, s.String(), " 75.00%  kernel\n 25.00%  main\n")
+
+	s.Reset()
+	src.WriteLineProfile(s, 10)
+	test.ExpectEquality(t, s.String(), " 75.00%  main.c:2  kernel\n 25.00%  main.c:1  main\n")
+
+	// the number of entries can be limited
+	s.Reset()
+	src.WriteFunctionProfile(s, 1)
+	test.ExpectEquality(t, s.String(), " 75.00%  kernel\n")
+
+	// writing the profile does not change the order of the sorted lists
+	test.ExpectEquality(t, src.SortedFunctions.Functions[0].Name, "main")
+}
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -65,6 +66,10 @@ type Source struct {
 	debugLoc   *loclistSection
 	debugFrame *frameSection
 
+	// the value added to the addresses in the DWARF data. the same value is
+	// added to the addresses in a symbol file
+	addressAdjustment uint64
+
 	// source is compiled with optimisation
 	Optimised bool
 
@@ -86,6 +91,10 @@ type Source struct {
 	FilesByShortname map[string]*SourceFile
 	ShortFilenames   []string
 
+	// files listed in the DWARF data that could not be read. most likely
+	// because the file is not on disk
+	MissingFiles []string
+
 	// functions found in the compile units
 	Functions     map[string]*SourceFunction
 	FunctionNames []string
@@ -181,6 +190,10 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 	var fromCartridge bool
 	var err error
 
+	// path to the ELF file. different to the elfFile argument because it is
+	// set even when the ELF file has been found automatically
+	elfPath := elfFile
+
 	// open ELF file
 	if elfFile != "" {
 		ef, err = elf.Open(elfFile)
@@ -189,7 +202,7 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 		}
 
 	} else {
-		ef, fromCartridge = findELF(romFile)
+		ef, elfPath, fromCartridge = findELF(romFile)
 		if ef == nil {
 			return nil, fmt.Errorf("dwarf: compiled ELF file not found")
 		}
@@ -347,6 +360,24 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 	} else {
 		logger.Logf(logger.Allow, "dwarf", "using address adjustment: %#x", int(addressAdjustment))
 	}
+	src.addressAdjustment = addressAdjustment
+
+	// check that the ELF file is for the program in the cartridge. this isn't
+	// required if the ELF file came from the cartridge
+	//
+	// a mismatch isn't fatal because the DWARF data might still be useful but
+	// it is a common cause of confusing debugging sessions so we warn about it
+	if !fromCartridge {
+		sections, err := elfCheckSections(ef, addressAdjustment)
+		if err != nil {
+			return nil, err
+		}
+		err = checkELF(sections, bus.GetCoProc())
+		if err != nil {
+			logger.Logf(logger.Allow, "dwarf", "warning: %v", err)
+			logger.Log(logger.Allow, "dwarf", "DWARF data is probably for a different version of the program")
+		}
+	}
 
 	// disassemble every word in the ELF file using the cartridge coprocessor interface
 	//
@@ -438,6 +469,9 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 					sf, err := readSourceFile(f.Name, src.path, &src.AllLines)
 					if err != nil {
 						logger.Log(logger.Allow, "dwarf", err)
+						if !slices.Contains(src.MissingFiles, f.Name) {
+							src.MissingFiles = append(src.MissingFiles, f.Name)
+						}
 					} else {
 						src.Files[sf.Filename] = sf
 						src.Filenames = append(src.Filenames, sf.Filename)
@@ -537,12 +571,16 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 	// sort list of filenames and functions
 	sort.Strings(src.Filenames)
 	sort.Strings(src.ShortFilenames)
+	sort.Strings(src.MissingFiles)
 
 	// sort lines by function and number. sort is stable so we can do this in
 	// two passes
 	src.SortedLines.Sort(SortLinesFunction, false, false, false, profiling.FocusAll)
 	src.SortedLines.Sort(SortLinesNumber, false, false, false, profiling.FocusAll)
 
+	// lines with instructions that have been reordered by the compiler
+	findInterleavedLines(src)
+
 	// sorted functions
 	src.SortedFunctions.Sort(SortFunctionsName, false, false, false, profiling.FocusAll)
 	sort.Strings(src.FunctionNames)
@@ -565,6 +603,13 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 	// update global variables
 	src.UpdateGlobalVariables()
 
+	// add symbols from a symbol file accompanying the ELF file. symbols
+	// supplement the DWARF data and are useful for hand-written assembly
+	err = loadSymbolFile(src, elfPath)
+	if err != nil {
+		logger.Logf(logger.Allow, "dwarf", "%v", err)
+	}
+
 	// determine highest address occupied by the program
 	findHighAddress(src)
 
@@ -576,10 +621,28 @@ func NewSource(romFile string, cart Cartridge, elfFile string) (*Source, error)
 	logger.Logf(logger.Allow, "dwarf", "%d global variables", len(src.SortedGlobals.Variables))
 	logger.Logf(logger.Allow, "dwarf", "%d local variable (loclists)", len(src.SortedLocals.Variables))
 	logger.Logf(logger.Allow, "dwarf", "high address (%08x)", src.HighAddress)
+	if _, pct := src.InterleavedLines(); pct > 0 {
+		logger.Logf(logger.Allow, "dwarf", "%.02f%% of source lines have interleaved instructions", pct)
+	}
 
 	return src, nil
 }
 
+// sourceLine returns the SourceLine for the line number in the named file. An
+// error is returned if the file has not been loaded or if the line number is
+// outside the range of the file. The latter indicates that the file on disk is
+// not the file that was used to create the DWARF data.
+func (src *Source) sourceLine(filename string, linenum int64) (*SourceLine, error) {
+	sf := src.Files[filename]
+	if sf == nil {
+		return nil, fmt.Errorf("file not available: %s", filename)
+	}
+	if linenum < 1 || linenum > int64(sf.Content.Len()) {
+		return nil, fmt.Errorf("line %d is outside of %s (%d lines). source is out of sync with DWARF data", linenum, filename, sf.Content.Len())
+	}
+	return sf.Content.Lines[linenum-1], nil
+}
+
 func allocateSourceLines(src *Source, dwrf *dwarf.Data, addressAdjustment uint64) error {
 	for _, e := range src.compileUnits {
 		// the source line we're working on
@@ -615,10 +678,6 @@ func allocateSourceLines(src *Source, dwrf *dwarf.Data, addressAdjustment uint64
 				logger.Logf(logger.Allow, "dwarf", "file not available for linereader: %s", le.File.Name)
 				break // line entry for loop. will continue with compile unit loop
 			}
-			if le.Line-1 > src.Files[le.File.Name].Content.Len() {
-				logger.Logf(logger.Allow, "dwarf", "current source is unrelated to ELF/DWARF data (number of lines)")
-				break // line entry for loop. will continue with compile unit loop
-			}
 
 			// reset start address value if necessary
 			if resetStartAddr {
@@ -659,8 +718,13 @@ func allocateSourceLines(src *Source, dwrf *dwarf.Data, addressAdjustment uint64
 				}
 			}
 
-			// prepare for next iteration
-			ln = src.Files[le.File.Name].Content.Lines[le.Line-1]
+			// prepare for next iteration. a line entry that does not fit the
+			// source file is skipped. addresses covered by the entry will not
+			// be attributed to any source line
+			ln, err = src.sourceLine(le.File.Name, int64(le.Line))
+			if err != nil {
+				logger.Log(logger.Allow, "dwarf", err)
+			}
 			startAddr = endAddr
 
 			// if this is the end of a sequence then the start address must be
@@ -900,6 +964,67 @@ func addFunctionStubs(src *Source, ef *elf.File) error {
 	return nil
 }
 
+// ListFiles writes the list of source files to io.Writer. The short and long
+// filename of each file is listed along with the number of lines in the file.
+// Files listed in the DWARF data that could not be read are listed after the
+// other files.
+func (src *Source) ListFiles(output io.Writer) {
+	for _, fn := range src.Filenames {
+		sf := src.Files[fn]
+		output.Write([]byte(fmt.Sprintf("%s (%s) %d lines\n", sf.ShortFilename, sf.Filename, len(sf.Content.Lines))))
+	}
+	for _, fn := range src.MissingFiles {
+		output.Write([]byte(fmt.Sprintf("missing: %s\n", fn)))
+	}
+}
+
+// ListCompileUnits writes the list of compile units to io.Writer. The name of
+// each compile unit is listed along with the producer string (the compiler and
+// the options used to build the unit) and the number of child entries.
+func (src *Source) ListCompileUnits(output io.Writer) {
+	for _, cu := range src.compileUnits {
+		name := "unnamed"
+		if fld := cu.unit.AttrField(dwarf.AttrName); fld != nil {
+			if s, ok := fld.Val.(string); ok {
+				name = s
+			}
+		}
+
+		producer := "unknown producer"
+		if fld := cu.unit.AttrField(dwarf.AttrProducer); fld != nil {
+			if s, ok := fld.Val.(string); ok {
+				producer = s
+			}
+		}
+
+		output.Write([]byte(fmt.Sprintf("%s (%s) %d children\n", name, producer, len(cu.children))))
+	}
+}
+
+// ListGlobals writes the list of global variables to io.Writer. The name,
+// address, type name and size of each variable is listed. Variables should be
+// updated with UpdateGlobalVariables() before calling this function.
+func (src *Source) ListGlobals(output io.Writer) {
+	for _, varb := range src.SortedGlobals.Variables {
+		addr := "--------"
+		if a, ok := varb.Address(); ok {
+			addr = fmt.Sprintf("%08x", a)
+		}
+		output.Write([]byte(fmt.Sprintf("%s %s %s %d bytes\n", addr, varb.Name, varb.Type.Name, varb.Type.Size)))
+	}
+}
+
+// FindGlobal returns the global variable with the specified name. Returns nil
+// if there is no global variable with that name.
+func (src *Source) FindGlobal(name string) *SourceVariable {
+	for _, varb := range src.SortedGlobals.Variables {
+		if varb.Name == name {
+			return varb
+		}
+	}
+	return nil
+}
+
 func readSourceFile(filename string, path string, all *AllSourceLines) (*SourceFile, error) {
 	var err error
 
@@ -945,11 +1070,11 @@ func readSourceFile(filename string, path string, all *AllSourceLines) (*SourceF
 	return &fl, nil
 }
 
-func findELF(romFile string) (*elf.File, bool) {
+func findELF(romFile string) (*elf.File, string, bool) {
 	// try the ROM file itself. it might be an ELF file
 	ef, err := elf.Open(romFile)
 	if err == nil {
-		return ef, true
+		return ef, romFile, true
 	}
 
 	// the file is not an ELF file so the remainder of the function will work
@@ -973,14 +1098,15 @@ func findELF(romFile string) (*elf.File, bool) {
 
 	for _, p := range subpaths {
 		for _, f := range filenames {
-			ef, err = elf.Open(filepath.Join(pathToROM, p, f))
+			fn := filepath.Join(pathToROM, p, f)
+			ef, err = elf.Open(fn)
 			if err == nil {
-				return ef, false
+				return ef, fn, false
 			}
 		}
 	}
 
-	return nil, false
+	return nil, "", false
 }
 
 // FindSourceLine returns line entry for the address. Returns nil if the
@@ -989,6 +1115,33 @@ func (src *Source) FindSourceLine(addr uint32) *SourceLine {
 	return src.LinesByAddress[uint64(addr)]
 }
 
+// SourceContext writes the source line for the address to io.Writer, along
+// with the specified number of lines either side of it. The line for the
+// address is marked with a > character. Returns false if the address has no
+// source line.
+func (src *Source) SourceContext(output io.Writer, addr uint32, context int) bool {
+	ln := src.FindSourceLine(addr)
+	if ln == nil || ln.IsStub() || ln.File == nil {
+		return false
+	}
+
+	lines := ln.File.Content.Lines
+	idx := ln.LineNumber - 1
+	start := max(0, idx-context)
+	end := min(len(lines), idx+context+1)
+
+	output.Write([]byte(fmt.Sprintf("%s\n", ln.String())))
+	for _, l := range lines[start:end] {
+		marker := " "
+		if l.LineNumber == ln.LineNumber {
+			marker = ">"
+		}
+		output.Write([]byte(fmt.Sprintf("%s %4d %s\n", marker, l.LineNumber, l.PlainContent)))
+	}
+
+	return true
+}
+
 // UpdateGlobalVariables using the current state of the emulated coprocessor.
 // Local variables are updated when coprocessor yields (see OnYield() function)
 func (src *Source) UpdateGlobalVariables() {
@@ -0,0 +1,66 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestSourceLineMismatch(t *testing.T) {
+	src := &Source{
+		Files: make(map[string]*SourceFile),
+	}
+
+	// main.c matches the DWARF data but sprites.h has been edited since the
+	// ELF file was built and is now shorter than the DWARF data expects
+	for _, f := range []struct {
+		name     string
+		numLines int
+	}{
+		{name: "main.c", numLines: 10},
+		{name: "sprites.h", numLines: 2},
+	} {
+		sf := &SourceFile{Filename: f.name}
+		for i := range f.numLines {
+			sf.Content.Lines = append(sf.Content.Lines, &SourceLine{File: sf, LineNumber: i + 1})
+		}
+		src.Files[f.name] = sf
+	}
+
+	// line entries outside of the mismatched file are errors
+	_, err := src.sourceLine("sprites.h", 5)
+	test.ExpectFailure(t, err)
+	_, err = src.sourceLine("sprites.h", 0)
+	test.ExpectFailure(t, err)
+
+	// as are line entries for files that are not loaded
+	_, err = src.sourceLine("missing.c", 1)
+	test.ExpectFailure(t, err)
+
+	// lines that are in range are still usable in both files
+	ln, err := src.sourceLine("sprites.h", 2)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, ln.LineNumber, 2)
+
+	for i := range 10 {
+		ln, err := src.sourceLine("main.c", int64(i+1))
+		test.ExpectSuccess(t, err)
+		test.ExpectEquality(t, ln.LineNumber, i+1)
+		test.ExpectEquality(t, ln.File.Filename, "main.c")
+	}
+}
@@ -0,0 +1,91 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// newTestFile creates a SourceFile with the specified number of lines
+func newTestFile(filename string, shortFilename string, numLines int) *dwarf.SourceFile {
+	sf := &dwarf.SourceFile{
+		Filename:      filename,
+		ShortFilename: shortFilename,
+	}
+	for i := range numLines {
+		sf.Content.Lines = append(sf.Content.Lines, &dwarf.SourceLine{
+			File:       sf,
+			LineNumber: i + 1,
+		})
+	}
+	return sf
+}
+
+func TestListFiles(t *testing.T) {
+	src := &dwarf.Source{
+		Files: make(map[string]*dwarf.SourceFile),
+	}
+
+	for _, sf := range []*dwarf.SourceFile{
+		newTestFile("/home/user/project/main.c", "main.c", 3),
+		newTestFile("/home/user/project/sprites.h", "sprites.h", 1),
+	} {
+		src.Files[sf.Filename] = sf
+		src.Filenames = append(src.Filenames, sf.Filename)
+	}
+	src.MissingFiles = append(src.MissingFiles, "/usr/lib/gcc/arm-none-eabi/include/stdint.h")
+
+	expected, err := os.ReadFile("testdata/listfiles.txt")
+	test.DemandSuccess(t, err)
+
+	s := &strings.Builder{}
+	src.ListFiles(s)
+	test.ExpectEquality(t, s.String(), string(expected))
+}
+
+func TestSourceContext(t *testing.T) {
+	sf := newTestFile("/home/user/project/main.c", "main.c", 10)
+	fn := &dwarf.SourceFunction{Name: "main", DeclLine: sf.Content.Lines[0]}
+	for i, ln := range sf.Content.Lines {
+		ln.PlainContent = fmt.Sprintf("line %d", i+1)
+		ln.Function = fn
+	}
+
+	src := &dwarf.Source{
+		LinesByAddress: map[uint64]*dwarf.SourceLine{
+			0x1000: sf.Content.Lines[1],
+		},
+	}
+
+	s := &strings.Builder{}
+	test.ExpectSuccess(t, src.SourceContext(s, 0x1000, 3))
+	test.ExpectEquality(t, s.String(), "main.c:2 [main]\n"+
+		"     1 line 1\n"+
+		">    2 line 2\n"+
+		"     3 line 3\n"+
+		"     4 line 4\n"+
+		"     5 line 5\n")
+
+	s.Reset()
+	test.ExpectFailure(t, src.SourceContext(s, 0x2000, 3))
+	test.ExpectEquality(t, s.Len(), 0)
+}
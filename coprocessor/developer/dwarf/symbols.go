@@ -0,0 +1,264 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/profiling"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// the extension of a symbol file that accompanies an ELF file. the symbol
+// file has the same name as the ELF file but with this extension in place of
+// the ELF file's extension
+const symbolFileExtension = ".sym"
+
+// the largest symbol size that will be accepted. a function symbol occupies an
+// entry in the line table for every address in its range so an unreasonably
+// large size would take a very long time to add
+const symbolMaxSize = 0x10000
+
+// symbolFilename returns the name of the symbol file that would accompany the
+// ELF file
+func symbolFilename(elfFile string) string {
+	return strings.TrimSuffix(elfFile, filepath.Ext(elfFile)) + symbolFileExtension
+}
+
+// loadSymbolFile adds the symbols in the symbol file that accompanies the ELF
+// file. it is not an error for the symbol file to not exist
+func loadSymbolFile(src *Source, elfFile string) error {
+	fn := symbolFilename(elfFile)
+
+	f, err := os.Open(fn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	n, err := src.AddSymbols(f)
+	if err != nil {
+		return err
+	}
+
+	logger.Logf(logger.Allow, "dwarf", "%d symbols added from %s", n, fn)
+
+	return nil
+}
+
+// AddSymbols reads symbols from io.Reader and adds them to the function and
+// global variable tables. Symbols that are already known to the DWARF data are
+// ignored, as are symbols of a type that isn't supported. Returns the number
+// of symbols added.
+//
+// The format of the symbols is the same as the output of the nm tool, with or
+// without symbol sizes (the -S flag). For example:
+//
+//	20000100 T main
+//	20000180 00000020 T update
+//	40000000 00000004 D score
+//
+// Symbol types T and t are treated as functions. Symbol types D, d, B, b, R
+// and r are treated as global variables. Blank lines and lines beginning
+// with a # are ignored.
+//
+// The address of each symbol is adjusted in the same way as the addresses in
+// the DWARF data. Symbols with a size larger than 64KB are ignored, as are
+// global variables if the ELF file has no location section.
+func (src *Source) AddSymbols(r io.Reader) (int, error) {
+	var n int
+
+	scanner := bufio.NewScanner(r)
+	var lineNum int
+	for scanner.Scan() {
+		lineNum++
+
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+
+		flds := strings.Fields(s)
+
+		var addr uint64
+		var size uint64
+		var typ string
+		var name string
+		var err error
+
+		switch len(flds) {
+		case 3:
+			typ = flds[1]
+			name = flds[2]
+		case 4:
+			size, err = strconv.ParseUint(flds[1], 16, 32)
+			if err != nil {
+				return n, fmt.Errorf("symbols: line %d: %w", lineNum, err)
+			}
+			typ = flds[2]
+			name = flds[3]
+		default:
+			return n, fmt.Errorf("symbols: line %d: wrong number of fields", lineNum)
+		}
+
+		addr, err = strconv.ParseUint(flds[0], 16, 32)
+		if err != nil {
+			return n, fmt.Errorf("symbols: line %d: %w", lineNum, err)
+		}
+		addr += src.addressAdjustment
+
+		if size > symbolMaxSize {
+			logger.Logf(logger.Allow, "dwarf", "symbols: line %d: size of %s is too large (%#x)", lineNum, name, size)
+			continue // for loop
+		}
+
+		switch typ {
+		case "T", "t":
+			if src.addSymbolFunction(name, addr, size) {
+				n++
+			}
+		case "D", "d", "B", "b", "R", "r":
+			ok, err := src.addSymbolVariable(name, addr, size)
+			if err != nil {
+				return n, fmt.Errorf("symbols: line %d: %w", lineNum, err)
+			}
+			if ok {
+				n++
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return n, fmt.Errorf("symbols: %w", err)
+	}
+
+	// sort functions, lines and globals in the same way as NewSource()
+	src.SortedFunctions.Sort(SortFunctionsName, false, false, false, profiling.FocusAll)
+	sort.Strings(src.FunctionNames)
+	src.SortedLines.Sort(SortLinesFunction, false, false, false, profiling.FocusAll)
+	src.SortedLines.Sort(SortLinesNumber, false, false, false, profiling.FocusAll)
+	sort.Sort(src.SortedGlobals)
+	src.UpdateGlobalVariables()
+
+	return n, nil
+}
+
+// addSymbolFunction adds a stub function for the symbol. returns false if the
+// function was not added because a function with that name already exists or
+// because the address range is already covered by another function
+func (src *Source) addSymbolFunction(name string, addr uint64, size uint64) bool {
+	if _, ok := src.Functions[name]; ok {
+		return false
+	}
+
+	// align address
+	// TODO: this is a bit of ARM specific knowledge that should be removed
+	addr &= 0xfffffffe
+
+	rng := SourceRange{
+		Start: addr,
+		End:   addr,
+	}
+	if size > 0 {
+		rng.End = addr + size - 1
+	}
+
+	// the function is not added if any part of the range is already known
+	for a := rng.Start; a <= rng.End; a++ {
+		if _, ok := src.LinesByAddress[a]; ok {
+			return false
+		}
+	}
+
+	stubFn := &SourceFunction{
+		Name: name,
+	}
+	stubFn.Range = append(stubFn.Range, rng)
+	stubFn.DeclLine = CreateStubLine(stubFn)
+
+	// each address in the range shares the same stub line
+	ln := CreateStubLine(stubFn)
+	for a := rng.Start; a <= rng.End; a++ {
+		src.LinesByAddress[a] = ln
+	}
+	src.SortedLines.Lines = append(src.SortedLines.Lines, ln)
+
+	src.Functions[name] = stubFn
+	src.FunctionNames = append(src.FunctionNames, name)
+	src.SortedFunctions.Functions = append(src.SortedFunctions.Functions, stubFn)
+
+	return true
+}
+
+// addSymbolVariable adds a global variable for the symbol. the type of the
+// variable is an unsigned integer of the symbol size. if the size isn't 8bit or
+// 16bit then the variable is assumed to be 32bit. returns false if the variable
+// was not added because a global variable with that name or address already
+// exists or because there is no location section
+func (src *Source) addSymbolVariable(name string, addr uint64, size uint64) (bool, error) {
+	if src.debugLoc == nil {
+		logger.Logf(logger.Allow, "dwarf", "symbols: no location section for variable %s", name)
+		return false, nil
+	}
+
+	if _, ok := src.GlobalsByAddress[addr]; ok {
+		return false, nil
+	}
+	if src.FindGlobal(name) != nil {
+		return false, nil
+	}
+
+	var typ *SourceType
+	switch size {
+	case 1:
+		typ = &SourceType{Name: "uint8_t", Size: 1}
+	case 2:
+		typ = &SourceType{Name: "uint16_t", Size: 2}
+	default:
+		typ = &SourceType{Name: "uint32_t", Size: 4}
+	}
+
+	varb := &SourceVariable{
+		Name: name,
+		Type: typ,
+	}
+
+	// location of the variable is a DW_OP_addr operation
+	expr := make([]uint8, 5)
+	expr[0] = 0x03
+	src.debugLoc.byteOrder.PutUint32(expr[1:], uint32(addr))
+
+	var err error
+	varb.loclist, err = src.debugLoc.newLoclistFromSingleOperator(varb, expr)
+	if err != nil {
+		return false, err
+	}
+
+	src.GlobalsByAddress[addr] = varb
+	src.SortedGlobals.Variables = append(src.SortedGlobals.Variables, varb)
+
+	return true, nil
+}
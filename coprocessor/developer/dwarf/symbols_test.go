@@ -0,0 +1,145 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package dwarf
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestSymbolFilename(t *testing.T) {
+	test.ExpectEquality(t, symbolFilename("main/bin/armcode.elf"), "main/bin/armcode.sym")
+	test.ExpectEquality(t, symbolFilename("custom2"), "custom2.sym")
+}
+
+func TestAddSymbols(t *testing.T) {
+	cp := testCoProc{
+		mem: testMemory{
+			origin: 0x40000000,
+			data:   []byte{0x2a, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00},
+		},
+	}
+
+	sec, err := newLoclistSection([]uint8{0x00}, binary.LittleEndian, cp)
+	test.DemandSuccess(t, err)
+
+	// source with a single function that is known from the DWARF data
+	mainFn := &SourceFunction{Name: "main"}
+	mainFn.Range = append(mainFn.Range, SourceRange{Start: 0x20000100, End: 0x2000010f})
+	mainLn := CreateStubLine(mainFn)
+
+	src := &Source{
+		debugLoc:         sec,
+		Functions:        map[string]*SourceFunction{"main": mainFn},
+		FunctionNames:    []string{"main"},
+		GlobalsByAddress: make(map[uint64]*SourceVariable),
+		LinesByAddress:   make(map[uint64]*SourceLine),
+	}
+	src.SortedFunctions.Functions = append(src.SortedFunctions.Functions, mainFn)
+	for a := uint64(0x20000100); a <= 0x2000010f; a++ {
+		src.LinesByAddress[a] = mainLn
+	}
+
+	symbols := `# supplementary symbols
+20000100 00000010 T main
+20000111 00000008 T update
+20000120 t kernel
+40000000 00000004 D score
+40000004 00000001 b lives
+40000008 00000004 N debug
+`
+
+	n, err := src.AddSymbols(strings.NewReader(symbols))
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, n, 4)
+
+	// the DWARF function is unchanged
+	test.ExpectEquality(t, src.Functions["main"], mainFn)
+
+	// functions from the symbol file
+	fn, ok := src.Functions["update"]
+	test.DemandSuccess(t, ok)
+	test.ExpectEquality(t, fn.IsStub(), true)
+	test.ExpectEquality(t, fn.Range[0].Start, uint64(0x20000110))
+	test.ExpectEquality(t, fn.Range[0].End, uint64(0x20000117))
+	test.ExpectEquality(t, src.FindSourceLine(0x20000114).Function, fn)
+
+	fn, ok = src.Functions["kernel"]
+	test.DemandSuccess(t, ok)
+	test.ExpectEquality(t, src.FindSourceLine(0x20000120).Function, fn)
+
+	test.ExpectEquality(t, len(src.Functions), len(src.FunctionNames))
+	test.ExpectEquality(t, strings.Join(src.FunctionNames, " "), "kernel main update")
+
+	// global variables from the symbol file
+	g := src.FindGlobal("score")
+	test.DemandSuccess(t, g != nil)
+	test.ExpectEquality(t, g.Type.Size, 4)
+	test.ExpectEquality(t, g.Value(), uint32(42))
+	test.ExpectEquality(t, src.GlobalsByAddress[0x40000000], g)
+
+	g = src.FindGlobal("lives")
+	test.DemandSuccess(t, g != nil)
+	test.ExpectEquality(t, g.Type.Size, 1)
+	test.ExpectEquality(t, g.Value(), uint32(7))
+
+	// unsupported symbol types are ignored
+	test.ExpectEquality(t, src.FindGlobal("debug"), (*SourceVariable)(nil))
+
+	// adding the same symbols a second time adds nothing
+	n, err = src.AddSymbols(strings.NewReader(symbols))
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, n, 0)
+
+	// badly formed symbol file
+	_, err = src.AddSymbols(strings.NewReader("20000200 main\n"))
+	test.ExpectFailure(t, err)
+}
+
+func TestAddSymbolsAdjusted(t *testing.T) {
+	// source with no DWARF data and no location section. the program has been
+	// relocated to 0x20000000
+	src := &Source{
+		addressAdjustment: 0x20000000,
+		Functions:         make(map[string]*SourceFunction),
+		GlobalsByAddress:  make(map[uint64]*SourceVariable),
+		LinesByAddress:    make(map[uint64]*SourceLine),
+	}
+
+	symbols := `00000100 00000010 T main
+00000200 ffffffff T huge
+40000000 00000004 D score
+`
+
+	// the variable is skipped because there is no location section and the
+	// function with the very large size is also skipped
+	n, err := src.AddSymbols(strings.NewReader(symbols))
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, n, 1)
+
+	fn, ok := src.Functions["main"]
+	test.DemandSuccess(t, ok)
+	test.ExpectEquality(t, fn.Range[0].Start, uint64(0x20000100))
+	test.ExpectEquality(t, fn.Range[0].End, uint64(0x2000010f))
+	test.ExpectEquality(t, src.FindSourceLine(0x20000104).Function, fn)
+
+	_, ok = src.Functions["huge"]
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, src.FindGlobal("score"), (*SourceVariable)(nil))
+}
@@ -147,9 +147,17 @@ type SourceLine struct {
 	// what are the addresses to use for breakpoints
 	BreakAddresses []uint32
 
+	// whether the instructions for this line are interleaved with the
+	// instructions of another line. this is usually the result of the compiler
+	// reordering instructions during optimisation
+	Interleaved bool
+
 	// whether this source line has been responsible for a likely bug (eg. illegal access of memory)
 	Bug bool
 
+	// whether this source line has made an illegal memory access
+	IllegalAccess bool
+
 	// profiling for the line
 	Cycles profiling.Cycles
 
@@ -88,8 +88,9 @@ func (flt Faults) WriteLog(w io.Writer) {
 	}
 }
 
-// NewEntry adds a new entry to the list of faults
-func (flt *Faults) NewEntry(category Category, event string, instructionAddr uint32, accessAddr uint32) {
+// NewEntry adds a new entry to the list of faults. Returns true if this is the
+// first time the fault has been seen
+func (flt *Faults) NewEntry(category Category, event string, instructionAddr uint32, accessAddr uint32) bool {
 	key := fmt.Sprintf("%08x%08x", instructionAddr, accessAddr)
 
 	e, found := flt.entries[key]
@@ -115,4 +116,6 @@ func (flt *Faults) NewEntry(category Category, event string, instructionAddr uin
 	if category == StackCollision {
 		flt.HasStackCollision = true
 	}
+
+	return !found
 }
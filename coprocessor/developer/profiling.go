@@ -56,7 +56,13 @@ func (dev *Developer) StartProfiling() {
 }
 
 // ProcessProfiling implements the coprocessor.CartCoProcDeveloper interface.
-func (dev *Developer) ProcessProfiling() {
+func (dev *Developer) ProcessProfiling(cycles float32) {
+	// add cycles to the scanline on which the coprocessor finished executing.
+	// this does not require source
+	dev.scanlineCyclesLock.Lock()
+	dev.scanlineCycles.cycle(dev.tv.GetCoords().Scanline, cycles)
+	dev.scanlineCyclesLock.Unlock()
+
 	if dev.source == nil {
 		return
 	}
@@ -0,0 +1,54 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package developer
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// scanlineCycles records the number of coprocessor cycles consumed on each
+// scanline of the television frame
+type scanlineCycles struct {
+	// cycles for the frame currently being drawn
+	current [specification.AbsoluteMaxScanlines]float32
+
+	// cycles for the most recently completed frame
+	previous [specification.AbsoluteMaxScanlines]float32
+}
+
+// cycle adds the number of cycles to the scanline
+func (sc *scanlineCycles) cycle(scanline int, cycles float32) {
+	if scanline < 0 || scanline >= len(sc.current) {
+		return
+	}
+	sc.current[scanline] += cycles
+}
+
+// newFrame commits the cycles for the current frame
+func (sc *scanlineCycles) newFrame() {
+	sc.previous = sc.current
+	clear(sc.current[:])
+}
+
+// CyclesPerScanline returns the number of coprocessor cycles consumed on each
+// scanline of the most recently completed frame. Cycles for a single execution
+// of the coprocessor program are assigned to the scanline on which the
+// execution finished.
+func (dev *Developer) CyclesPerScanline() [specification.AbsoluteMaxScanlines]float32 {
+	dev.scanlineCyclesLock.Lock()
+	defer dev.scanlineCyclesLock.Unlock()
+	return dev.scanlineCycles.previous
+}
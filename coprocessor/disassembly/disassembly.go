@@ -16,6 +16,8 @@
 package disassembly
 
 import (
+	"container/list"
+	"slices"
 	"sort"
 	"sync"
 
@@ -45,8 +47,17 @@ type Disassembly struct {
 	cart Cartridge
 
 	disasm DisasmEntries
+
+	// the maximum number of entries in the disassembly. always MaxEntries
+	// except during testing
+	maxEntries int
 }
 
+// MaxEntries is the maximum number of entries in the coprocessor disassembly.
+// The limit is fixed. When the number of entries exceeds the limit the least
+// recently executed entries are removed.
+const MaxEntries = 65536
+
 // DisasmEntries contains all the current information about the coprocessor
 // disassembly, including whether disassembly is currently enabled.
 type DisasmEntries struct {
@@ -59,13 +70,20 @@ type DisasmEntries struct {
 	LastExecutionSummary coprocessor.CartCoProcDisasmSummary
 
 	LastStart coords.TelevisionCoords
+
+	// the entries ordered by how recently they were executed. the most
+	// recently executed entry is at the front of the list. the elements map
+	// is indexed by the same key as the Entries map
+	recency  *list.List
+	elements map[string]*list.Element
 }
 
 // NewDisassembly returns a new Coprocessor instance if cartridge implements the
 // coprocessor bus.
 func NewDisassembly(tv TV) Disassembly {
 	return Disassembly{
-		tv: tv,
+		tv:         tv,
+		maxEntries: MaxEntries,
 	}
 }
 
@@ -79,6 +97,8 @@ func (dsm *Disassembly) AttachCartridge(cart Cartridge) {
 		LastExecution: make([]coprocessor.CartCoProcDisasmEntry, 0, 1024),
 		Entries:       make(map[string]coprocessor.CartCoProcDisasmEntry),
 		Keys:          make([]string, 0, 1024),
+		recency:       list.New(),
+		elements:      make(map[string]*list.Element),
 	}
 
 	if cart != nil && cart.GetCoProcBus() != nil {
@@ -174,15 +194,38 @@ func (dsm *Disassembly) End(summary coprocessor.CartCoProcDisasmSummary) {
 
 	for _, entry := range dsm.disasm.LastExecution {
 		key := entry.Key()
-		if _, ok := dsm.disasm.Entries[key]; !ok {
+		if e, ok := dsm.disasm.elements[key]; ok {
+			dsm.disasm.recency.MoveToFront(e)
+		} else {
 			dsm.disasm.Keys = append(dsm.disasm.Keys, key)
+			dsm.disasm.elements[key] = dsm.disasm.recency.PushFront(key)
 		}
 		dsm.disasm.Entries[key] = entry
 	}
 
+	dsm.evict()
 	sort.Strings(dsm.disasm.Keys)
 }
 
+// evict the least recently executed entries until the number of entries is
+// no more than the maximum. must be called from inside the critical section
+func (dsm *Disassembly) evict() {
+	if dsm.disasm.recency == nil || dsm.disasm.recency.Len() <= dsm.maxEntries {
+		return
+	}
+
+	for dsm.disasm.recency.Len() > dsm.maxEntries {
+		key := dsm.disasm.recency.Remove(dsm.disasm.recency.Back()).(string)
+		delete(dsm.disasm.elements, key)
+		delete(dsm.disasm.Entries, key)
+	}
+
+	dsm.disasm.Keys = slices.DeleteFunc(dsm.disasm.Keys, func(key string) bool {
+		_, ok := dsm.disasm.Entries[key]
+		return !ok
+	})
+}
+
 // BorrowDisasm will lock the DisasmEntries structure for the durction of the
 // supplied function, which will be executed with the disasm structure as an
 // argument.
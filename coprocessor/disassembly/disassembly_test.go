@@ -0,0 +1,97 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// testTV implements the TV interface
+type testTV struct{}
+
+func (_ testTV) AdjCoords(_ television.Adj, _ int) coords.TelevisionCoords {
+	return coords.TelevisionCoords{}
+}
+
+// testEntry implements the coprocessor.CartCoProcDisasmEntry interface
+type testEntry uint32
+
+func (e testEntry) String() string { return e.Key() }
+func (e testEntry) Key() string    { return fmt.Sprintf("%08x", uint32(e)) }
+func (e testEntry) CSV() string    { return e.Key() }
+func (e testEntry) Size() int      { return 2 }
+
+// execute simulates a single execution of a coprocessor program in which the
+// instructions at the addresses are executed
+func execute(dsm *Disassembly, addrs ...uint32) {
+	dsm.Start()
+	for _, a := range addrs {
+		dsm.Step(testEntry(a))
+	}
+	dsm.End(nil)
+}
+
+func TestEviction(t *testing.T) {
+	dsm := NewDisassembly(testTV{})
+	dsm.AttachCartridge(nil)
+	dsm.disasm.Enabled = true
+	dsm.maxEntries = 10
+
+	// fill the disassembly with more entries than the maximum
+	for a := range uint32(8) {
+		execute(&dsm, 0x1000+a*2)
+	}
+	execute(&dsm, 0x2000, 0x2002, 0x2004, 0x2006)
+
+	test.ExpectEquality(t, len(dsm.disasm.Entries), 10)
+	test.ExpectEquality(t, len(dsm.disasm.Keys), 10)
+
+	// the two oldest entries have been evicted
+	_, ok := dsm.disasm.Entries[testEntry(0x1000).Key()]
+	test.ExpectFailure(t, ok)
+	_, ok = dsm.disasm.Entries[testEntry(0x1002).Key()]
+	test.ExpectFailure(t, ok)
+	_, ok = dsm.disasm.Entries[testEntry(0x1004).Key()]
+	test.ExpectSuccess(t, ok)
+
+	// executing an existing entry makes it the most recent and so it survives
+	// the next eviction
+	execute(&dsm, 0x1004)
+	execute(&dsm, 0x3000, 0x3002)
+	_, ok = dsm.disasm.Entries[testEntry(0x1004).Key()]
+	test.ExpectSuccess(t, ok)
+	_, ok = dsm.disasm.Entries[testEntry(0x1006).Key()]
+	test.ExpectFailure(t, ok)
+	_, ok = dsm.disasm.Entries[testEntry(0x1008).Key()]
+	test.ExpectFailure(t, ok)
+	_, ok = dsm.disasm.Entries[testEntry(0x3002).Key()]
+	test.ExpectSuccess(t, ok)
+
+	// keys are sorted and match the entries
+	test.ExpectEquality(t, len(dsm.disasm.Keys), len(dsm.disasm.Entries))
+	for i, k := range dsm.disasm.Keys {
+		_, ok := dsm.disasm.Entries[k]
+		test.ExpectSuccess(t, ok)
+		if i > 0 {
+			test.ExpectSuccess(t, dsm.disasm.Keys[i-1] < k)
+		}
+	}
+}
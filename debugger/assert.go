@@ -0,0 +1,69 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AssertionFailed is returned by the ASSERT command when the machine state does
+// not match the expected value. A failed assertion causes a script to end
+// immediately.
+var AssertionFailed = errors.New("assertion failed")
+
+// assert compares the named machine component against the expected value.
+// Valid targets are the CPU registers (A, X, Y, SP, PC) and the television
+// coordinates (FRAME, SCANLINE, CLOCK).
+func (dbg *Debugger) assert(target string, expected int) error {
+	var actual int
+	var format string
+
+	switch target {
+	case "A":
+		actual = int(dbg.vcs.CPU.A.Value())
+		format = "%#02x"
+	case "X":
+		actual = int(dbg.vcs.CPU.X.Value())
+		format = "%#02x"
+	case "Y":
+		actual = int(dbg.vcs.CPU.Y.Value())
+		format = "%#02x"
+	case "SP":
+		actual = int(dbg.vcs.CPU.SP.Value())
+		format = "%#02x"
+	case "PC":
+		actual = int(dbg.vcs.CPU.PC.Value())
+		format = "%#04x"
+	case "FRAME":
+		actual = dbg.vcs.TV.GetCoords().Frame
+		format = "%d"
+	case "SCANLINE":
+		actual = dbg.vcs.TV.GetCoords().Scanline
+		format = "%d"
+	case "CLOCK":
+		actual = dbg.vcs.TV.GetCoords().Clock
+		format = "%d"
+	default:
+		return fmt.Errorf("cannot assert value of %s", target)
+	}
+
+	if actual != expected {
+		return fmt.Errorf("%w: %s is "+format+" and not "+format, AssertionFailed, target, actual, expected)
+	}
+
+	return nil
+}
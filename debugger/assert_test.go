@@ -0,0 +1,68 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger"
+)
+
+func (trm *mockTerm) testAssert() {
+	// set register and assert that it has been set. there should be no output
+	trm.sndInput("CPU SET A 0x5a")
+	trm.cmpOutput("")
+	trm.sndInput("ASSERT A 0x5a")
+	trm.cmpOutput("")
+
+	// the instruction being stepped over does not change the A register
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("ASSERT A 0x5a")
+	trm.cmpOutput("")
+
+	// television coordinates
+	trm.sndInput("ASSERT FRAME 0")
+	trm.cmpOutput("")
+}
+
+// run the debugger with the supplied initialisation script. returns the error
+// from StartInDebugMode()
+func runInitScript(t *testing.T, script string) error {
+	t.Helper()
+
+	opts := debugger.CommandLineOptions{
+		InitScript: newTestFile(t, "assert.script", []byte(script)),
+	}
+
+	// the QUIT command will only be read if the script succeeds
+	return startDebugger(t, opts, "", func(trm *mockTerm) {
+		trm.sndInput("QUIT")
+	})
+}
+
+func TestDebugger_assertScript(t *testing.T) {
+	err := runInitScript(t, "CPU SET A 0x5a\nASSERT A 0x5a\n")
+	if err != nil {
+		t.Errorf("unexpected error from script: %v", err)
+	}
+
+	err = runInitScript(t, "CPU SET A 0x5a\nASSERT A 0x00\nCPU SET A 0x00\n")
+	if !errors.Is(err, debugger.AssertionFailed) {
+		t.Errorf("expected assertion failure from script: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// chipLog records every change to a TIA or RIOT register for a single frame
+// and writes it to a CSV file. logging starts at the beginning of the next
+// frame and ends when the television starts the following frame
+type chipLog struct {
+	dbg   *Debugger
+	f     *os.File
+	w     *csv.Writer
+	frame int
+}
+
+// startChipLog creates the CSV file and attaches the chip log to the emulation.
+// any existing chip log is ended
+func (dbg *Debugger) startChipLog(filename string) error {
+	// only one chip log can be active at once
+	dbg.endChipLog()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("chip log: %w", err)
+	}
+
+	lg := &chipLog{
+		dbg:   dbg,
+		f:     f,
+		w:     csv.NewWriter(f),
+		frame: dbg.vcs.TV.GetCoords().Frame + 1,
+	}
+
+	err = lg.w.Write([]string{"frame", "scanline", "clock", "register", "value"})
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("chip log: %w", err)
+	}
+
+	dbg.chipLog = lg
+	dbg.printLine(terminal.StyleFeedback, "logging chip writes for frame %d to %s", lg.frame, filename)
+
+	return nil
+}
+
+// ObserveChipWrite implements the chipbus.WriteObserver interface. chip writes
+// are forwarded to the chip log by the debugger's chipObserver
+func (lg *chipLog) ObserveChipWrite(reg chipbus.ChangedRegister) {
+	coords := lg.dbg.vcs.TV.GetCoords()
+	if coords.Frame != lg.frame {
+		return
+	}
+
+	err := lg.w.Write([]string{
+		fmt.Sprintf("%d", coords.Frame),
+		fmt.Sprintf("%d", coords.Scanline),
+		fmt.Sprintf("%d", coords.Clock),
+		string(reg.Register),
+		fmt.Sprintf("%#02x", reg.Value),
+	})
+	if err != nil {
+		lg.dbg.printLine(terminal.StyleError, "chip log: %s", err.Error())
+		lg.end()
+	}
+}
+
+// newFrame should be called whenever the television starts a new frame. the
+// chip log is ended once the frame being logged has completed
+func (lg *chipLog) newFrame(info television.FrameInfo) {
+	if info.FrameNum >= lg.frame {
+		lg.end()
+	}
+}
+
+// endChipLog ends the active chip log, if there is one. the CSV file will be
+// incomplete if the frame being logged has not yet completed
+func (dbg *Debugger) endChipLog() {
+	if dbg.chipLog != nil {
+		dbg.chipLog.end()
+	}
+}
+
+// end detaches the chip log from the emulation and closes the CSV file
+func (lg *chipLog) end() {
+	lg.dbg.chipLog = nil
+
+	lg.w.Flush()
+	err := lg.w.Error()
+	if err == nil {
+		err = lg.f.Close()
+	} else {
+		lg.f.Close()
+	}
+
+	if err != nil {
+		lg.dbg.printLine(terminal.StyleError, "chip log: %s", err.Error())
+		return
+	}
+
+	if lg.dbg.vcs.TV.GetCoords().Frame <= lg.frame {
+		lg.dbg.printLine(terminal.StyleFeedback, "chip writes for incomplete frame %d logged to %s", lg.frame, lg.f.Name())
+		return
+	}
+	lg.dbg.printLine(terminal.StyleFeedback, "chip writes for frame %d logged to %s", lg.frame, lg.f.Name())
+}
@@ -0,0 +1,139 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// chipLogROM returns a ROM that draws frames of 259 scanlines
+func chipLogROM(t *testing.T) string {
+	t.Helper()
+
+	// a frame of 259 scanlines with a change to the background color at the
+	// end of the frame
+	//
+	//	LDA #$02
+	//	STA VSYNC
+	//	STA WSYNC
+	//	STA WSYNC
+	//	STA WSYNC
+	//	LDA #$00
+	//	STA VSYNC
+	//	LDX #$00
+	//	STA WSYNC
+	//	INX
+	//	BNE $F010
+	//	LDA #$0E
+	//	STA COLUBK
+	//	JMP $F000
+	return newTestROM(t, []byte{
+		0xa9, 0x02, 0x85, 0x00, 0x85, 0x02, 0x85, 0x02, 0x85, 0x02,
+		0xa9, 0x00, 0x85, 0x00, 0xa2, 0x00, 0x85, 0x02, 0xe8, 0xd0,
+		0xfb, 0xa9, 0x0e, 0x85, 0x09, 0x4c, 0x00, 0xf0,
+	})
+}
+
+func (trm *mockTerm) testChipLog() {
+	trm.insertCartridge(chipLogROM(trm.t))
+
+	fn := filepath.Join(trm.t.TempDir(), "chiplog.csv")
+	trm.sndInput("TIA LOG " + fn)
+	trm.cmpOutput("logging chip writes for frame 1 to " + fn)
+
+	trm.sndInput("BREAK FRAME 3")
+	trm.cmpOutput("")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("chip writes for frame 1 logged")
+	trm.rcvOutputUntil("break on Frame")
+
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+
+	f, err := os.Open(fn)
+	test.DemandSuccess(trm.t, err)
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	test.DemandSuccess(trm.t, err)
+
+	test.ExpectEquality(trm.t, len(records), 263)
+	test.ExpectEquality(trm.t, strings.Join(records[0], ","), "frame,scanline,clock,register,value")
+
+	// the frame number changes during the VSYNC so the order of the writes in
+	// the log will not be the same as the order in the program
+	count := make(map[string]int)
+	for _, r := range records[1:] {
+		test.ExpectEquality(trm.t, r[0], "1")
+		count[r[3]]++
+		if r[3] == "COLUBK" {
+			test.ExpectEquality(trm.t, r[4], "0x0e")
+		}
+	}
+	test.ExpectEquality(trm.t, count["VSYNC"], 2)
+	test.ExpectEquality(trm.t, count["WSYNC"], 259)
+	test.ExpectEquality(trm.t, count["COLUBK"], 1)
+}
+
+func (trm *mockTerm) testChipLogQuit(fn string) {
+	defer func() { trm.sndInput("QUIT") }()
+
+	trm.sndInput("TIA LOG " + fn)
+	trm.rcvOutput()
+	trm.expectOutput("logging chip writes for frame 1")
+
+	// quit part way through the frame being logged
+	trm.sndInput("BREAK FRAME 1")
+	trm.rcvOutput()
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on Frame")
+	for range 20 {
+		trm.sndInput("STEP")
+		trm.rcvOutput()
+	}
+}
+
+func TestChipLogQuit(t *testing.T) {
+	fn := chipLogROM(t)
+
+	logfn := filepath.Join(t.TempDir(), "chiplog.csv")
+	err := startDebugger(t, debugger.CommandLineOptions{}, fn, func(trm *mockTerm) {
+		trm.testChipLogQuit(logfn)
+	})
+	test.DemandSuccess(t, err)
+
+	// the writes logged before the debugger quit have been written to the file
+	f, err := os.Open(logfn)
+	test.DemandSuccess(t, err)
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	test.DemandSuccess(t, err)
+
+	test.ExpectSuccess(t, len(records) > 1)
+	test.ExpectSuccess(t, len(records) < 263)
+	for _, r := range records[1:] {
+		test.ExpectEquality(t, r[0], "1")
+	}
+}
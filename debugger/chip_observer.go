@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// audioWrite records a write to one of the TIA audio registers
+type audioWrite struct {
+	// the number of audio register writes since the debugger was created. two
+	// consecutive writes of the same value to the same register will have a
+	// different sequence number
+	seq uint64
+
+	reg   cpubus.Register
+	value uint8
+}
+
+func (w audioWrite) String() string {
+	if w.reg == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s=0x%02x", w.reg, w.value)
+}
+
+// chipObserver is attached to the emulation as the chip write observer and as
+// a frame trigger for the lifetime of the debugger. chip writes and new frames
+// are forwarded to the chip log, if one is active, and writes to the audio
+// registers are recorded for the AUDIO halt target
+type chipObserver struct {
+	dbg *Debugger
+
+	// the most recent write to an audio register
+	lastAudioWrite audioWrite
+}
+
+// ObserveChipWrite implements the chipbus.WriteObserver interface
+func (obs *chipObserver) ObserveChipWrite(reg chipbus.ChangedRegister) {
+	switch reg.Register {
+	case cpubus.AUDC0, cpubus.AUDC1, cpubus.AUDF0, cpubus.AUDF1, cpubus.AUDV0, cpubus.AUDV1:
+		obs.lastAudioWrite = audioWrite{
+			seq:   obs.lastAudioWrite.seq + 1,
+			reg:   reg.Register,
+			value: reg.Value,
+		}
+	}
+
+	if obs.dbg.chipLog != nil {
+		obs.dbg.chipLog.ObserveChipWrite(reg)
+	}
+}
+
+// NewFrame implements the television.FrameTrigger interface
+func (obs *chipObserver) NewFrame(info television.FrameInfo) error {
+	if obs.dbg.chipLog != nil {
+		obs.dbg.chipLog.newFrame(info)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// clocks returns the CPU cycle count and the TIA clock reported by the
+// CLOCKS command
+func (trm *mockTerm) clocks() (int, int) {
+	trm.sndInput("CLOCKS")
+	trm.rcvOutput()
+
+	var cycles, clocks, scanline int
+	for _, s := range trm.output {
+		fmt.Sscanf(s, "cpu cycles: %d", &cycles)
+		fmt.Sscanf(s, "tia clocks: %d of %d", &clocks, &scanline)
+	}
+	if scanline != specification.ClksScanline {
+		trm.t.Errorf("unexpected number of clocks in scanline (%d)", scanline)
+	}
+	return cycles, clocks
+}
+
+func (trm *mockTerm) testClocks() {
+	//	LDA $80
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa5, 0x80, 0x4c, 0x00, 0xf0}))
+
+	cycles, clocks := trm.clocks()
+	trm.expectOutput("coproc: none")
+
+	// the two instructions in the program both take three CPU cycles
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+
+	stepCycles, stepClocks := trm.clocks()
+	if stepCycles-cycles != 6 {
+		trm.t.Errorf("unexpected number of CPU cycles after stepping (%d) should be (6)", stepCycles-cycles)
+	}
+
+	// there are three color clocks for every CPU cycle
+	d := (stepClocks - clocks + specification.ClksScanline) % specification.ClksScanline
+	if d != 18 {
+		trm.t.Errorf("unexpected number of TIA clocks after stepping (%d) should be (18)", d)
+	}
+}
@@ -28,6 +28,7 @@ type CommandLineOptions struct {
 	Spec      string
 	FpsCap    bool
 	Multiload int
+	TurboLoad bool
 	Mapping   string
 	Bank      string
 	Left      string
@@ -38,16 +38,23 @@ import (
 	"github.com/jetsetilly/gopher2600/disassembly"
 	"github.com/jetsetilly/gopher2600/disassembly/symbols"
 	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/registers"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm/architecture"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/plusrom"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 	"github.com/jetsetilly/gopher2600/hardware/peripherals/atarivox"
 	"github.com/jetsetilly/gopher2600/hardware/peripherals/controllers"
 	"github.com/jetsetilly/gopher2600/hardware/peripherals/savekey"
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/plugging"
+	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
 	"github.com/jetsetilly/gopher2600/hardware/television"
 	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio/mix"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/patch"
 	"github.com/jetsetilly/gopher2600/resources/unique"
@@ -192,6 +199,42 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 
 	case cmdReset:
+		option, _ := tokens.Get()
+		switch strings.ToUpper(option) {
+		case "PATTERN":
+			// set the RAM pattern preference before resetting
+			pattern, _ := tokens.Get()
+			err := dbg.vcs.Env.Prefs.RAMPattern.Set(strings.ToUpper(pattern))
+			if err != nil {
+				return err
+			}
+			if seed, ok := tokens.Get(); ok {
+				n, err := strconv.ParseInt(seed, 0, 64)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("seed must be a number (%s)", seed))
+					return nil
+				}
+				err = dbg.vcs.Env.Prefs.RAMSeed.Set(int(n))
+				if err != nil {
+					return err
+				}
+			}
+		case "PC":
+			// set or clear the PC override before resetting
+			arg, _ := tokens.Get()
+			if strings.ToUpper(arg) == "OFF" {
+				dbg.resetPC = nil
+			} else {
+				n, err := strconv.ParseUint(arg, 0, 16)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("PC must be a 16bit address (%s)", arg))
+					return nil
+				}
+				pc := uint16(n)
+				dbg.resetPC = &pc
+			}
+		}
+
 		// resetting in the middle of a CPU instruction requires the input loop
 		// to be unwound before continuing
 		dbg.unwindLoop(func() error {
@@ -201,6 +244,9 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				return err
 			}
 			dbg.printLine(terminal.StyleFeedback, "machine reset")
+			if dbg.resetPC != nil {
+				dbg.printLine(terminal.StyleFeedbackSecondary, fmt.Sprintf("PC overridden to %04x", *dbg.resetPC))
+			}
 			return nil
 		})
 
@@ -218,6 +264,9 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 		if tk, ok := tokens.Get(); ok {
 			switch tk {
+			case "COPROC":
+				return dbg.stepCoProc()
+
 			case "BACK":
 				back = true
 				adjAmount *= -1
@@ -291,6 +340,11 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 			switch mode {
 			case "":
+				// step the coprocessor rather than the 6507 if requested
+				if dbg.coprocQuantum {
+					return dbg.stepCoProc()
+				}
+
 				// continue with current quantum state
 
 				// if quantum is not the QuantumClock and CPU is not RDY then STEP
@@ -311,11 +365,22 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					dbg.stepOutOfVideoStepInputLoop = true
 				}
 			case "INSTRUCTION":
+				dbg.coprocQuantum = false
 				dbg.setQuantum(govern.QuantumInstruction)
 			case "CYCLE":
+				dbg.coprocQuantum = false
 				dbg.setQuantum(govern.QuantumCycle)
 			case "CLOCK":
+				dbg.coprocQuantum = false
 				dbg.setQuantum(govern.QuantumClock)
+			case "BANK":
+				// the bank change is reported when the emulation halts
+				from := dbg.vcs.Mem.Cart.GetBank(dbg.vcs.CPU.PC.Address())
+				dbg.stepBankFrom = &from
+				_ = dbg.halting.volatileTraps.parseCommand(commandline.TokeniseInput("BANK"))
+
+				// bank change may take many cycles to trigger
+				dbg.runUntilHalt = true
 			default:
 				// token not recognised so forward rest of tokens to the volatile
 				// traps parser
@@ -338,13 +403,26 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		mode = strings.ToUpper(mode)
 		switch mode {
 		case "INSTRUCTION":
+			dbg.coprocQuantum = false
 			dbg.setQuantum(govern.QuantumInstruction)
 		case "CYCLE":
+			dbg.coprocQuantum = false
 			dbg.setQuantum(govern.QuantumCycle)
 		case "CLOCK":
+			dbg.coprocQuantum = false
 			dbg.setQuantum(govern.QuantumClock)
+		case "COPROC":
+			if dbg.vcs.Mem.Cart.GetCoProcBus() == nil {
+				dbg.printLine(terminal.StyleError, "cartridge does not have a coprocessor")
+				return nil
+			}
+			dbg.coprocQuantum = true
 		default:
-			dbg.printLine(terminal.StyleFeedback, "set to %s", strings.ToUpper(dbg.Quantum().String()))
+			if dbg.coprocQuantum {
+				dbg.printLine(terminal.StyleFeedback, "set to COPROC")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "set to %s", strings.ToUpper(dbg.Quantum().String()))
+			}
 		}
 
 	case cmdScript:
@@ -473,6 +551,41 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			return nil
 		})
 
+	case cmdSeed:
+		arg, ok := tokens.Get()
+		if !ok {
+			dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("seed: %d", dbg.vcs.Env.Random.Seed()))
+			return nil
+		}
+
+		seed, err := strconv.ParseInt(arg, 0, 64)
+		if err != nil {
+			dbg.printLine(terminal.StyleError, fmt.Sprintf("seed must be a number (%s)", arg))
+			return nil
+		}
+		dbg.vcs.Env.Random.SetSeed(seed)
+
+		// reset machine so that the seed is applied from power-on
+		dbg.unwindLoop(func() error {
+			err := dbg.reset(false)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "seed set to %d. machine reset", seed)
+			return nil
+		})
+
+	case cmdAssert:
+		target, _ := tokens.Get()
+		value, _ := tokens.Get()
+
+		expected, err := strconv.ParseInt(value, 0, 32)
+		if err != nil {
+			return fmt.Errorf("assertion value must be a number (%s)", value)
+		}
+
+		return dbg.assert(strings.ToUpper(target), int(expected))
+
 	case cmdInsert:
 		dbg.unwindLoop(func() error {
 			filename, _ := tokens.Get()
@@ -523,6 +636,15 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					dbg.vcs.Mem.Cart.Hash,
 				)
 
+			case "HOTSPOTS":
+				hotspots := dbg.vcs.Mem.Cart.Hotspots()
+				if len(hotspots) == 0 {
+					dbg.printLine(terminal.StyleFeedback, "cartridge has no hotspots")
+				}
+				for _, h := range hotspots {
+					dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("%#04x %s", h.Address, h.Description))
+				}
+
 			case "STATIC":
 				// !!TODO: poke/peek static cartridge static data areas
 				if bus := dbg.vcs.Mem.Cart.GetStaticBus(); bus != nil {
@@ -574,6 +696,34 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("rom dumped to %s", romdump))
 				}
 
+			case "DIFF":
+				fn, _ := tokens.Get()
+				diff, err := dbg.vcs.Mem.Cart.Diff(fn)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				if len(diff) == 0 {
+					dbg.printLine(terminal.StyleFeedback, "no differences")
+				} else {
+					for _, d := range diff {
+						dbg.printLine(terminal.StyleFeedback, d.String())
+					}
+				}
+
+			case "ROMWRITES":
+				if arg, ok := tokens.Get(); ok {
+					err := dbg.vcs.Env.Prefs.LogROMWrites.Set(strings.ToUpper(arg) == "ON")
+					if err != nil {
+						return err
+					}
+				}
+				if dbg.vcs.Env.Prefs.LogROMWrites.Get().(bool) {
+					dbg.printLine(terminal.StyleFeedback, "logging of ROM writes: ON")
+				} else {
+					dbg.printLine(terminal.StyleFeedback, "logging of ROM writes: OFF")
+				}
+
 			case "SETBANK":
 				spec, _ := tokens.Get()
 				err := dbg.vcs.Mem.Cart.SetBank(spec)
@@ -590,6 +740,11 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			}
 		} else {
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.Mem.Cart.String())
+			if dbg.vcs.Mem.Cart.NumBanks() > 1 {
+				pc := dbg.vcs.CPU.PC.Address()
+				dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("current bank: %d (PC=%#04x)",
+					dbg.vcs.Mem.Cart.CurrentBank(pc), pc))
+			}
 		}
 
 	case cmdPatch:
@@ -618,16 +773,81 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					dbg.printLine(terminal.StyleFeedback, err.Error())
 				}
 				return nil
+			case "COMPARE":
+				fn, _ := tokens.Get()
+				f, err := os.Open(fn)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				defer f.Close()
+
+				s := strings.Builder{}
+				n, err := dbg.Disasm.Compare(&s, f)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				if n == 0 {
+					dbg.printLine(terminal.StyleFeedback, "disassembly matches reference")
+				} else {
+					dbg.printLine(terminal.StyleFeedback, strings.TrimSuffix(s.String(), "\n"))
+					dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("%d differences from reference", n))
+				}
+				return nil
+			case "EXPORT":
+				fn, _ := tokens.Get()
+				f, err := os.Create(fn)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				defer f.Close()
+
+				err = dbg.Disasm.Export(f)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("disassembly exported to %s", fn))
+				return nil
+			case "COLUMNS":
+				// no arguments shows the current column settings
+				if tokens.IsEnd() {
+					dbg.printLine(terminal.StyleFeedback, dbg.Disasm.Prefs.Columns().String())
+					return nil
+				}
+
+				// listed columns are enabled and all others are disabled
+				var attr disassembly.ColumnAttr
+				for col, ok := tokens.Get(); ok; col, ok = tokens.Get() {
+					switch strings.ToUpper(col) {
+					case "BYTECODE":
+						attr.ByteCode = true
+					case "CYCLES":
+						attr.Cycles = true
+					case "LABEL":
+						attr.Label = true
+					case "NOTES":
+						attr.Notes = true
+					default:
+						return fmt.Errorf("unknown disassembly column (%s)", col)
+					}
+				}
+				dbg.Disasm.Prefs.SetColumns(attr)
+				err := dbg.Disasm.Prefs.Save()
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+				}
+				dbg.printLine(terminal.StyleFeedback, attr.String())
+				return nil
 			case "BYTECODE":
 				bytecode = true
 			}
 		}
 
-		attr := disassembly.ColumnAttr{
-			ByteCode: bytecode,
-			Label:    true,
-			Cycles:   true,
-		}
+		attr := dbg.Disasm.Prefs.Columns()
+		attr.ByteCode = attr.ByteCode || bytecode
 
 		s := strings.Builder{}
 		err := dbg.Disasm.Write(&s, attr)
@@ -673,6 +893,26 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			_ = dbg.gui.SetFeature(gui.ReqCoProcSourceLine, ln)
 			return nil
 
+		case "REFERENCES":
+			search, _ := tokens.Get()
+
+			ai := dbg.dbgmem.GetAddressInfo(search, true)
+			if ai == nil {
+				dbg.printLine(terminal.StyleError, "cannot find references to %s: unrecognised address", search)
+				return nil
+			}
+
+			n, err := dbg.Disasm.References(output, ai.Address)
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				dbg.printLine(terminal.StyleFeedback, "no references to %#04x in disassembly", ai.Address)
+			} else {
+				dbg.printLine(terminal.StyleFeedback, output.String())
+			}
+			return nil
+
 		case "OPERATOR":
 			scope = disassembly.GrepOperator
 		case "OPERAND":
@@ -961,8 +1201,8 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			return nil
 		}
 
-		// whether to show bytecode
-		bytecode := false
+		// optional columns
+		attr := dbg.Disasm.Prefs.Columns()
 
 		option, ok := tokens.Get()
 		if ok {
@@ -976,7 +1216,7 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				return nil
 
 			case "BYTECODE":
-				bytecode = true
+				attr.ByteCode = true
 			}
 		}
 
@@ -987,15 +1227,31 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 		s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldAddress))
 		s.WriteString(" ")
-		if bytecode {
+		if attr.ByteCode {
 			s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldBytecode))
 			s.WriteString(" ")
 		}
 		s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldOperator))
 		s.WriteString(" ")
 		s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldOperand))
-		s.WriteString(" ")
-		s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldCycles))
+
+		// the effective address is only interesting if it is different to the
+		// operand. ie. when indexing or indirection is used
+		if dbg.liveDisasmEntry.Result.HasEffectiveAddress {
+			switch dbg.liveDisasmEntry.Result.Defn.AddressingMode {
+			case instructions.IndexedIndirect, instructions.IndirectIndexed,
+				instructions.AbsoluteIndexedX, instructions.AbsoluteIndexedY,
+				instructions.ZeroPageIndexedX, instructions.ZeroPageIndexedY:
+				s.WriteString(fmt.Sprintf(" (ea %#04x)", dbg.liveDisasmEntry.Result.EffectiveAddress))
+			}
+		}
+
+		if attr.Cycles {
+			s.WriteString(" ")
+			s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldCycles))
+		}
+
+		// notes are always shown by the LAST command
 		s.WriteString(" ")
 		s.WriteString(dbg.liveDisasmEntry.GetField(disassembly.FldNotes))
 
@@ -1132,6 +1388,40 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					reg.Load(uint8(v))
 				}
 
+			case "STACK":
+				depth := dbg.vcs.CPU.CallDepth
+				if depth < 0 {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("call depth: %d (stack has been manipulated)", depth))
+					return nil
+				}
+				dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("call depth: %d", depth))
+
+				for i, a := range dbg.vcs.CPU.CallStack() {
+					lo, err := dbg.vcs.Mem.Peek(a)
+					if err != nil {
+						dbg.printLine(terminal.StyleError, err.Error())
+						return nil
+					}
+					// the stack is in page one so the high byte of a return
+					// address at the top of the page wraps to the bottom
+					hi, err := dbg.vcs.Mem.Peek(0x0100 | ((a + 1) & 0xff))
+					if err != nil {
+						dbg.printLine(terminal.StyleError, err.Error())
+						return nil
+					}
+					ret := (uint16(hi)<<8 | uint16(lo)) + 1
+					dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("%2d: %#04x (stack %#04x)", i, ret, a))
+				}
+
+			case "HISTOGRAM":
+				option, _ := tokens.Get()
+				if strings.ToUpper(option) == "CLEAR" {
+					dbg.cpuHistogram.clear()
+					dbg.printLine(terminal.StyleFeedback, "instruction histogram cleared")
+					return nil
+				}
+				dbg.cpuHistogram.write(dbg.writerInStyle(terminal.StyleInstrument))
+
 			default:
 				// already caught by command line ValidateTokens()
 			}
@@ -1263,8 +1553,89 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 	case cmdTIA:
 		arg, _ := tokens.Get()
 		switch arg {
+		case "AUDIO":
+			option, _ := tokens.Get()
+			if option == "MUTE" {
+				channel, ok := tokens.Get()
+				if ok {
+					switch channel {
+					case "0":
+						dbg.audioMute = mix.MuteChannel0
+					case "1":
+						dbg.audioMute = mix.MuteChannel1
+					case "BOTH":
+						dbg.audioMute = mix.MuteBoth
+					case "NONE":
+						dbg.audioMute = mix.MuteNone
+					}
+
+					err := dbg.gui.SetFeature(gui.ReqAudioMute, dbg.audioMute)
+					if err != nil {
+						dbg.printLine(terminal.StyleError, err.Error())
+						return nil
+					}
+				}
+				dbg.printLine(terminal.StyleFeedback, dbg.audioMute.String())
+				return nil
+			}
+			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Audio.String())
 		case "HMOVE":
-			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Hmove.String())
+			hm := &dbg.vcs.TIA.Hmove
+
+			if hm.Future.IsActive() {
+				dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("latch: latching in %d", hm.Future.Remaining()))
+			} else if hm.Latch {
+				dbg.printLine(terminal.StyleInstrument, "latch: latched")
+			} else {
+				dbg.printLine(terminal.StyleInstrument, "latch: not latched")
+			}
+
+			// the ripple counter is shown as a bar that shortens as the
+			// counter ticks down
+			if ct, ok := hm.RippleCount(); ok {
+				bar := strings.Repeat("#", ct+1) + strings.Repeat(".", 15-ct)
+				dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("ripple: %2d %s", ct, bar))
+			} else if hm.RippleJustEnded {
+				dbg.printLine(terminal.StyleInstrument, "ripple: just ended")
+			} else {
+				dbg.printLine(terminal.StyleInstrument, "ripple: inactive")
+			}
+
+			clk := "phase: " + dbg.vcs.TIA.PClk.String()
+			if hm.Clk {
+				clk = fmt.Sprintf("%s [ripple clock]", clk)
+			}
+			dbg.printLine(terminal.StyleInstrument, clk)
+
+			dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("last strobe: %s", hm.LastStrobe))
+		case "LOG":
+			fn, _ := tokens.Get()
+			err := dbg.startChipLog(fn)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, err.Error())
+				return nil
+			}
+		case "SNAPSHOT":
+			dbg.tiaSnapshot = dbg.vcs.TIA.Snapshot()
+			dbg.printLine(terminal.StyleFeedback, "TIA state saved")
+		case "RESTORE":
+			if dbg.tiaSnapshot == nil {
+				dbg.printLine(terminal.StyleError, "no TIA state has been saved")
+				return nil
+			}
+			dbg.vcs.PlumbTIA(dbg.tiaSnapshot)
+			dbg.printLine(terminal.StyleFeedback, "TIA state restored")
+			dbg.printLine(terminal.StyleFeedbackSecondary, "the TIA may now be out of sync with the rest of the machine")
+		case "REVISION":
+			preset, ok := tokens.Get()
+			if ok {
+				err := dbg.vcs.Env.Prefs.Revision.ApplyPreset(preset)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+			}
+			dbg.printLine(terminal.StyleFeedback, dbg.vcs.Env.Prefs.Revision.String())
 		default:
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.String())
 		}
@@ -1273,7 +1644,58 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		arg, _ := tokens.Get()
 		switch arg {
 		case "TIMER":
+			option, _ := tokens.Get()
+			if option == "SET" {
+				arg, _ := tokens.Get()
+				interval, err := strconv.Atoi(arg)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "interval must be 1, 8, 64 or 1024")
+					return nil
+				}
+
+				arg, _ = tokens.Get()
+				count, err := strconv.ParseUint(arg, 0, 8)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "count must be an 8 bit number (%s)", arg)
+					return nil
+				}
+
+				err = dbg.vcs.RIOT.Timer.SetInterval(timer.Divider(interval), uint8(count))
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+			}
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.RIOT.Timer.String())
+		case "INPT":
+			arg, ok := tokens.Get()
+			if ok {
+				inptx, err := strconv.Atoi(arg)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "INPTx register must be a number between 0 and 5")
+					return nil
+				}
+
+				arg, ok = tokens.Get()
+				if ok {
+					if strings.ToUpper(arg) == "RELEASE" {
+						err = dbg.vcs.RIOT.Ports.ReleaseINPTx(inptx)
+					} else {
+						var v uint64
+						v, err = strconv.ParseUint(arg, 0, 8)
+						if err != nil {
+							dbg.printLine(terminal.StyleError, "value must be an 8 bit number (%s)", arg)
+							return nil
+						}
+						err = dbg.vcs.RIOT.Ports.ForceINPTx(inptx, uint8(v))
+					}
+					if err != nil {
+						dbg.printLine(terminal.StyleError, err.Error())
+						return nil
+					}
+				}
+			}
+			dbg.printLine(terminal.StyleInstrument, dbg.vcs.RIOT.Ports.StringINPTx())
 		case "PORTS":
 			fallthrough
 		default:
@@ -1304,12 +1726,92 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					}
 				}
 
+				info := dbg.vcs.TV.GetFrameInfo()
 				dbg.printLine(terminal.StyleInstrument,
-					fmt.Sprintf("actual=%s, requested=%s",
-						dbg.vcs.TV.GetFrameInfo().Spec.ID,
+					fmt.Sprintf("actual=%s, requested=%s, refresh=%.2fHz, scanlines=%d",
+						info.Spec.ID,
 						dbg.vcs.TV.GetReqSpecID(),
+						info.RefreshRate,
+						info.TotalScanlines,
 					))
 
+			case "STABILIZE":
+				err := dbg.vcs.TV.Stabilise()
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				dbg.printLine(terminal.StyleFeedback, "television will be stable on the next frame")
+
+			case "PALETTE":
+				// palette is changed for the current specification
+				spec := dbg.vcs.TV.GetFrameInfo().Spec.ID
+
+				fn, ok := tokens.Get()
+				if !ok {
+					err := dbg.vcs.TV.SetPalette(spec, nil)
+					if err != nil {
+						dbg.printLine(terminal.StyleError, err.Error())
+						return nil
+					}
+					dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("built-in palette restored for %s", spec))
+					return nil
+				}
+
+				f, err := os.Open(fn)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				defer f.Close()
+
+				palette, err := specification.ReadPalette(f)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+
+				err = dbg.vcs.TV.SetPalette(spec, palette)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("custom palette loaded for %s", spec))
+
+			case "SIGNALS":
+				arg, _ := tokens.Get()
+				scanline, err := strconv.Atoi(arg)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("scanline must be a number (%s)", arg))
+					return nil
+				}
+
+				sigs := dbg.vcs.TV.GetScanlineSignals(scanline)
+				if sigs == nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("scanline %d is out of range", scanline))
+					return nil
+				}
+
+				for clk, sig := range sigs {
+					if sig.Index == signal.NoSignal {
+						dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("%03d: no signal", clk-specification.ClksHBlank))
+						continue
+					}
+
+					s := strings.Builder{}
+					s.WriteString(fmt.Sprintf("%03d: ", clk-specification.ClksHBlank))
+					s.WriteString(sig.String())
+					if sig.Color == signal.VideoBlack {
+						s.WriteString("pixel=black")
+					} else {
+						s.WriteString(fmt.Sprintf("pixel=%#02x", uint8(sig.Color)))
+					}
+					if sig.AudioUpdate {
+						s.WriteString(fmt.Sprintf(" audio=%d,%d", sig.AudioChannel0, sig.AudioChannel1))
+					}
+					dbg.printLine(terminal.StyleInstrument, s.String())
+				}
+
 			default:
 				// already caught by command line ValidateTokens()
 			}
@@ -1317,6 +1819,24 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TV.String())
 		}
 
+	case cmdClocks:
+		dbg.printLine(terminal.StyleInstrument, "cpu cycles: %d", dbg.vcs.CPU.CycleCount)
+		dbg.printLine(terminal.StyleInstrument, "tia clocks: %d of %d",
+			dbg.vcs.TV.GetCoords().Clock+specification.ClksHBlank, specification.ClksScanline)
+
+		bus := dbg.vcs.Mem.Cart.GetCoProcBus()
+		if bus == nil {
+			dbg.printLine(terminal.StyleInstrument, "coproc: none")
+			return nil
+		}
+
+		dbg.printLine(terminal.StyleInstrument, "coproc clock: %.2fMHz", dbg.vcs.Env.Prefs.ARM.Clock.Get().(float64))
+		if c, ok := bus.GetCoProc().(coprocessor.CartCoProcCycles); ok {
+			dbg.printLine(terminal.StyleInstrument, "coproc cycles: %.0f (last run)", c.LastRunCycles())
+		} else {
+			dbg.printLine(terminal.StyleInstrument, "coproc cycles: unavailable")
+		}
+
 	// information about the machine (sprites, playfield)
 	case cmdPlayer:
 		plyr := -1
@@ -1331,9 +1851,15 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 		switch plyr {
 		case 0:
+			if !dbg.spritePosition(tokens, dbg.vcs.TIA.Video.Player0) {
+				return nil
+			}
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Player0.String())
 
 		case 1:
+			if !dbg.spritePosition(tokens, dbg.vcs.TIA.Video.Player1) {
+				return nil
+			}
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Player1.String())
 
 		default:
@@ -1354,9 +1880,15 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 		switch miss {
 		case 0:
+			if !dbg.spritePosition(tokens, dbg.vcs.TIA.Video.Missile0) {
+				return nil
+			}
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Missile0.String())
 
 		case 1:
+			if !dbg.spritePosition(tokens, dbg.vcs.TIA.Video.Missile1) {
+				return nil
+			}
 			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Missile1.String())
 
 		default:
@@ -1365,10 +1897,19 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 
 	case cmdBall:
+		if !dbg.spritePosition(tokens, dbg.vcs.TIA.Video.Ball) {
+			return nil
+		}
 		dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Ball.String())
 
 	case cmdPlayfield:
-		dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Playfield.String())
+		option, _ := tokens.Get()
+		switch option {
+		case "ASCII":
+			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Playfield.ASCII())
+		default:
+			dbg.printLine(terminal.StyleInstrument, dbg.vcs.TIA.Video.Playfield.String())
+		}
 
 	case cmdPlusROM:
 		plusrom, ok := dbg.vcs.Mem.Cart.GetContainer().(*plusrom.PlusROM)
@@ -1550,6 +2091,21 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				}
 			}
 
+		case "RAM":
+			bus := dbg.vcs.Mem.Cart.GetStaticBus()
+			if bus == nil {
+				dbg.printLine(terminal.StyleError, "cartridge does not have any coprocessor memory")
+				return nil
+			}
+
+			static := bus.GetStatic()
+			if static == nil {
+				dbg.printLine(terminal.StyleError, "cartridge does not have any coprocessor memory")
+				return nil
+			}
+
+			dbg.coprocRAM(static, tokens)
+
 		case "REGS":
 			coproc := bus.GetCoProc()
 
@@ -1598,8 +2154,15 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		case "SET":
 			var reg int
 			var value uint32
+			var seed bool
 			arg, ok := tokens.Get()
 			if ok {
+				// general registers specified with the R prefix are seeded
+				// for the next execution of the coprocessor program
+				if len(arg) > 1 && strings.ToUpper(arg[:1]) == "R" {
+					arg = arg[1:]
+					seed = true
+				}
 				n, err := strconv.ParseInt(arg, 0, 32)
 				if err != nil {
 					dbg.printLine(terminal.StyleError, fmt.Sprintf("%s is not a number", arg))
@@ -1616,12 +2179,279 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				}
 				value = uint32(n)
 			}
-			if bus.GetCoProc().RegisterSet(reg, value) {
+			if seed {
+				seeder, ok := bus.GetCoProc().(coprocessor.CartCoProcSeeder)
+				if !ok {
+					dbg.printLine(terminal.StyleError, "coproc does not support seeding of registers")
+					return nil
+				}
+				err := seeder.SeedRegister(reg, value)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("cannot seed coproc register R%d: %v", reg, err))
+					return nil
+				}
+				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("seeding coproc register R%d with %08x", reg, value))
+			} else if bus.GetCoProc().RegisterSet(reg, value) {
 				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("setting coproc register %d to %08x\n", reg, value))
 			} else {
 				dbg.printLine(terminal.StyleError, fmt.Sprintf("cannot set coproc register %d to %08x\n", reg, value))
 			}
 
+		case "STATUS":
+			status, ok := bus.GetCoProc().(coprocessor.CartCoProcStatus)
+			if !ok {
+				dbg.printLine(terminal.StyleError, "coproc does not have status flags")
+				return nil
+			}
+
+			if _, ok := tokens.Get(); ok {
+				flag, _ := tokens.Get()
+				value, _ := tokens.Get()
+				err := status.SetStatusFlag(flag, value == "1")
+				if err != nil {
+					dbg.printLine(terminal.StyleError, err.Error())
+					return nil
+				}
+			}
+
+			dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("coproc status: %s", status.StatusFlags()))
+
+		case "RESET":
+			err := dbg.vcs.Mem.Cart.ResetCoProc()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, err.Error())
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "coproc has been reset")
+
+		case "CLK":
+			if arg, ok := tokens.Get(); ok {
+				mhz, err := strconv.ParseFloat(arg, 64)
+				if err != nil || mhz <= 0 {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("%s is not a valid clock speed", arg))
+					return nil
+				}
+				err = dbg.vcs.Env.Prefs.ARM.Clock.Set(mhz)
+				if err != nil {
+					return err
+				}
+
+				// apply new clock speed immediately if possible
+				if p, ok := bus.GetCoProc().(coprocessor.CartCoProcPreferences); ok {
+					p.UpdatePrefs()
+				}
+			}
+			dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("coproc clock: %.2fMHz", dbg.vcs.Env.Prefs.ARM.Clock.Get().(float64)))
+
+		case "MAM":
+			if arg, ok := tokens.Get(); ok {
+				var mode int
+				switch strings.ToUpper(arg) {
+				case "OFF":
+					mode = int(architecture.MAMdisabled)
+				case "PARTIAL":
+					mode = int(architecture.MAMpartial)
+				case "FULL":
+					mode = int(architecture.MAMfull)
+				case "DRIVER":
+					mode = preferences.MAMDriver
+				default:
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("%s is not a valid MAM mode", arg))
+					return nil
+				}
+
+				// the MAM preference is applied at the start of the next
+				// coprocessor run
+				err := dbg.vcs.Env.Prefs.ARM.MAM.Set(mode)
+				if err != nil {
+					return err
+				}
+			}
+
+			switch dbg.vcs.Env.Prefs.ARM.MAM.Get().(int) {
+			case int(architecture.MAMdisabled):
+				dbg.printLine(terminal.StyleFeedback, "coproc MAM: off")
+			case int(architecture.MAMpartial):
+				dbg.printLine(terminal.StyleFeedback, "coproc MAM: partial")
+			case int(architecture.MAMfull):
+				dbg.printLine(terminal.StyleFeedback, "coproc MAM: full")
+			default:
+				dbg.printLine(terminal.StyleFeedback, "coproc MAM: driver")
+			}
+
+		case "RELOAD":
+			layoutChanged, err := dbg.CoProcDev.ReloadSource()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, err.Error())
+				return nil
+			}
+			if layoutChanged {
+				dbg.printLine(terminal.StyleFeedback, "program layout has changed. coprocessor breakpoints have been cleared")
+			}
+			dbg.printLine(terminal.StyleFeedback, "coprocessor source reloaded")
+
+		case "FILES":
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				src.ListFiles(dbg.writerInStyle(terminal.StyleFeedback))
+				if len(src.MissingFiles) > 0 {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("%d source files listed in the DWARF data are missing", len(src.MissingFiles)))
+				}
+			})
+
+		case "UNITS":
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				src.ListCompileUnits(dbg.writerInStyle(terminal.StyleFeedback))
+			})
+
+		case "VARS":
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				src.UpdateGlobalVariables()
+				src.ListGlobals(dbg.writerInStyle(terminal.StyleFeedback))
+			})
+
+		case "VAR":
+			name, _ := tokens.Get()
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				varb := src.FindGlobal(name)
+				if varb == nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("no global variable named %s", name))
+					return
+				}
+				varb.Update()
+				dbg.printLine(terminal.StyleFeedback, varb.String())
+			})
+
+		case "INTERLEAVE":
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				lines, pct := src.InterleavedLines()
+				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("%.2f%% of source lines have interleaved instructions", pct))
+				for _, ln := range lines {
+					dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("%s:%d  %s", ln.File.ShortFilename, ln.LineNumber, ln.Function.Name))
+				}
+			})
+
+		case "PROFILE":
+			arg, _ := tokens.Get()
+			dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+				if src == nil {
+					dbg.printLine(terminal.StyleError, "no source files found")
+					return
+				}
+				s := &strings.Builder{}
+				if arg == "LINES" {
+					src.WriteLineProfile(s, 10)
+				} else {
+					src.WriteFunctionProfile(s, 10)
+				}
+				if s.Len() == 0 {
+					dbg.printLine(terminal.StyleError, "no profiling information for the most recent frame")
+					return
+				}
+				for _, l := range strings.Split(strings.TrimSuffix(s.String(), "\n"), "\n") {
+					dbg.printLine(terminal.StyleFeedback, l)
+				}
+			})
+
+		case "IMMEDIATE":
+			if arg, ok := tokens.Get(); ok {
+				// the preference is read by the coprocessor at the start of
+				// the next execution
+				err := dbg.vcs.Env.Prefs.ARM.Immediate.Set(strings.ToUpper(arg) == "ON")
+				if err != nil {
+					return err
+				}
+			}
+			if dbg.vcs.Env.Prefs.ARM.Immediate.Get().(bool) {
+				dbg.printLine(terminal.StyleFeedback, "coproc immediate mode: ON (cycle counting disabled)")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "coproc immediate mode: OFF (cycle accurate)")
+			}
+
+		case "BREAK":
+			if arg, ok := tokens.Get(); ok {
+				dbg.CoProcDev.DisableBreakpoints(strings.ToUpper(arg) == "OFF")
+			}
+			if dbg.CoProcDev.BreakpointsDisabled() {
+				dbg.printLine(terminal.StyleFeedback, "coproc breakpoints: OFF")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "coproc breakpoints: ON")
+			}
+
+		case "BREAKEND":
+			if arg, ok := tokens.Get(); ok {
+				dbg.halting.breakOnProgramEnd = strings.ToUpper(arg) == "ON"
+			}
+			if dbg.halting.breakOnProgramEnd {
+				dbg.printLine(terminal.StyleFeedback, "coproc break on program end: ON")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "coproc break on program end: OFF")
+			}
+
+		case "YIELD":
+			state := bus.CoProcExecutionState()
+			dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("sync: %s", state.Sync))
+			dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("yield: %s", state.Yield))
+
+		case "DISASM":
+			coproc := bus.GetCoProc()
+
+			var addr uint32
+			if arg, ok := tokens.Get(); ok {
+				n, err := strconv.ParseUint(arg, 0, 32)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, fmt.Sprintf("%s is not a valid address", arg))
+					return nil
+				}
+				addr = uint32(n)
+			} else {
+				pc, ok := coproc.Register(15)
+				if !ok {
+					dbg.printLine(terminal.StyleError, "cannot read coprocessor PC register")
+					return nil
+				}
+
+				// the PC register is one instruction ahead of the instruction
+				// that will be executed next
+				addr = pc - 2
+			}
+
+			dbg.coprocDisasm(coproc, addr)
+
+		case "MEMMAP":
+			dbg.coprocMemMap(bus.GetCoProc())
+
+		case "SOURCE":
+			coproc := bus.GetCoProc()
+			pc, ok := coproc.Register(15)
+			if !ok {
+				dbg.printLine(terminal.StyleError, "cannot read coprocessor PC register")
+				return nil
+			}
+
+			// the PC register is one instruction ahead of the instruction
+			// that will be executed next
+			dbg.coprocSource(coproc, pc-2)
+
 		case "STEP":
 			dbg.CoProcDev.BreakNextInstruction()
 			dbg.runUntilHalt = true
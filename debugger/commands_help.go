@@ -21,7 +21,20 @@ var helps = map[string]string{
 	commandline.HelpCommand: "Lists commands and provides help for individual commands.",
 
 	cmdReset: `Reset the emulated machine (including television) to its initial state. The
-debugger itself (breakpoints, etc.) will not be reset.`,
+debugger itself (breakpoints, etc.) will not be reset.
+
+The PATTERN argument sets the pattern used to fill RAM on reset. This is useful for finding
+programs that rely on the power-on state of RAM. ZERO and FF fill RAM with 0x00 or 0xff. ALTERNATE
+fills RAM with alternating 0x00 and 0xff values. RANDOM fills RAM with random values from the
+optional seed. The same seed will always produce the same RAM contents. The pattern is not used
+if the random state preference is set.
+
+The PC argument sets the address that the CPU will start executing from after a reset, instead of
+the address in the cartridge's reset vector. The address is used for every subsequent reset until
+it is cleared with OFF or until a new cartridge is inserted. This is useful for testing a routine
+that has no reset vector of its own. For example:
+
+	RESET PC 0xf100`,
 
 	cmdQuit: `Quit the debugger. If script is being recorded then QUIT will instead halt
 recording of the script and not cause the debugger to exit.`,
@@ -37,7 +50,14 @@ the emulation can be stepped backwards too.
 The OVER option changes how the STEP command works with JSR opcodes. Stepping OVER a JSR opcode causes
 the STEP to end on the programme after the corresponding RTS. Note that if there is no RTS then the program
 will run forever and you will need to stop the execution with the HALT command (or through the debugging GUI
-or with a CTRL-C on some terminals)`,
+or with a CTRL-C on some terminals)
+
+The COPROC option executes a single instruction in the cartridge coprocessor. The 6507 is not advanced.
+The PC of the coprocessor after the instruction has executed is displayed.
+
+The BANK mode runs the emulation until the cartridge changes the bank that the CPU is executing
+from. The emulation halts on the instruction boundary after the change and the bank numbers before
+and after the change are displayed.`,
 
 	cmdQuantum: `Change or view the stepping quantum. The stepping quantum defines the
 frequency at which the emulation is checked and reported upon by the emulation when
@@ -56,7 +76,12 @@ and precisely when the registers in the TIA change.
 
 The three quantums have been listed above in order of descending efficiency.
 In other words INSTRUCTION produces the fastest emulation and CLOCK produces
-the slowest emulation.`,
+the slowest emulation.
+
+The COPROC quantum is available for cartridges with a coprocessor. In this
+quantum, the STEP command executes a single coprocessor instruction (see the
+COPROC option of the STEP command). The previous quantum is still used when
+the emulation is running.`,
 
 	cmdScript: `Run commands from specified file or record commands to a file. The RECORD
 argument indicates that a new script is to be recorded. Recording will not
@@ -88,12 +113,40 @@ frame values are optional.
 
 May leave the emulation mid CPU instruction but will not change the stepping quantum.`,
 
+	cmdAssert: `Compare a CPU register or television coordinate against the expected value. If the
+values do not match then an error is printed. When used in a script, a failed assertion will
+end the script immediately.
+
+	ASSERT A 0x5a
+	ASSERT SCANLINE 40
+
+Assertions are most useful for creating self-checking scripts.`,
+
+	cmdSeed: `Set the seed used for all random numbers in the emulation and reset the machine. Emulations
+with the same seed and the same input will produce identical runs. This includes the random state
+on reset (if the preference is set) and random numbers requested by coprocessor programs. With no
+argument the current seed is displayed.`,
+
 	cmdInsert: `Insert cartridge into emulation. Cartridge names (with paths) beginning with
 http:// will loaded via the http protocol. If no such protocol is present, the
 cartridge will be loaded from disk.`,
 
 	cmdCartridge: `Display information about the current cartridge. Without arguments the command
-will show where the game was loaded from, the cartridge type and bank number.`,
+will show where the game was loaded from, the cartridge type and, for cartridges with more than one
+bank, the number of the bank currently mapped to the PC address.
+
+DIFF compares the banks of the current cartridge with the named ROM file and lists every byte that
+differs, by bank number and offset into the bank. The comparison is made with the current contents
+of the cartridge so changes made with POKE or PATCH will be listed.
+
+HOTSPOTS lists the hotspot addresses of the cartridge mapper. These are the addresses that cause a
+bankswitch or which have some other special purpose. Each hotspot is shown with its symbol, its
+purpose and whether it is triggered by reading or writing the address.
+
+ROMWRITES turns ON or OFF the logging of writes to the cartridge address space that are not to a
+bankswitch hotspot or to cartridge RAM. Writes to ROM have no effect on real hardware but they can
+indicate a bug in the program or an undocumented hotspot. Without an argument the current setting
+is displayed.`,
 
 	cmdPatch: "Apply a patch file to the loaded cartridge",
 
@@ -102,13 +155,30 @@ banks can be displayed by specifying the bank number. Use BYTECODE to display ra
 the disassembly.
 
 The optional numeric argument will show the disassembly of either the cartridge bank (if present) or
-of the specific cartridge address.`,
+of the specific cartridge address.
+
+COMPARE will compare the disassembly against a reference listing file and report every address
+where the operator or operand differs. Each line of the reference listing should contain an
+address, an operator and an optional operand. Text after a semi-colon is ignored.
+
+EXPORT will write the disassembly to the named file as source that can be reassembled with DASM.
+Labels from the symbol table are included and anything that is not a recognised instruction is
+written as byte data.
+
+COLUMNS sets the optional columns that are displayed by the DISASM and LAST commands. The listed
+columns are enabled and all other optional columns are disabled. The optional columns are BYTECODE,
+CYCLES, LABEL and NOTES. By default the CYCLES and LABEL columns are enabled. The NOTES column only
+applies to DISASM because LAST always shows the notes. The current setting is displayed if no
+columns are listed. The setting is saved to the preferences file.`,
 
 	cmdGrep: `Simple string search (case insensitive) of the disassembly. Prints all matching lines
 in the disassembly to the termain.
 
 The scope of the GREP can be restricted to the OPERATOR and OPERAND columns. By
-default GREP will consider the entire line.`,
+default GREP will consider the entire line.
+
+REFERENCES will list every instruction, in every bank, that reads, writes or jumps to the specified
+address. The address can be numeric or a symbol.`,
 
 	cmdSymbol: `The SYMBOL command displays symbolic information about a memory address. Addresses can be
 specified by symbol.
@@ -153,13 +223,27 @@ ON argument to resume ONTRACE reporting.`,
 
 	cmdLast: `Prints the disassembly of the last cpu/video cycle. Use the BYTECODE argument 
 to display the raw bytes alongside the disassembly. The DEFN argument meanwhile
-will display the definition of the opcode that was used during execution.`,
+will display the definition of the opcode that was used during execution.
+
+For instructions that use indexed or indirect addressing, the effective address
+(the address in memory that was actually accessed) is shown after the operand.`,
 
 	cmdMemMap: `Display high-level VCS memory map. With the optional address argument information
 about the address will be displayed.`,
 
 	cmdCPU: `Display the current state of the CPU. The SET argument can be used to change the
-contents of the CPU registers.`,
+contents of the CPU registers.
+
+The STACK argument displays the subroutine call depth. This is the number of JSR instructions that
+have not been matched by an RTS instruction. The return address of each call is also displayed,
+most recent call first, along with the stack address where the return address can be found. If the
+program has manipulated the stack then the return addresses may be meaningless and the call depth
+can be negative.
+
+The HISTOGRAM argument lists the number of times each opcode has been executed, most frequent
+first. Counting begins when the debugger starts and continues across resets of the machine.
+Instructions that are executed as part of a rewind are not counted. The CLEAR option resets all
+counts to zero.`,
 
 	cmdBus: `Display the state of the address and data bus.`,
 
@@ -196,10 +280,56 @@ additional RAM in the cartridge.`,
 
 Video and CPU cycles are counted from the beginning of the current scanline.
 
-The optional HMOVE argument will display the TIA HMOVE information instead.`,
+The HMOVE argument will display the state of the HMOVE process instead. This is the state of the
+HMOVE latch, the value of the ripple counter, the phase clock and the TV coordinates of the most
+recent HMOVE strobe. The ripple counter counts down from 15 after HMOVE has been strobed and is
+shown as a bar that shortens with every tick of the counter. The phase clock is marked when the
+ripple counter is being clocked.
+
+The AUDIO argument displays the state of the audio registers. The MUTE option silences
+one or both audio channels, which is useful for listening to each channel separately. The
+channel registers are not affected, only the output of the channel is muted:
+
+	TIA AUDIO MUTE 0
+
+Use NONE to restore both channels.
+
+The LOG argument records every write to a TIA or RIOT register during the next
+frame to the specified file. Each line of the file is in CSV format and gives the
+frame, scanline and clock of the write, along with the register name and the
+value written. Logging stops automatically once the frame has ended. If the debugger
+quits before the end of the frame then the file contains the writes logged so far.
+
+The SNAPSHOT argument saves the current state of the TIA. The RESTORE argument
+replaces the TIA with the saved state, which is useful for re-running a section
+of the screen from a known TIA state. The CPU, RIOT and memory are not affected
+and neither are the TIA registers that are stored in memory, such as the
+collision registers. Note that restoring the TIA can cause it to be out of sync
+with the rest of the machine, including the television.
+
+The REVISION argument lists the TIA revision bugs that are currently enabled. Specifying a preset
+will enable the combination of bugs typical of that type of console. The presets are STANDARD,
+EARLYNTSC, LATENTSC and PAL. The change is not saved to the preferences file.`,
 
 	cmdRIOT: `Display current state of the RIOT. Without an argument the command will display
-information about the RIOT ports (SWCHA, etc.)`,
+information about the RIOT ports (SWCHA, etc.)
+
+The TIMER argument displays the state of the RIOT timer. The timer can be set by
+specifying the interval and the count. The effect is the same as the CPU writing
+the count to the TIM1T, TIM8T, TIM64T or T1024T register. The interval must be
+one of 1, 8, 64 or 1024:
+
+	RIOT TIMER SET 64 10
+
+The INPT argument displays the INPTx input registers. Strictly, these registers are part
+of the TIA but they are driven by the peripherals attached to the RIOT ports. A register
+can be forced to a specific value by specifying the register number and the value:
+
+	RIOT INPT 4 0x00
+
+The forced value will remain, regardless of the attached peripheral, until it is released:
+
+	RIOT INPT 4 RELEASE`,
 
 	cmdAudio: `Display the current state of the audio subsystem.
 
@@ -214,13 +344,38 @@ information about the RIOT ports (SWCHA, etc.)`,
 
 	cmdTV: `Display the current TV state. Optional argument SPEC will display the currently
 selected TV specification. Supplying an argument to the TV SPEC command will set the TV to that
-specification. AUTO indicates that the specification will change if the condition of the TV signal
-suggest that it should.`,
+specification, even if the TV was created with a specific specification. AUTO indicates that the
+specification will change if the condition of the TV signal suggest that it should. The refresh
+rate and number of scanlines for the resulting specification are also displayed.
+
+The PALETTE argument will load a custom palette for the current TV specification. The palette file
+should contain 128 colors of three bytes each (red, green and blue). The built-in palette is
+restored if no file is specified.
+
+The SIGNALS argument will display the signals received by the TV for the specified scanline. The
+HSYNC, VSYNC, VBLANK, pixel and audio information is shown for every clock.
+
+The STABILIZE argument will cause the TV to report as stable from the next frame, without waiting
+for the usual number of synchronised frames. This is useful in scripts that test behaviour that only
+happens once the TV is stable. It is not possible to stabilize the TV while an AUTO specification
+is being decided.`,
+
+	cmdClocks: `Display the current timing of the emulation. The number of CPU cycles since the
+last reset and the number of color clocks since the start of the current scanline are shown,
+along with the clock speed of the cartridge's coprocessor and the number of coprocessor cycles
+consumed by the most recent execution of the coprocessor program. There are three color clocks
+for every CPU cycle.`,
 
 	cmdPlayer: `Display the current state of the player sprites. The player information to
 display can be selected with 0 or 1 arguments. Omitting this argument will show
 information for both players.
 
+The POS argument, which requires a player to be selected, will move the player
+so that it is drawn at the specified pixel. The pixel is counted from the left
+edge of the visible screen and must be between 0 and 159. The new position
+takes effect from the next scanline. The effect is the same as a perfectly
+timed write to the RESP0 or RESP1 register.
+
         player0: 101100 (36) _.--.__*--._ [021 > 0x0 > 016] | vdel
 
            |           |           |              |         |   |
@@ -269,6 +424,10 @@ or will move later in the frame/scanline.`,
 display can be selected with the 0 or 1 arguments. Omitting this argument will show information
 for both missiles.
 
+The POS argument, which requires a missile to be selected, will move the
+missile so that it is drawn at the specified pixel. See the PLAYER command for
+details.
+
         missile0: 011101 (30) _*--.__.--._ [002 > 0x0 > 002] | disb
 
            |           |           |               |         |   |
@@ -313,6 +472,9 @@ or will move later in the frame/scanline.`,
 
 	cmdBall: `Display the current state of the ball sprite.
 
+The POS argument will move the ball so that it is drawn at the specified pixel.
+See the PLAYER command for details.
+
         ball: 011010 (21) _*--.__.--._ [038 > 0x0 > 038] disb
 
            |        |           |               |         |
@@ -358,7 +520,11 @@ The playfield registers are presented as they are stored. The TIA of course,
 reads the bits in a different order but that is not represented here.
 
 The notes field shows the following information as appropriate: priority mode
-(as in the example above); scoremode; reflected mode.`,
+(as in the example above); scoremode; reflected mode.
+
+The ASCII argument will show the playfield as it would be drawn across the
+scanline, with the bits in the order the TIA reads them and with any
+reflection applied. Set bits are shown as '#' and unset bits as '.'.`,
 
 	// peripherals (components that might not be present)
 	cmdPlusROM: `Controls the attached PlusROM. HOST and PATH can be changed on a per cartridge
@@ -375,6 +541,90 @@ will have a "FPU" group.
 
 The SET argument will set a register value. The 'register' number must be the 'extended register'
 number rather than the display number.
+
+Alternatively, a general register can be specified with the 'R' prefix. For example, R0 or R1. In
+this form the register is seeded with the value before the next execution of the coprocessor
+program. This is useful for testing coprocessor routines in isolation. The SP, LR and PC registers
+cannot be seeded.
+
+The STATUS argument displays the condition flags of the coprocessor's status register. An upper
+case letter indicates that the flag is set and a lower case letter that it is clear. The N, Z, C
+and V flags can be changed with the SET option. For example, STATUS SET Z 1 will set the zero flag.
+This is useful for testing conditional paths in the coprocessor program. Any IT block state is not
+affected by the change.
+
+The RESET argument resets the coprocessor registers to the values given by the cartridge's reset
+vectors. The 6507, the VCS RAM and the TIA are not affected. This is useful for running the
+coprocessor program again from the beginning without resetting the whole machine. Only cartridge
+types where the coprocessor program runs to completion, such as CDF and DPC+, can be reset.
+
+The CLK argument will set the clock speed of the coprocessor in MHz. The change is made through the
+ARM preferences and so will affect the cycle budget of the coprocessor program. Without a value the
+current clock speed is displayed.
+
+The MAM argument will set the mode of the memory accelerator module (MAM) to OFF, PARTIAL or FULL.
+The DRIVER mode uses the value set by the cartridge driver. The change is made through the ARM
+preferences and takes effect the next time the coprocessor program is run. This is useful for
+seeing how the MAM affects the cycle count of the coprocessor program. Without a value the current
+mode is displayed.
+
+The RELOAD argument will load the source for the coprocessor program again. This is useful if the
+ELF file has been rebuilt. Profiling information is kept for functions that have not moved. If the
+layout of the program has changed then the coprocessor breakpoints will be cleared.
+
+The DISASM argument will disassemble the coprocessor program starting at the current PC address.
+An alternative address can be specified. Disassembly is annotated with source lines if available.
+
+The SOURCE argument shows the source lines surrounding the instruction at the current PC address.
+The current line is marked with a '>' character. If there is no source for the PC address then the
+disassembly is shown instead.
+
+The MEMMAP argument shows the memory map of the coprocessor. This is the location of the Flash and
+SRAM regions for the coprocessor architecture, followed by the segments of the cartridge's static
+memory. For ELF cartridges the segments include the SRAM, the GPIO area, the StrongARM program and
+the ELF sections that have been loaded into memory.
+
+The RAM argument shows a hex dump of the coprocessor's SRAM. By default the entire SRAM is shown.
+An address and an optional length can be specified to show a smaller region. This is the
+coprocessor equivalent of the RAM command.
+
+The PROFILE argument lists the ten functions that used the most coprocessor cycles in the most
+recent frame. Each function is shown with its share of the cycles used by the whole program in that
+frame. The LINES option lists individual lines of source instead of functions.
+
+The FILES argument lists the source files found in the DWARF data, with the short and long
+filename and the number of lines in each file. Files that are listed in the DWARF data but which
+could not be found on disk are listed separately.
+
+The UNITS argument lists the compile units found in the DWARF data. Each unit is shown with the
+producer string, which names the compiler and the options used to build the unit, and with the
+number of entries in the unit. This is useful for checking that every part of the project has been
+found and for spotting units built with unexpected optimisation settings.
+
+The VARS argument lists the global variables found in the DWARF data. Each variable is shown with
+its address, name, type and size in bytes. The VAR argument shows the current value of a single
+global variable.
+
+The INTERLEAVE argument shows the percentage of source lines where the instructions for the line
+are interleaved with the instructions of another line. The interleaved lines are then listed. This
+is usually the result of the compiler reordering instructions during optimisation, which can make
+the profiling figures for those lines misleading.
+
+The IMMEDIATE argument turns immediate mode ON or OFF. In immediate mode the coprocessor does not
+count cycles, which is faster but less accurate. The change takes effect the next time the
+coprocessor runs. Without an argument the current mode is displayed.
+
+The BREAK argument turns coprocessor breakpoints ON or OFF. When breakpoints are OFF the coprocessor
+program will not halt on any breakpoint but the breakpoints are not cleared and will take effect
+again when breakpoints are turned back ON. Without an argument the current setting is displayed.
+
+The BREAKEND argument turns ON or OFF the halting of the emulation when the coprocessor program has
+run to completion and returned to the VCS. This is only meaningful for cartridge types where the
+coprocessor program runs to completion, such as CDF and DPC+. Without an argument the current
+setting is displayed.
+
+The YIELD argument will display the synchronisation state of the coprocessor and the reason for the
+most recent yield, along with the address of the instruction executing at the time of the yield.
 	`,
 
 	cmdDWARF: `Debugging information for cartridge types that support DWARF debugging.
@@ -419,8 +669,11 @@ about its state. Current targets are:
 
 	the CPU registers (PC, A, X, Y and SP)
 	the TV state (FRAMENUM, SCANLINE, CLOCK)
+	the number of CPU cycles since reset (CYCLE)
+	TIA collision latches (COLLISION <register>)
 	cartidge BANK
 	CPU result (RESULT OPERATOR, RESULT EFFECT, RESULT PAGEFAULT, RESULT BUG)
+	coprocessor registers (COPROC <register>)
 
 Specifying an address without a target will be assumed to be break on the PC
 and the current cartridge bank. So:
@@ -450,6 +703,36 @@ execution breaks on SL 10 & X 255. After resumption, the break will not apply
 until X changes from 255 to something else and then back again, or SL is hit on
 the next frame and X again (or still) has a value of 255.i
 
+A breakpoint can be turned into a tracepoint with the LOG argument. A tracepoint
+does not halt the emulation. Instead, the log message is printed and the
+emulation continues. For example:
+
+	BREAK SL 10 LOG "scanline ten: A=%A X=%X"
+
+The following placeholders in the log message are replaced with the current
+value of the CPU register: %A, %X, %Y, %SP and %PC. The log message should be
+quoted if it contains spaces.
+
+The COLLISION target halts the emulation on the color clock at which a bit in
+the named collision register is first set. For example:
+
+	BREAK COLLISION CXP0FB
+
+The register name can be omitted, in which case the emulation will halt when a
+bit in any collision register is first set. Collision registers are cleared by
+the CXCLR register.
+
+The COPROC target halts the emulation when a register in the cartridge's
+coprocessor contains the specified value. The register is named with or
+without the R prefix. It is most useful in combination with a 6507 condition.
+For example:
+
+	BREAK 0x1000 & COPROC R2 5
+
+The coprocessor registers are checked at the same time as the 6507 state and
+so the break will only happen when the 6507 condition is met and the
+coprocessor register has the specified value.
+
 Existing breakpoints can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
@@ -458,6 +741,20 @@ to any other value. Traps are very similar to breakpoints in some ways.  They
 can be applied to the same set of targets as BREAK (see help for BREAK command
 for details).
 
+In addition, the HMOVE target can be used to halt the emulation whenever the
+HMOVE register is strobed. The TV coordinates at the time of the strobe are
+reported along with the phase clock value and the resulting delay (in color
+clocks) before the HMOVE signal is decoded. For example:
+
+	TRAP HMOVE
+
+The AUDIO target can be used to halt the emulation whenever one of the TIA audio
+registers (AUDC0, AUDC1, AUDF0, AUDF1, AUDV0 and AUDV1) is written to. The trap
+fires even if the value written is the same as the previous value. The name of
+the register and the value written are reported. For example:
+
+	TRAP AUDIO
+
 Existing traps can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
@@ -512,6 +809,37 @@ The PHANTOM argument will halt execution if the address is unintentionally touch
 instruction decoding. This is sometimes useful to understand why cartridge RAM is being written too
 or why a cartridge hotspot is being triggered.
 
+A range of addresses can be watched with the RANGE argument. The two addresses that follow are the
+start and end of the range and are inclusive. The watch will halt execution on access of any address
+in the range and will report the specific address that was accessed.
+
+	WATCH WRITE RANGE 0x80 0x8f
+
+The above example will halt execution when any of the sixteen addresses between 0x80 and 0x8f are
+written to. The RANGE argument is required because a number following a single address is the value
+to watch for.
+
+Additional conditions can be placed on a watch with the & symbol. A condition is either a
+breakpoint target or a second memory address, followed by the value it must have for the
+watch to halt execution.
+
+	WATCH WRITE 0x80 & X 10
+
+	WATCH 0x80 & 0x81 0
+
+The first example will halt execution when address 0x80 is written to but only if the X register
+contains the value 10. The second example will halt execution on read access of address 0x80 but
+only if address 0x81 contains the value 0.
+
+The VECTORS argument will halt execution when the value of the NMI, RESET or IRQ vector changes.
+The old and new value of the vector is reported.
+
+	WATCH VECTORS
+
+Unlike other watches, the vectors are compared with their previous value after every CPU cycle,
+regardless of which address the CPU has accessed. This means that a change caused by cartridge
+bankswitching will also halt execution.
+
 Existing watches can be reviewed with the LIST command and deleted with the DROP or CLEAR commands`,
 
 	cmdTrace: `Trace activity on the specied memory address. This means any activity, read or write.
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 )
 
@@ -35,6 +36,8 @@ const (
 	cmdRewind     = "REWIND"
 	cmdComparison = "COMPARISON"
 	cmdGoto       = "GOTO"
+	cmdAssert     = "ASSERT"
+	cmdSeed       = "SEED"
 
 	cmdInsert    = "INSERT"
 	cmdCartridge = "CARTRIDGE"
@@ -57,6 +60,7 @@ const (
 	cmdRIOT      = "RIOT"
 	cmdAudio     = "AUDIO"
 	cmdTV        = "TV"
+	cmdClocks    = "CLOCKS"
 	cmdPlayer    = "PLAYER"
 	cmdMissile   = "MISSILE"
 	cmdBall      = "BALL"
@@ -88,46 +92,49 @@ const (
 )
 
 var commandTemplate = []string{
-	cmdReset,
+	cmdReset + fmt.Sprintf(" (PATTERN [%s] (%%<seed>N)|PC [OFF|%%<address>N])", strings.Join(preferences.RAMPatternList, "|")),
 	cmdQuit,
 
 	cmdRun,
-	cmdStep + " (BACK|OVER) (INSTRUCTION|CLOCK|SCANLINE|FRAME)",
+	cmdStep + " (BACK|OVER|COPROC) (INSTRUCTION|CLOCK|SCANLINE|FRAME|BANK)",
 	cmdHalt,
-	cmdQuantum + " (INSTRUCTION|CYCLE|CLOCK)",
+	cmdQuantum + " (INSTRUCTION|CYCLE|CLOCK|COPROC)",
 	cmdScript + " [RECORD %<new file>F|END|%<file>F]",
 	cmdRewind + " [%<frame>N|LAST|SUMMARY]",
 	cmdComparison + " [%<frame>N|LOCK|UNLOCK]",
 	cmdGoto + " [%<clock>N] (%<scanline>N) (%<frame>N)",
+	cmdAssert + " [A|X|Y|SP|PC|FRAME|SCANLINE|CLOCK] %<value>N",
+	cmdSeed + " (%<seed>N)",
 
 	cmdInsert + " %<cartridge>F",
-	cmdCartridge + " (PATH|NAME|MAPPER|CONTAINER|MAPPEDBANKS|HASH|STATIC|REGISTERS|RAM|DUMP|SETBANK %<bank>S|{%<mapper specific>X})",
+	cmdCartridge + " (PATH|NAME|MAPPER|CONTAINER|MAPPEDBANKS|HASH|HOTSPOTS|STATIC|REGISTERS|RAM|DUMP|DIFF %<file>F|ROMWRITES ([ON|OFF])|SETBANK %<bank>S|{%<mapper specific>X})",
 	cmdPatch + " %<patch file>S",
-	cmdDisasm + " (BYTECODE|REDUX)",
-	cmdGrep + " (OPERATOR|OPERAND|COPROC) %<search>S",
+	cmdDisasm + " (BYTECODE|REDUX|COMPARE [%<reference>F]|EXPORT [%<file>F]|COLUMNS {BYTECODE|CYCLES|LABEL|NOTES})",
+	cmdGrep + " (OPERATOR|OPERAND|COPROC|REFERENCES) %<search>S",
 	cmdSymbol + " [LIST (LABELS|READ|WRITE)|%<symbol>X]",
 	cmdOnHalt + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdOnStep + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdOnTrace + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdLast + " (DEFN|BYTECODE)",
 	cmdMemMap + " (%<address>S)",
-	cmdCPU + " (STATUS ([SET|UNSET|TOGGLE] [S|O|B|D|I|Z|C])|(SET [PC|A|X|Y|SP] [%<register value>S]))",
+	cmdCPU + " (STATUS ([SET|UNSET|TOGGLE] [S|O|B|D|I|Z|C])|(SET [PC|A|X|Y|SP] [%<register value>S])|STACK|HISTOGRAM (CLEAR))",
 	cmdBus + " (DETAIL)",
 	cmdPeek + " [%<address>S] {%<addresses>S}",
 	cmdPoke + " %<address>S [%<value>N] {%<values>N}",
 	cmdSwap + " %<address>S %<address>S",
 	cmdRAM,
-	cmdTIA + " (HMOVE)",
-	cmdRIOT + " (PORTS|TIMER)",
+	cmdTIA + fmt.Sprintf(" (AUDIO (MUTE ([0|1|BOTH|NONE]))|HMOVE|LOG %%<file>F|SNAPSHOT|RESTORE|REVISION ([%s]))", strings.Join(preferences.RevisionPresetList, "|")),
+	cmdRIOT + " (PORTS|TIMER (SET %<interval>N %<count>N)|INPT (%<register>N (RELEASE|%<value>N)))",
 	cmdAudio,
-	cmdTV + fmt.Sprintf(" (SPEC (%s))", strings.Join(specification.ReqSpecList, "|")),
-	cmdPlayer + " (0|1)",
-	cmdMissile + " (0|1)",
-	cmdBall,
-	cmdPlayfield,
+	cmdTV + fmt.Sprintf(" (SPEC (%s)|PALETTE (%%<palette>F)|SIGNALS [%%<scanline>N]|STABILIZE)", strings.Join(specification.ReqSpecList, "|")),
+	cmdClocks,
+	cmdPlayer + " ([0|1] (POS %<pixel>N))",
+	cmdMissile + " ([0|1] (POS %<pixel>N))",
+	cmdBall + " (POS %<pixel>N)",
+	cmdPlayfield + " (ASCII)",
 
 	cmdPlusROM + " (NICK [%<name>S]|ID [%<id>S]|HOST [%<host>S]|PATH [%<path>S])",
-	cmdCoProc + " (ID|LIST [FAULTS|SOURCEFILES|FUNCTIONS]|TOP (%<top>N)|MEM [DUMP {%<area>S}|SEARCH {%<value>N} {%<bitwidth>N}]|REGS %<group>S|SET %<register>N %<value>N|STEP)",
+	cmdCoProc + " (ID|LIST [FAULTS|SOURCEFILES|FUNCTIONS]|TOP (%<top>N)|MEM [DUMP {%<area>S}|SEARCH {%<value>N} {%<bitwidth>N}]|RAM (%<address>N (%<length>N))|PROFILE (FUNCTIONS|LINES)|REGS %<group>S|SET %<register>S %<value>N|STATUS (SET [N|Z|C|V] [0|1])|RESET|STEP|CLK (%<mhz>P)|MAM ([OFF|PARTIAL|FULL|DRIVER])|RELOAD|DISASM (%<address>N)|SOURCE|MEMMAP|FILES|UNITS|VARS|VAR %<name>S|INTERLEAVE|IMMEDIATE ([ON|OFF])|BREAK ([ON|OFF])|BREAKEND ([ON|OFF])|YIELD)",
 	cmdDWARF + " [FUNCTIONS|GLOBALS|LOCALS {DERIVATION|RANGES|ERROR}|FRAMEBASE {DERIVATION}|LINE %<file:line>S|CALLSTACK|CALLERS %<function>S]",
 
 	// user input
@@ -137,9 +144,9 @@ var commandTemplate = []string{
 	cmdKeypad + " [LEFT|RIGHT] [NONE|0|1|2|3|4|5|6|7|8|9|*|#]",
 
 	// halt conditions
-	cmdBreak + " [%<address>S|%<target>S %<value>N] {& %<address>S|%<target>S %<value>S}",
+	cmdBreak + " [%<address>S|%<target>S %<value>N] {& %<address>S|%<target>S %<value>S} (LOG %<message>S)",
 	cmdTrap + " [%<address>S] {%<address>S}",
-	cmdWatch + " (READ|WRITE) (STRICT) (PHANTOM|GHOST) [%<address>S] (%<value>S)",
+	cmdWatch + " (READ|WRITE) (STRICT) (PHANTOM|GHOST) [VECTORS|RANGE %<start>S %<end>S|%<address>S] (%<value>S) {& %<address>S|%<target>S %<value>S}",
 	cmdTrace + " (STRICT) (%<address>S)",
 	cmdList + " [BREAKS|TRAPS|WATCHES|TRACES|ALL]",
 	cmdDrop + " [BREAK|TRAP|WATCH|TRACE] %<number in list>N",
@@ -0,0 +1,61 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm/architecture"
+)
+
+// coprocMemoryModel is implemented by coprocessors that can describe the
+// memory model of their architecture
+type coprocMemoryModel interface {
+	MemoryModel() architecture.Map
+}
+
+// coprocMemMap prints the memory regions of the coprocessor architecture,
+// followed by the segments of the cartridge's static memory. for ELF
+// cartridges the segments include the loaded ELF sections
+func (dbg *Debugger) coprocMemMap(coproc coprocessor.CartCoProc) {
+	if m, ok := coproc.(coprocMemoryModel); ok {
+		mmap := m.MemoryModel()
+		dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("architecture: %s (%s)", mmap.CartArchitecture, mmap.ARMArchitecture))
+		dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("  %-20s %08x to %08x", "Flash", mmap.FlashOrigin, mmap.FlashMemtop))
+		dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("  %-20s %08x to %08x", "SRAM", mmap.SRAMOrigin, mmap.SRAMMemtop))
+	}
+
+	bus := dbg.vcs.Mem.Cart.GetStaticBus()
+	if bus == nil {
+		return
+	}
+	static := bus.GetStatic()
+	if static == nil {
+		return
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "segments:")
+	for _, seg := range static.Segments() {
+		// a segment with a memtop lower than the origin is empty
+		if seg.Memtop < seg.Origin {
+			dbg.printLine(terminal.StyleFeedbackSecondary, fmt.Sprintf("  %-20s %08x (empty)", seg.Name, seg.Origin))
+			continue
+		}
+		dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("  %-20s %08x to %08x", seg.Name, seg.Origin, seg.Memtop))
+	}
+}
@@ -0,0 +1,118 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+)
+
+// testELF returns a minimal relocatable ARM ELF file. the main function
+// returns immediately and is followed by a reference to a strongARM function,
+// which causes the strongARM program to be created when the ELF is loaded
+func testELF() []byte {
+	return testELFWithText([]byte{
+		0x70, 0x47, // BX LR
+		0x00, 0x00, // padding
+		0x00, 0x00, 0x00, 0x00, // address of vcsJmp3 after relocation
+	})
+}
+
+// testELFWithText is the same as testELF but with the specified main function.
+// the final four bytes of the text are relocated with the address of vcsJmp3
+func testELFWithText(text []byte) []byte {
+	strtab := []byte("\x00main\x00vcsJmp3\x00")
+	shstrtab := []byte("\x00.text\x00.rel.text\x00.symtab\x00.strtab\x00.shstrtab\x00")
+
+	// symbol 1 is main and symbol 2 is vcsJmp3
+	var symtab bytes.Buffer
+	binary.Write(&symtab, binary.LittleEndian, []elf.Sym32{
+		{},
+		{Name: 1, Info: elf.ST_INFO(elf.STB_GLOBAL, elf.STT_FUNC), Shndx: 1},
+		{Name: 6, Info: elf.ST_INFO(elf.STB_GLOBAL, elf.STT_NOTYPE), Shndx: uint16(elf.SHN_UNDEF)},
+	})
+
+	var rel bytes.Buffer
+	binary.Write(&rel, binary.LittleEndian, elf.Rel32{
+		Off:  uint32(len(text) - 4),
+		Info: elf.R_INFO32(2, uint32(elf.R_ARM_ABS32)),
+	})
+
+	// section data follows the file header
+	const headerSize = 52
+	textOff := uint32(headerSize)
+	relOff := textOff + uint32(len(text))
+	symtabOff := relOff + uint32(rel.Len())
+	strtabOff := symtabOff + uint32(symtab.Len())
+	shstrtabOff := strtabOff + uint32(len(strtab))
+	shOff := (shstrtabOff + uint32(len(shstrtab)) + 3) &^ 3
+
+	sections := []elf.Section32{
+		{},
+		{Name: 1, Type: uint32(elf.SHT_PROGBITS), Flags: uint32(elf.SHF_ALLOC | elf.SHF_EXECINSTR),
+			Off: textOff, Size: uint32(len(text)), Addralign: 4},
+		{Name: 7, Type: uint32(elf.SHT_REL), Off: relOff, Size: uint32(rel.Len()),
+			Link: 3, Info: 1, Addralign: 4, Entsize: 8},
+		{Name: 17, Type: uint32(elf.SHT_SYMTAB), Off: symtabOff, Size: uint32(symtab.Len()),
+			Link: 4, Info: 1, Addralign: 4, Entsize: 16},
+		{Name: 25, Type: uint32(elf.SHT_STRTAB), Off: strtabOff, Size: uint32(len(strtab)), Addralign: 1},
+		{Name: 33, Type: uint32(elf.SHT_STRTAB), Off: shstrtabOff, Size: uint32(len(shstrtab)), Addralign: 1},
+	}
+
+	hdr := elf.Header32{
+		Type:      uint16(elf.ET_REL),
+		Machine:   uint16(elf.EM_ARM),
+		Version:   uint32(elf.EV_CURRENT),
+		Shoff:     shOff,
+		Ehsize:    headerSize,
+		Shentsize: 40,
+		Shnum:     uint16(len(sections)),
+		Shstrndx:  5,
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS32)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, hdr)
+	b.Write(text)
+	b.Write(rel.Bytes())
+	b.Write(symtab.Bytes())
+	b.Write(strtab)
+	b.Write(shstrtab)
+	for b.Len() < int(shOff) {
+		b.WriteByte(0)
+	}
+	binary.Write(&b, binary.LittleEndian, sections)
+
+	return b.Bytes()
+}
+
+func (trm *mockTerm) testCoProcMemMap() {
+	trm.insertCartridge(newTestFile(trm.t, "memmap.elf", testELF()))
+
+	trm.sndInput("COPROC MEMMAP")
+	trm.rcvOutput()
+	trm.expectOutput("architecture: PlusCart")
+	trm.expectOutput("  Flash                20000000 to 2fffffff")
+	trm.expectOutput("  SRAM                 10000000 to 1fffffff")
+	trm.expectOutput("segments:")
+	trm.expectOutput("  SRAM                 10000000 to 10010000")
+	trm.expectOutput("  StrongARM Program    2000000c to ")
+	trm.expectOutput("  .text                20000000 to 2000000b")
+}
@@ -0,0 +1,106 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+)
+
+// the number of bytes shown on each line of the SRAM dump
+const coprocRAMLineLength = 16
+
+// coprocRAM prints a hex dump of the coprocessor's SRAM. the optional tokens
+// specify the address to start from and the number of bytes to dump. by
+// default the entire SRAM is dumped
+func (dbg *Debugger) coprocRAM(static mapper.CartStatic, tokens *commandline.Tokens) {
+	var origin uint32
+	for _, seg := range static.Segments() {
+		if seg.Name == "SRAM" {
+			origin = seg.Origin
+			break
+		}
+	}
+
+	data, ok := static.Reference("SRAM")
+	if !ok || len(data) == 0 {
+		dbg.printLine(terminal.StyleError, "cartridge does not have any coprocessor SRAM")
+		return
+	}
+
+	// the memtop field of the segment is not used because it is not consistent
+	// between cartridge types whether it is the last address in the segment or
+	// one beyond it
+	memtop := origin + uint32(len(data)) - 1
+
+	start := 0
+	end := len(data)
+
+	if arg, ok := tokens.Get(); ok {
+		a, err := strconv.ParseUint(arg, 0, 32)
+		if err != nil || uint32(a) < origin || uint32(a) > memtop {
+			dbg.printLine(terminal.StyleError, fmt.Sprintf("address must be between %08x and %08x", origin, memtop))
+			return
+		}
+		start = int(uint32(a) - origin)
+
+		if arg, ok := tokens.Get(); ok {
+			n, err := strconv.ParseUint(arg, 0, 32)
+			if err != nil || n == 0 {
+				dbg.printLine(terminal.StyleError, fmt.Sprintf("%s is not a valid length", arg))
+				return
+			}
+			end = min(start+int(n), len(data))
+		}
+	}
+
+	s := strings.Builder{}
+	ascii := strings.Builder{}
+
+	for i := start; i < end; i += coprocRAMLineLength {
+		s.Reset()
+		ascii.Reset()
+
+		s.WriteString(fmt.Sprintf("%08x ", origin+uint32(i)))
+
+		for j := i; j < i+coprocRAMLineLength; j++ {
+			if j-i == coprocRAMLineLength/2 {
+				s.WriteString(" ")
+			}
+
+			// the final line may be shorter than the others
+			if j >= end {
+				s.WriteString("   ")
+				continue
+			}
+
+			v := data[j]
+			s.WriteString(fmt.Sprintf(" %02x", v))
+			if v >= 0x20 && v <= 0x7e {
+				ascii.WriteByte(v)
+			} else {
+				ascii.WriteByte('.')
+			}
+		}
+
+		dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("%s  |%s|", s.String(), ascii.String()))
+	}
+}
@@ -0,0 +1,50 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCoProcRAM() {
+	// the main function writes the string "ABCD" to the start of SRAM and then
+	// calls vcsJmp3 repeatedly
+	text := []byte{
+		0x02, 0x48, // LDR R0, [PC, #8]
+		0x03, 0x49, // LDR R1, [PC, #12]
+		0x01, 0x60, // STR R1, [R0]
+		0x03, 0x4a, // LDR R2, [PC, #12]
+		0x90, 0x47, // BLX R2
+		0xfc, 0xe7, // B 0x06
+		0x00, 0x00, 0x00, 0x10, // start of SRAM
+		0x41, 0x42, 0x43, 0x44, // ABCD
+		0x00, 0x00, 0x00, 0x00, // address of vcsJmp3 after relocation
+	}
+	trm.insertCartridge(newTestFile(trm.t, "ram.elf", testELFWithText(text)))
+
+	trm.sndInput("COPROC RAM 0x10000000 16")
+	trm.cmpOutput("10000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|")
+
+	// the coprocessor program will have run after one frame
+	trm.sndInput("STEP FRAME")
+	trm.rcvOutput()
+
+	// the final line of the dump is shorter than the others
+	trm.sndInput("COPROC RAM 0x10000000 20")
+	trm.rcvOutput()
+	trm.expectOutput("10000000  41 42 43 44 00 00 00 00  00 00 00 00 00 00 00 00  |ABCD............|")
+	trm.cmpOutput("10000010  00 00 00 00                                       |....|")
+
+	// address outside of SRAM
+	trm.sndInput("COPROC RAM 0x20000000")
+	trm.cmpOutput("address must be between 10000000 and 1000ffff")
+}
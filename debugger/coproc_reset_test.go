@@ -0,0 +1,61 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testResetCoProc() {
+	// a DPC+ cartridge of all zero bytes. 3K driver, six 4K banks, 4K data and
+	// 1K frequency table
+	trm.insertCartridge(newDPCplusFile(trm.t, make([]byte, 32768)))
+
+	trm.sndInput("POKE 0x80 0x42")
+	trm.cmpOutput("0x0080 (RAM) -> 0x42")
+
+	trm.sndInput("COPROC REGS")
+	trm.rcvOutput()
+	reset := append([]string{}, trm.output...)
+
+	trm.sndInput("COPROC SET 0 0x1234")
+	trm.rcvOutput()
+	trm.sndInput("COPROC SET 1 0x5678")
+	trm.rcvOutput()
+	trm.sndInput("COPROC SET 13 0x40001000")
+	trm.rcvOutput()
+	trm.sndInput("COPROC REGS")
+	trm.rcvOutput()
+	trm.expectOutput("R00: 00001234\tR01: 00005678")
+	trm.expectOutput("R12: 00000000\tR13: 40001000")
+
+	trm.sndInput("COPROC RESET")
+	trm.cmpOutput("coproc has been reset")
+
+	// registers have returned to their reset values
+	trm.sndInput("COPROC REGS")
+	trm.rcvOutput()
+	if len(trm.output) != len(reset) {
+		trm.t.Errorf("unexpected number of lines from COPROC REGS after reset")
+	} else {
+		for i := range reset {
+			if trm.output[i] != reset[i] {
+				trm.t.Errorf("unexpected register values after reset (%s) should be (%s)", trm.output[i], reset[i])
+			}
+		}
+	}
+
+	// VCS RAM is unaffected
+	trm.sndInput("PEEK 0x80")
+	trm.rcvOutput()
+	trm.expectOutput("0x0080 (RAM) -> 0x42")
+}
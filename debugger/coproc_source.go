@@ -0,0 +1,71 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm"
+)
+
+// the number of instructions shown by coprocDisasm()
+const coprocDisasmWindow = 16
+
+// the number of lines either side of the current line shown by coprocSource()
+const coprocSourceContext = 3
+
+// coprocDisasm prints the disassembly of coprocessor memory starting at the
+// address. the disassembly is annotated with source lines if possible
+func (dbg *Debugger) coprocDisasm(coproc coprocessor.CartCoProc, addr uint32) {
+	entries := arm.PeekDisassemble(coproc, addr, coprocDisasmWindow)
+	if len(entries) == 0 {
+		dbg.printLine(terminal.StyleError, fmt.Sprintf("cannot disassemble coprocessor memory at %08x", addr))
+		return
+	}
+
+	dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+		var prev *dwarf.SourceLine
+		for _, e := range entries {
+			if src != nil {
+				if ln := src.FindSourceLine(e.Addr); ln != nil && ln != prev && !ln.IsStub() {
+					dbg.printLine(terminal.StyleFeedbackSecondary, fmt.Sprintf("%s: %s", ln.String(), strings.TrimSpace(ln.PlainContent)))
+					prev = ln
+				}
+			}
+			dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("%s %s %s", e.Address, e.Operator, e.Operand))
+		}
+	})
+}
+
+// coprocSource prints the source lines surrounding the address. if there is no
+// source for the address then the disassembly is printed instead
+func (dbg *Debugger) coprocSource(coproc coprocessor.CartCoProc, addr uint32) {
+	var found bool
+	dbg.CoProcDev.BorrowSource(func(src *dwarf.Source) {
+		if src != nil {
+			found = src.SourceContext(dbg.writerInStyle(terminal.StyleFeedback), addr, coprocSourceContext)
+		}
+	})
+
+	if !found {
+		dbg.printLine(terminal.StyleFeedbackSecondary, fmt.Sprintf("no source for %08x", addr))
+		dbg.coprocDisasm(coproc, addr)
+	}
+}
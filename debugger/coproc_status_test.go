@@ -0,0 +1,34 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCoProcStatus() {
+	// a DPC+ cartridge of all zero bytes. 3K driver, six 4K banks, 4K data and
+	// 1K frequency table
+	trm.insertCartridge(newDPCplusFile(trm.t, make([]byte, 32768)))
+
+	trm.sndInput("COPROC STATUS")
+	trm.cmpOutput("coproc status: nzcvq")
+
+	trm.sndInput("COPROC STATUS SET Z 1")
+	trm.cmpOutput("coproc status: nZcvq")
+
+	trm.sndInput("COPROC STATUS SET C 1")
+	trm.cmpOutput("coproc status: nZCvq")
+
+	trm.sndInput("COPROC STATUS SET Z 0")
+	trm.cmpOutput("coproc status: nzCvq")
+}
@@ -0,0 +1,52 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+)
+
+// stepCoProc executes a single instruction in the cartridge coprocessor. the
+// 6507 is not advanced
+func (dbg *Debugger) stepCoProc() error {
+	bus := dbg.vcs.Mem.Cart.GetCoProcBus()
+	if bus == nil {
+		dbg.printLine(terminal.StyleError, "cartridge does not have a coprocessor")
+		return nil
+	}
+
+	coproc := bus.GetCoProc()
+	stepper, ok := coproc.(coprocessor.CartCoProcStepper)
+	if !ok {
+		dbg.printLine(terminal.StyleError, "coprocessor does not support single stepping")
+		return nil
+	}
+
+	_, err := stepper.StepInstruction()
+	if err != nil {
+		dbg.printLine(terminal.StyleError, err.Error())
+		return nil
+	}
+
+	if pc, ok := coproc.Register(15); ok {
+		dbg.printLine(terminal.StyleInstrument, fmt.Sprintf("coprocessor PC: %08x", pc))
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newDPCplusFile writes the DPC+ cartridge data to a temporary file and returns
+// the filename. the identifying bytes of the DPC+ driver are added to the data
+// so that the mapper is detected when the file is inserted
+func newDPCplusFile(t *testing.T, data []byte) string {
+	t.Helper()
+
+	copy(data[0x20:], []byte{0x1e, 0xab, 0xad, 0x10})
+	copy(data[0x40:], []byte("DPC+DPC+"))
+
+	return newTestFile(t, "coproc.bin", data)
+}
+
+func (trm *mockTerm) testStepCoProc() {
+	// a DPC+ cartridge of all zero bytes. 3K driver, six 4K banks, 4K data and
+	// 1K frequency table
+	trm.insertCartridge(newDPCplusFile(trm.t, make([]byte, 32768)))
+
+	trm.sndInput("CPU SET PC 0xf000")
+	trm.cmpOutput("")
+
+	// the cartridge data is all zero, which the ARM decodes as a MOV
+	// instruction. each step advances the coprocessor PC by two bytes
+	var pc uint32
+	trm.sndInput("STEP COPROC")
+	trm.rcvOutput()
+	if len(trm.output) == 0 {
+		trm.t.Errorf("no output from STEP COPROC")
+		return
+	}
+	_, err := fmt.Sscanf(trm.output[len(trm.output)-1], "coprocessor PC: %08x", &pc)
+	if err != nil {
+		trm.t.Errorf("unexpected output from STEP COPROC (%s)", trm.output[len(trm.output)-1])
+		return
+	}
+
+	trm.sndInput("STEP COPROC")
+	trm.cmpOutput(fmt.Sprintf("coprocessor PC: %08x", pc+2))
+
+	// STEP in the COPROC quantum also steps the coprocessor
+	trm.sndInput("QUANTUM COPROC")
+	trm.cmpOutput("")
+	trm.sndInput("QUANTUM")
+	trm.cmpOutput("set to COPROC")
+	trm.sndInput("STEP")
+	trm.cmpOutput(fmt.Sprintf("coprocessor PC: %08x", pc+4))
+
+	// the 6507 has not been advanced
+	trm.sndInput("ASSERT PC 0xf000")
+	trm.cmpOutput("")
+
+	trm.sndInput("QUANTUM INSTRUCTION")
+	trm.cmpOutput("")
+	trm.sndInput("QUANTUM")
+	trm.cmpOutput("set to INSTRUCTION")
+}
@@ -0,0 +1,86 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+)
+
+// cpuHistogram counts the number of times each 6507 opcode has been executed
+// since the debugger was started or since the histogram was last cleared
+type cpuHistogram struct {
+	dbg *Debugger
+
+	counts [256]int
+	defns  [256]*instructions.Definition
+}
+
+// ObserveInstruction implements the cpu.InstructionObserver interface
+func (hist *cpuHistogram) ObserveInstruction(result execution.Result) {
+	// instructions executed while rewinding have already been counted
+	if hist.dbg.State() == govern.Rewinding {
+		return
+	}
+
+	hist.counts[result.Defn.OpCode]++
+	hist.defns[result.Defn.OpCode] = result.Defn
+}
+
+// clear all counts
+func (hist *cpuHistogram) clear() {
+	hist.counts = [256]int{}
+}
+
+// write the histogram to io.Writer. opcodes are listed in order of frequency,
+// most frequent first. opcodes that have not been executed are not listed
+func (hist *cpuHistogram) write(w io.Writer) {
+	type entry struct {
+		count int
+		defn  *instructions.Definition
+	}
+
+	var entries []entry
+	var total int
+	for i, c := range hist.counts {
+		if c > 0 {
+			entries = append(entries, entry{count: c, defn: hist.defns[i]})
+			total += c
+		}
+	}
+
+	if total == 0 {
+		w.Write([]byte("no instructions executed\n"))
+		return
+	}
+
+	// opcodes with the same count are listed in opcode order
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].count > entries[j].count
+	})
+
+	for _, e := range entries {
+		w.Write([]byte(fmt.Sprintf("%10d %5.1f%%  %02x %s %s\n", e.count,
+			float64(e.count)*100/float64(total), e.defn.OpCode,
+			e.defn.Operator, e.defn.AddressingMode)))
+	}
+	w.Write([]byte(fmt.Sprintf("%10d total\n", total)))
+}
@@ -0,0 +1,50 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCPUHistogram() {
+	//	LDA $80
+	//	NOP
+	//	NOP
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa5, 0x80, 0xea, 0xea, 0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("CPU HISTOGRAM CLEAR")
+	trm.cmpOutput("instruction histogram cleared")
+	trm.sndInput("CPU HISTOGRAM")
+	trm.cmpOutput("no instructions executed")
+
+	// four iterations of the loop
+	for range 16 {
+		trm.sndInput("STEP")
+		trm.rcvOutput()
+	}
+
+	// opcodes are sorted by frequency. opcodes with the same frequency are
+	// sorted by opcode
+	trm.sndInput("CPU HISTOGRAM")
+	trm.rcvOutput()
+	trm.expectOutput("         8  50.0%  ea nop Implied")
+	trm.expectOutput("         4  25.0%  4c jmp Absolute")
+	trm.expectOutput("         4  25.0%  a5 lda ZeroPage")
+	trm.expectOutput("        16 total")
+	if len(trm.output) != 4 {
+		trm.t.Errorf("unexpected number of lines in histogram (%d)", len(trm.output))
+	}
+	if trm.output[0] != "         8  50.0%  ea nop Implied" || trm.output[1] != "         4  25.0%  4c jmp Absolute" {
+		trm.t.Errorf("histogram is not sorted correctly")
+	}
+}
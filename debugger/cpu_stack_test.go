@@ -0,0 +1,43 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCPUStack() {
+	//	$F000	JSR $F010
+	//	$F010	JSR $F020
+	//	$F020	JMP $F020
+	code := make([]byte, 0x030)
+	copy(code[0x000:], []byte{0x20, 0x10, 0xf0})
+	copy(code[0x010:], []byte{0x20, 0x20, 0xf0})
+	copy(code[0x020:], []byte{0x4c, 0x20, 0xf0})
+	trm.insertCartridge(newTestROM(trm.t, code))
+
+	trm.sndInput("CPU STACK")
+	trm.rcvOutput()
+	trm.expectOutput("call depth: 0")
+
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+
+	// return addresses are listed most recent call first
+	trm.sndInput("CPU STACK")
+	trm.rcvOutput()
+	trm.expectOutput("call depth: 2")
+	trm.expectOutput(" 0: 0xf013 (stack 0x01fc)")
+	trm.expectOutput(" 1: 0xf003 (stack 0x01fe)")
+}
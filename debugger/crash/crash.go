@@ -0,0 +1,140 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/resources/unique"
+	"github.com/jetsetilly/gopher2600/rewind"
+)
+
+// Bundle contains the information about the state of the emulation that is
+// written to the crash file.
+type Bundle struct {
+	// the filename and hash of the loaded ROM
+	ROM      string
+	HashSHA1 string
+	HashMD5  string
+
+	// summary of the frames held in the rewind history
+	RewindSummary string
+
+	// export of the most recent state in the rewind history and the user
+	// input recorded since the earliest state. the export is written to a
+	// separate file and can be loaded with LoadRewind()
+	Rewind rewind.Export
+
+	// the last state of the CPU and the coprocessor (if there is one)
+	CPU    string
+	CoProc string
+}
+
+// Gather is called to collect the Bundle when a panic has occurred.
+type Gather func() Bundle
+
+// Recover should be called with defer. If a panic has occurred then a crash
+// bundle is written to the directory specified by the path argument. The panic
+// is then resumed.
+func Recover(path string, gather Gather) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	fn, err := Write(path, gather(), r, debug.Stack())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "crash: cannot write crash bundle: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "crash: crash bundle written to %s\n", fn)
+	}
+
+	panic(r)
+}
+
+// the extension of the file containing the rewind export. the file has the
+// same name as the crash report but with this extension in place of ".txt"
+const rewindExtension = ".rewind"
+
+// Write the crash bundle to a uniquely named file in the directory specified
+// by the path argument. The rewind export is written to a file of the same
+// name but with a different extension. Returns the name of the crash report.
+func Write(path string, bundle Bundle, r any, stack []byte) (string, error) {
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+
+	base := filepath.Join(path, unique.Filename("crash", filepath.Base(bundle.ROM)))
+	fn := fmt.Sprintf("%s.txt", base)
+	rewindFn := fmt.Sprintf("%s%s", base, rewindExtension)
+
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("panic: %v\n\n", r))
+	s.WriteString(fmt.Sprintf("rom: %s\n", bundle.ROM))
+	s.WriteString(fmt.Sprintf("sha1: %s\n", bundle.HashSHA1))
+	s.WriteString(fmt.Sprintf("md5: %s\n\n", bundle.HashMD5))
+	s.WriteString(fmt.Sprintf("cpu: %s\n", bundle.CPU))
+	if bundle.CoProc != "" {
+		s.WriteString(fmt.Sprintf("coproc: %s\n", bundle.CoProc))
+	}
+	s.WriteString(fmt.Sprintf("\nrewind summary: %s\n", bundle.RewindSummary))
+	s.WriteString(fmt.Sprintf("rewind export: %s\n", filepath.Base(rewindFn)))
+	s.WriteString(fmt.Sprintf("\n%s", stack))
+
+	err = os.WriteFile(fn, []byte(s.String()), 0600)
+	if err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+
+	data, err := json.Marshal(bundle.Rewind)
+	if err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+
+	err = os.WriteFile(rewindFn, data, 0600)
+	if err != nil {
+		return "", fmt.Errorf("crash: %w", err)
+	}
+
+	return fn, nil
+}
+
+// LoadRewind loads the rewind export for the crash report named by the fn
+// argument. The returned export can be restored to a VCS with the
+// rewind.Export.Restore() function.
+func LoadRewind(fn string) (rewind.Export, error) {
+	var exp rewind.Export
+
+	fn = fmt.Sprintf("%s%s", strings.TrimSuffix(fn, filepath.Ext(fn)), rewindExtension)
+
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return exp, fmt.Errorf("crash: %w", err)
+	}
+
+	err = json.Unmarshal(data, &exp)
+	if err != nil {
+		return exp, fmt.Errorf("crash: %w", err)
+	}
+
+	return exp, nil
+}
@@ -0,0 +1,187 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package crash_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger/crash"
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/plugging"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/rewind"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a stub of an emulation loop that panics
+func stubRun(path string, gather crash.Gather) {
+	defer crash.Recover(path, gather)
+	panic("undecoded instruction")
+}
+
+func TestCrashBundle(t *testing.T) {
+	path := t.TempDir()
+
+	bundle := crash.Bundle{
+		ROM:           "test.bin",
+		HashSHA1:      "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		HashMD5:       "d41d8cd98f00b204e9800998ecf8427e",
+		RewindSummary: "c0 f1 f2",
+		CPU:           "PC=f000 A=00 X=00 Y=00 SP=ff",
+		CoProc:        "R0: 00000000",
+	}
+
+	var gathered bool
+	gather := func() crash.Bundle {
+		gathered = true
+		return bundle
+	}
+
+	// the panic should be resumed after the crash bundle has been written
+	var r any
+	func() {
+		defer func() {
+			r = recover()
+		}()
+		stubRun(path, gather)
+	}()
+	test.ExpectEquality(t, r, "undecoded instruction")
+	test.ExpectSuccess(t, gathered)
+
+	// there should be exactly one crash file
+	files, err := filepath.Glob(filepath.Join(path, "crash_test.bin_*.txt"))
+	test.DemandSuccess(t, err)
+	test.DemandEquality(t, len(files), 1)
+
+	data, err := os.ReadFile(files[0])
+	test.DemandSuccess(t, err)
+
+	report := string(data)
+	test.ExpectSuccess(t, strings.Contains(report, "panic: undecoded instruction"))
+	test.ExpectSuccess(t, strings.Contains(report, bundle.HashSHA1))
+	test.ExpectSuccess(t, strings.Contains(report, bundle.HashMD5))
+	test.ExpectSuccess(t, strings.Contains(report, bundle.RewindSummary))
+	test.ExpectSuccess(t, strings.Contains(report, bundle.CPU))
+	test.ExpectSuccess(t, strings.Contains(report, bundle.CoProc))
+}
+
+func TestCrashBundleRewind(t *testing.T) {
+	path := t.TempDir()
+
+	bundle := crash.Bundle{
+		ROM: "test.bin",
+		Rewind: rewind.Export{
+			Coords: coords.TelevisionCoords{Frame: 10, Scanline: 20, Clock: 30},
+			CPU: rewind.ExportCPU{
+				PC:     0xf002,
+				A:      0x42,
+				X:      0x01,
+				Y:      0x02,
+				SP:     0xfd,
+				Status: 0x30,
+			},
+			RAM: make([]uint8, 128),
+			Input: []rewind.ExportInput{
+				// an input event before the exported state which should
+				// not be replayed
+				{
+					Time: coords.TelevisionCoords{Frame: 9, Scanline: 100, Clock: 0},
+					Port: plugging.PortLeft,
+					Ev:   ports.Fire,
+					D:    "false",
+				},
+				{
+					Time: coords.TelevisionCoords{Frame: 10, Scanline: 21, Clock: 0},
+					Port: plugging.PortLeft,
+					Ev:   ports.Fire,
+					D:    "true",
+				},
+			},
+		},
+	}
+	for i := range bundle.Rewind.RAM {
+		bundle.Rewind.RAM[i] = uint8(i)
+	}
+
+	fn, err := crash.Write(path, bundle, "undecoded instruction", nil)
+	test.DemandSuccess(t, err)
+
+	exp, err := crash.LoadRewind(fn)
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, exp.Coords, bundle.Rewind.Coords)
+	test.ExpectEquality(t, exp.CPU, bundle.Rewind.CPU)
+	test.ExpectEquality(t, string(exp.RAM), string(bundle.Rewind.RAM))
+	test.DemandEquality(t, len(exp.Input), len(bundle.Rewind.Input))
+	for i := range exp.Input {
+		test.ExpectEquality(t, exp.Input[i], bundle.Rewind.Input[i])
+	}
+
+	// restore the export to a VCS running a program that loops at $F002
+	//
+	//	$F000 NOP
+	//	$F001 NOP
+	//	$F002 JMP $F002
+	vcs := hardwaretest.NewVCS(t, hardwaretest.ROM([]byte{0xea, 0xea, 0x4c, 0x02, 0xf0}))
+	test.DemandSuccess(t, exp.Restore(vcs))
+
+	test.ExpectEquality(t, vcs.CPU.PC.Value(), bundle.Rewind.CPU.PC)
+	test.ExpectEquality(t, vcs.CPU.A.Value(), bundle.Rewind.CPU.A)
+	test.ExpectEquality(t, vcs.CPU.SP.Value(), bundle.Rewind.CPU.SP)
+	test.ExpectEquality(t, string(vcs.Mem.RAM.RAM), string(bundle.Rewind.RAM))
+
+	// the fire button is read in bit 7 of INPT4
+	fire := func() bool {
+		t.Helper()
+		v, err := vcs.Mem.Read(cpubus.ReadAddressByRegister[cpubus.INPT4])
+		test.DemandSuccess(t, err)
+		return v&0x80 == 0x00
+	}
+	test.ExpectEquality(t, fire(), false)
+
+	// the fire button is pressed when the emulation reaches the scanline of
+	// the input event
+	err = vcs.Run(func() (govern.State, error) {
+		if vcs.TV.GetCoords().Scanline > 21 {
+			return govern.Ending, nil
+		}
+		return govern.Running, nil
+	})
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, fire(), true)
+}
+
+func TestNoCrash(t *testing.T) {
+	path := t.TempDir()
+
+	gather := func() crash.Bundle {
+		t.Errorf("gather function should not be called if there is no panic")
+		return crash.Bundle{}
+	}
+
+	func() {
+		defer crash.Recover(path, gather)
+	}()
+
+	files, err := os.ReadDir(path)
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, len(files), 0)
+}
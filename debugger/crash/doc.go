@@ -0,0 +1,31 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package crash writes a report to disk when the emulation panics. The report
+// identifies the ROM and records the last state of the CPU and the
+// coprocessor. A summary of the frames held in the rewind history is also
+// included.
+//
+// The most recent state in the rewind history is exported alongside the
+// report, together with the user input recorded since the earliest state in
+// the history. The export is loaded with LoadRewind() and can be restored to
+// a VCS, with the ROM inserted, in order to replay the emulation from that
+// point. Only the CPU registers and RAM are exported so the replay is an
+// approximation of the emulation that crashed.
+//
+// The Recover() function should be deferred by the function that runs the
+// emulation loop. The information in the report is collected by the Gather
+// function, which is only called if a panic has occurred.
+package crash
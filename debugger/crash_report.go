@@ -0,0 +1,66 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/debugger/crash"
+)
+
+// the directory (within the resources path) in which crash bundles are written
+const crashDir = "crash"
+
+// crashBundle collects the state of the emulation for the crash package. it
+// is called only after the emulation has panicked so care should be taken not
+// to assume that the emulation is in a sensible state.
+func (dbg *Debugger) crashBundle() crash.Bundle {
+	var b crash.Bundle
+
+	if dbg.cartload != nil {
+		b.ROM = dbg.cartload.Filename
+		b.HashSHA1 = dbg.cartload.HashSHA1
+		b.HashMD5 = dbg.cartload.HashMD5
+	}
+
+	if dbg.Rewind != nil {
+		b.RewindSummary = dbg.Rewind.String()
+		b.Rewind = dbg.Rewind.Export()
+	}
+
+	if dbg.vcs == nil {
+		return b
+	}
+
+	b.CPU = dbg.vcs.CPU.String()
+
+	if bus := dbg.vcs.Mem.Cart.GetCoProcBus(); bus != nil {
+		coproc := bus.GetCoProc()
+		if group, ok := coproc.RegisterSpec().Group(coprocessor.ExtendedRegisterCoreGroup); ok {
+			s := strings.Builder{}
+			for r := group.Start; r <= group.End; r++ {
+				if v, ok := coproc.Register(r); ok {
+					s.WriteString(fmt.Sprintf("%s: %08x ", group.Label(r), v))
+				}
+			}
+			b.CoProc = strings.TrimSpace(s.String())
+		}
+	}
+
+	return b
+}
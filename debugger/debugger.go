@@ -35,6 +35,7 @@ import (
 	coproc_dev "github.com/jetsetilly/gopher2600/coprocessor/developer"
 	coproc_dwarf "github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
 	coproc_disasm "github.com/jetsetilly/gopher2600/coprocessor/disassembly"
+	"github.com/jetsetilly/gopher2600/debugger/crash"
 	"github.com/jetsetilly/gopher2600/debugger/dbgmem"
 	"github.com/jetsetilly/gopher2600/debugger/govern"
 	"github.com/jetsetilly/gopher2600/debugger/script"
@@ -51,6 +52,8 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/plugging"
 	"github.com/jetsetilly/gopher2600/hardware/television"
 	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/hardware/tia"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio/mix"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/macro"
 	"github.com/jetsetilly/gopher2600/notifications"
@@ -61,6 +64,7 @@ import (
 	"github.com/jetsetilly/gopher2600/recorder"
 	"github.com/jetsetilly/gopher2600/reflection"
 	"github.com/jetsetilly/gopher2600/reflection/counter"
+	"github.com/jetsetilly/gopher2600/resources"
 	"github.com/jetsetilly/gopher2600/resources/unique"
 	"github.com/jetsetilly/gopher2600/rewind"
 	"github.com/jetsetilly/gopher2600/setup"
@@ -78,6 +82,9 @@ type Debugger struct {
 	// arguments from the command line
 	opts CommandLineOptions
 
+	// turbo loading of supercharger tapes
+	turboload *supercharger.TurboLoad
+
 	// current mode of the emulation. use setMode() to set the value
 	mode atomic.Value // emulation.Mode
 
@@ -96,6 +103,10 @@ type Debugger struct {
 	// preferences for the emulation
 	Prefs *Preferences
 
+	// pausing of the emulation when the host window loses focus. the policy
+	// is enabled by the AutoPauseOnBlur preference
+	autoPause govern.AutoPause
+
 	// reference to emulated hardware. this pointer never changes through the
 	// life of the emulation even though the hardware may change and the
 	// components may change (during rewind for example)
@@ -190,6 +201,24 @@ type Debugger struct {
 	// trace memory access
 	traces *traces
 
+	// the active chip log. nil if no chip log is active
+	chipLog *chipLog
+
+	// number of times each opcode has been executed
+	cpuHistogram *cpuHistogram
+
+	// TIA state saved by the TIA SNAPSHOT command. nil if no state has been
+	// saved
+	tiaSnapshot *tia.TIA
+
+	// observer of chip writes. attached to the emulation for the lifetime of
+	// the debugger
+	chipObserver *chipObserver
+
+	// audio channels muted by the TIA AUDIO MUTE command. the mute is applied
+	// by the GUI when the audio is mixed and is not part of the emulation state
+	audioMute mix.Mute
+
 	// commandOnHalt is the sequence of commands that runs when emulation
 	// halts
 	commandOnHalt       []*commandline.Tokens
@@ -206,6 +235,10 @@ type Debugger struct {
 	// Quantum to use when stepping/running
 	quantum atomic.Value // govern.Quantum
 
+	// STEP without arguments steps the cartridge coprocessor rather than the
+	// 6507. the quantum field is unchanged and is still used when running
+	coprocQuantum bool
+
 	// record user input to a script file
 	scriptScribe script.Scribe
 
@@ -270,6 +303,15 @@ type Debugger struct {
 	// when the emulation state is "inside" the WSYNC
 	stepOutOfVideoStepInputLoop bool
 
+	// the bank the CPU was executing from when the STEP BANK command was
+	// issued. used to report the change of bank when the emulation halts. nil
+	// if STEP BANK is not in progress
+	stepBankFrom *mapper.BankInfo
+
+	// the address the PC is set to after every reset of the machine. nil if
+	// the PC should be loaded from the reset vector as normal
+	resetPC *uint16
+
 	// some operations require that the input loop be restarted to make sure
 	// continued operation is not inside a video cycle loop
 	//
@@ -361,6 +403,15 @@ func NewDebugger(opts CommandLineOptions, create CreateUserInterface) (*Debugger
 		return nil, fmt.Errorf("debugger: %w", err)
 	}
 
+	// observe chip writes
+	dbg.chipObserver = &chipObserver{dbg: dbg}
+	dbg.vcs.SetChipWriteObserver(dbg.chipObserver)
+	dbg.vcs.TV.AddFrameTrigger(dbg.chipObserver)
+
+	// count executed instructions
+	dbg.cpuHistogram = &cpuHistogram{dbg: dbg}
+	dbg.vcs.CPU.SetInstructionObserver(dbg.cpuHistogram)
+
 	// create userinput/controllers handler
 	dbg.controllers = userinput.NewControllers(dbg.vcs.Input)
 
@@ -487,6 +538,15 @@ func NewDebugger(opts CommandLineOptions, create CreateUserInterface) (*Debugger
 	// set fps cap
 	dbg.vcs.TV.SetFPSCap(opts.FpsCap)
 
+	// turbo loading of supercharger tapes. audio is disabled while the tape
+	// is loading
+	dbg.turboload = supercharger.NewTurboLoad(dbg.vcs.TV, func(disabled bool) bool {
+		prev := dbg.vcs.TIA.AudioDisabled()
+		dbg.vcs.TIA.SetAudioDisabled(disabled)
+		return prev
+	})
+	dbg.turboload.Enabled = opts.TurboLoad
+
 	// initialise terminal
 	err = dbg.term.Initialise()
 	if err != nil {
@@ -682,6 +742,7 @@ func (dbg *Debugger) end() {
 	dbg.endPlayback()
 	dbg.endRecording()
 	dbg.endComparison()
+	dbg.endChipLog()
 	if dbg.macro != nil {
 		dbg.macro.Quit()
 	}
@@ -913,8 +974,12 @@ func (dbg *Debugger) CartYield(yield coprocessor.CoProcYield) coprocessor.YieldH
 
 	switch yield.Type {
 	case coprocessor.YieldProgramEnded:
-		// expected reason for CDF and DPC+ cartridges
-		return coprocessor.YieldHookContinue
+		// expected reason for CDF and DPC+ cartridges. the emulation only
+		// halts if it has been requested and the emulation is not initialising
+		if !dbg.halting.breakOnProgramEnd || dbg.State() == govern.Initialising {
+			return coprocessor.YieldHookContinue
+		}
+		dbg.halting.programEnded = true
 
 	case coprocessor.YieldSyncWithVCS:
 		// expected reason for ACE and ELF cartridges
@@ -950,6 +1015,14 @@ func (dbg *Debugger) CartYield(yield coprocessor.CoProcYield) coprocessor.YieldH
 }
 
 func (dbg *Debugger) run() error {
+	// write a crash bundle if the emulation panics. the panic is resumed after
+	// the bundle has been written
+	if pth, err := resources.JoinPath(crashDir); err != nil {
+		logger.Log(logger.Allow, "debugger", err)
+	} else {
+		defer crash.Recover(pth, dbg.crashBundle)
+	}
+
 	// end script recording gracefully. this way we don't have to worry too
 	// hard about script scribes
 	defer func() {
@@ -1043,10 +1116,23 @@ func (dbg *Debugger) run() error {
 // as well. it is sometimes appropriate to reset these (eg. on new cartridge
 // insert)
 func (dbg *Debugger) reset(newCartridge bool) error {
+	// the PC override is for the current cartridge only
+	if newCartridge {
+		dbg.resetPC = nil
+	}
+
 	err := dbg.vcs.Reset()
 	if err != nil {
 		return err
 	}
+
+	// override the PC loaded from the reset vector. this must happen before
+	// the rewind system is reset so that the override is part of the reset
+	// state
+	if dbg.resetPC != nil {
+		dbg.vcs.CPU.PC.Load(*dbg.resetPC)
+	}
+
 	dbg.Rewind.Reset()
 	dbg.Tracker.Reset()
 
@@ -1108,11 +1194,16 @@ func (dbg *Debugger) Notify(notice notifications.Notice) error {
 			dbg.vcs.Mem.Poke(supercharger.MutliloadByteAddress, uint8(dbg.opts.Multiload))
 		}
 
+		// run at full speed until the soundload has ended
+		dbg.turboload.Start()
+
 		err := dbg.gui.SetFeature(gui.ReqNotification, notifications.NotifySuperchargerSoundloadStarted)
 		if err != nil {
 			return err
 		}
 	case notifications.NotifySuperchargerSoundloadEnded:
+		dbg.turboload.End()
+
 		err := dbg.gui.SetFeature(gui.ReqNotification, notifications.NotifySuperchargerSoundloadEnded)
 		if err != nil {
 			return err
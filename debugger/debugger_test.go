@@ -17,6 +17,9 @@ package debugger_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
 	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
 	"github.com/jetsetilly/gopher2600/prefs"
 )
 
@@ -108,6 +112,26 @@ func (trm *mockTerm) rcvOutput() {
 	}
 }
 
+// rcvOutputUntil is similar to rcvOutput but continues to receive output
+// until a line beginning with the string argument has been received. useful
+// when the debugger is running the emulation and the amount of time before the
+// output is received is unpredictable
+func (trm *mockTerm) rcvOutputUntil(s string) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case o := <-trm.out:
+			trm.output = append(trm.output, o)
+			if strings.HasPrefix(o, s) {
+				return
+			}
+		case <-timeout:
+			trm.t.Errorf("timed out waiting for debugger output beginning with (%s)", s)
+			return
+		}
+	}
+}
+
 // cmpOutput compares the string argument with the *last line* of the most
 // recent output. it can easily be adapted to compare the whole output if
 // necessary.
@@ -131,11 +155,160 @@ func (trm *mockTerm) cmpOutput(s string) {
 	trm.t.Errorf(fmt.Sprintf("unexpected debugger output (%s) should be (%s)", trm.output[l], s))
 }
 
+// expectOutput checks that the most recent output contains a line beginning
+// with the string argument
+func (trm *mockTerm) expectOutput(s string) {
+	for _, o := range trm.output {
+		if strings.HasPrefix(o, s) {
+			return
+		}
+	}
+	trm.t.Errorf("expected debugger output beginning with (%s)", s)
+}
+
+// expectNoOutput checks that the most recent output does not contain a line
+// beginning with the string argument
+func (trm *mockTerm) expectNoOutput(s string) {
+	for _, o := range trm.output {
+		if strings.HasPrefix(o, s) {
+			trm.t.Errorf("unexpected debugger output (%s)", o)
+		}
+	}
+}
+
+// newTestFile writes the data to a file in a temporary directory and returns
+// the filename
+func newTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	fn := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(fn, data, 0644)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return fn
+}
+
+// newTestROM writes a 4k cartridge containing the code to a temporary file and
+// returns the filename. the code starts at address $F000
+func newTestROM(t *testing.T, code []byte) string {
+	t.Helper()
+	return newTestFile(t, "test.bin", hardwaretest.ROM(code))
+}
+
+// startDebugger creates a debugger with a mock terminal and starts it with the
+// cartridge file. the run function is called in a separate goroutine and
+// should end by sending the QUIT command. returns the error from
+// StartInDebugMode()
+func startDebugger(t *testing.T, opts debugger.CommandLineOptions, fn string, run func(trm *mockTerm)) error {
+	t.Helper()
+
+	prefs.DisableSaving = true
+
+	var trm *mockTerm
+
+	create := func(dbg *debugger.Debugger) (gui.GUI, terminal.Terminal, error) {
+		trm = newMockTerm(t)
+		return &mockGUI{}, trm, nil
+	}
+
+	dbg, err := debugger.NewDebugger(opts, create)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	go run(trm)
+
+	return dbg.StartInDebugMode(fn)
+}
+
+// insertCartridge inserts the cartridge file into the emulation. any output
+// caused by the insertion is discarded
+func (trm *mockTerm) insertCartridge(fn string) {
+	trm.sndInput("INSERT " + fn)
+	trm.rcvOutput()
+}
+
 func (trm *mockTerm) testSequence() {
 	defer func() { trm.sndInput("QUIT") }()
 	trm.testBreakpoints()
 	trm.testTraps()
 	trm.testWatches()
+	trm.testAssert()
+	trm.testTV()
+	trm.testAudioMute()
+	trm.testROMWrites()
+	trm.testDisasmColumns()
+	trm.testTracepoints()
+	trm.testStepCoProc()
+	trm.testBreakOnProgramEnd()
+	trm.testCoProcMemMap()
+	trm.testTrapHMOVE()
+	trm.testChipLog()
+	trm.testWatchVectors()
+	trm.testCoProcRAM()
+	trm.testCPUStack()
+	trm.testResetCoProc()
+	trm.testBreakCycle()
+	trm.testBreakCollision()
+	trm.testTrapAudio()
+	trm.testTIASnapshot()
+	trm.testCPUHistogram()
+	trm.testCoProcStatus()
+	trm.testTIAHmove()
+	trm.testStepBank()
+	trm.testResetPC()
+	trm.testBreakCoProc()
+	trm.testClocks()
+}
+
+func (trm *mockTerm) testTV() {
+	// forcing the specification changes the refresh rate and number of
+	// scanlines immediately
+	trm.sndInput("TV SPEC PAL")
+	trm.cmpOutput("actual=PAL, requested=PAL, refresh=50.08Hz, scanlines=312")
+	trm.sndInput("TV SPEC NTSC")
+	trm.cmpOutput("actual=NTSC, requested=NTSC, refresh=60.05Hz, scanlines=262")
+}
+
+func (trm *mockTerm) testROMWrites() {
+	// the ON and OFF arguments are not case sensitive
+	trm.sndInput("CARTRIDGE ROMWRITES on")
+	trm.cmpOutput("logging of ROM writes: ON")
+	trm.sndInput("CARTRIDGE ROMWRITES off")
+	trm.cmpOutput("logging of ROM writes: OFF")
+	trm.sndInput("CARTRIDGE ROMWRITES ON")
+	trm.cmpOutput("logging of ROM writes: ON")
+	trm.sndInput("CARTRIDGE ROMWRITES OFF")
+	trm.cmpOutput("logging of ROM writes: OFF")
+}
+
+func (trm *mockTerm) testDisasmColumns() {
+	trm.sndInput("DISASM COLUMNS")
+	trm.cmpOutput("columns: cycles, label")
+
+	// an unknown column is an error and the columns are not changed
+	trm.sndInput("DISASM COLUMNS BYTECODE FOO")
+	trm.cmpOutput("unrecognised argument (FOO) for DISASM")
+	trm.sndInput("DISASM COLUMNS")
+	trm.cmpOutput("columns: cycles, label")
+
+	trm.sndInput("DISASM COLUMNS label notes")
+	trm.cmpOutput("columns: label, notes")
+	trm.sndInput("DISASM COLUMNS CYCLES LABEL")
+	trm.cmpOutput("columns: cycles, label")
+}
+
+func (trm *mockTerm) testAudioMute() {
+	trm.sndInput("TIA AUDIO MUTE")
+	trm.cmpOutput("no channels muted")
+	trm.sndInput("TIA AUDIO MUTE 0")
+	trm.cmpOutput("channel 0 muted")
+	trm.sndInput("TIA AUDIO MUTE BOTH")
+	trm.cmpOutput("both channels muted")
+	trm.sndInput("TIA AUDIO MUTE NONE")
+	trm.cmpOutput("no channels muted")
 }
 
 func TestDebugger_withNonExistantInitScript(t *testing.T) {
@@ -0,0 +1,71 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package govern
+
+// AutoPause is the policy for pausing the emulation when the host window loses
+// focus and for resuming the emulation when focus is regained.
+//
+// The emulation is only resumed if it was paused by the policy. If the
+// emulation was already paused when focus was lost, or if the emulation was
+// resumed by some other means while focus was lost, then regaining focus will
+// not change the state of the emulation.
+//
+// The zero value is a disabled policy.
+type AutoPause struct {
+	enabled bool
+
+	// whether the emulation was paused by the policy
+	paused bool
+}
+
+// SetAutoPauseOnBlur enables or disables the policy. Disabling the policy
+// while the emulation is paused by the policy means that the emulation will
+// not be resumed when focus is regained.
+func (a *AutoPause) SetAutoPauseOnBlur(enabled bool) {
+	a.enabled = enabled
+	if !enabled {
+		a.paused = false
+	}
+}
+
+// AutoPauseOnBlur returns true if the policy is enabled.
+func (a *AutoPause) AutoPauseOnBlur() bool {
+	return a.enabled
+}
+
+// NotifyFocus should be called whenever the host window gains or loses focus.
+// The current state of the emulation is used to decide what the new state
+// should be. Returns the new state and true if the state of the emulation
+// should be changed.
+func (a *AutoPause) NotifyFocus(focused bool, current State) (State, bool) {
+	if focused {
+		if !a.paused {
+			return current, false
+		}
+		a.paused = false
+		if current == Paused {
+			return Running, true
+		}
+		return current, false
+	}
+
+	if a.enabled && current == Running {
+		a.paused = true
+		return Paused, true
+	}
+
+	return current, false
+}
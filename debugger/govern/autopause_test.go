@@ -0,0 +1,76 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package govern_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestAutoPause(t *testing.T) {
+	var a govern.AutoPause
+	var state govern.State
+	var ok bool
+
+	// focus changes have no effect when the policy is disabled
+	state, ok = a.NotifyFocus(false, govern.Running)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Running)
+
+	a.SetAutoPauseOnBlur(true)
+	test.ExpectEquality(t, a.AutoPauseOnBlur(), true)
+
+	// losing focus pauses a running emulation and regaining focus resumes it
+	state, ok = a.NotifyFocus(false, govern.Running)
+	test.ExpectEquality(t, ok, true)
+	test.ExpectEquality(t, state, govern.Paused)
+	state, ok = a.NotifyFocus(true, state)
+	test.ExpectEquality(t, ok, true)
+	test.ExpectEquality(t, state, govern.Running)
+
+	// regaining focus a second time has no effect
+	state, ok = a.NotifyFocus(true, state)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Running)
+
+	// an emulation that was already paused is not resumed when focus is regained
+	state, ok = a.NotifyFocus(false, govern.Paused)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Paused)
+	state, ok = a.NotifyFocus(true, state)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Paused)
+
+	// the emulation was resumed by other means while focus was lost
+	state, ok = a.NotifyFocus(false, govern.Running)
+	test.ExpectEquality(t, ok, true)
+	test.ExpectEquality(t, state, govern.Paused)
+	state, ok = a.NotifyFocus(true, govern.Running)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Running)
+
+	// disabling the policy while paused by the policy means the emulation is
+	// not resumed
+	state, ok = a.NotifyFocus(false, govern.Running)
+	test.ExpectEquality(t, ok, true)
+	test.ExpectEquality(t, state, govern.Paused)
+	a.SetAutoPauseOnBlur(false)
+	state, ok = a.NotifyFocus(true, state)
+	test.ExpectEquality(t, ok, false)
+	test.ExpectEquality(t, state, govern.Paused)
+}
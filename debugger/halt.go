@@ -40,6 +40,12 @@ type haltCoordination struct {
 	// the cartridge has issued a yield signal that we should stop the debugger for
 	cartridgeYield coprocessor.CoProcYield
 
+	// halt when the coprocessor program has run to completion. the
+	// programEnded flag is set by the cartridge yield hook when the halt
+	// condition has been met
+	breakOnProgramEnd bool
+	programEnded      bool
+
 	// the emulation must yield to the cartridge but it must be delayed until it
 	// is in a better state
 	//
@@ -94,11 +100,19 @@ func (h *haltCoordination) reset() {
 		Type: coprocessor.YieldProgramEnded,
 	}
 	h.televisionHalt = nil
+	h.programEnded = false
 }
 
 // check for a halt condition and set the halt flag if found. returns true if
 // emulation should continue and false if the emulation should halt
 func (h *haltCoordination) check() bool {
+	if h.programEnded {
+		h.haltReason = "coprocessor program ended"
+		h.dbg.printLine(terminal.StyleFeedback, h.haltReason)
+		h.halt = true
+		return false
+	}
+
 	if h.cartridgeYield.Type != coprocessor.YieldProgramEnded {
 		h.haltReason = string(h.cartridgeYield.Type)
 		// if h.cartridgeYield.Error != nil && h.cartridgeYield.Error.Error() != "" {
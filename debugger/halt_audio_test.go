@@ -0,0 +1,46 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testTrapAudio() {
+	//	LDA #$0F
+	//	STA AUDV0
+	//	LDX #$04
+	//	STX COLUBK
+	//	STX AUDC0
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa9, 0x0f, 0x85, 0x19, 0xa2, 0x04, 0x86, 0x09, 0x86, 0x15, 0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("TRAP AUDIO")
+	trm.cmpOutput("")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("trap on AUDIO")
+	trm.expectOutput("trap on AUDIO [none->AUDV0=0x0f]")
+
+	// the write to COLUBK does not cause the trap to fire
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("trap on AUDIO")
+	trm.expectOutput("trap on AUDIO [AUDV0=0x0f->AUDC0=0x04]")
+
+	// the trap fires on every write to an audio register
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("trap on AUDIO")
+	trm.expectOutput("trap on AUDIO [AUDC0=0x04->AUDV0=0x0f]")
+
+	trm.sndInput("CLEAR TRAPS")
+	trm.cmpOutput("traps cleared")
+}
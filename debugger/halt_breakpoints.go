@@ -61,6 +61,11 @@ type breaker struct {
 
 	// single linked list ANDs breakers together
 	next *breaker
+
+	// if log is not empty then the breaker is a tracepoint. rather than
+	// halting the emulation the log message is printed and the emulation
+	// continues. only meaningful for the head of the linked list
+	log string
 }
 
 func (bk breaker) String() string {
@@ -71,6 +76,9 @@ func (bk breaker) String() string {
 		s.WriteString(fmt.Sprintf(" & %s->%s", n.target.label, n.target.stringValue(n.value)))
 		n = n.next
 	}
+	if bk.log != "" {
+		s.WriteString(fmt.Sprintf(" LOG \"%s\"", bk.log))
+	}
 	return s.String()
 }
 
@@ -211,6 +219,9 @@ func (bp *breakpoints) drop(num int) error {
 // check compares the current state of the emulation with every breakpoint
 // condition. returns a string listing every condition that matches (separated
 // by \n).
+//
+// tracepoints that match are logged immediately and are not included in the
+// returned string.
 func (bp *breakpoints) check() string {
 	if len(bp.breaks) == 0 {
 		return ""
@@ -223,12 +234,30 @@ func (bp *breakpoints) check() string {
 		}
 
 		if bp.breaks[i].check() == checkMatch {
+			if bp.breaks[i].log != "" {
+				bp.dbg.printLine(terminal.StyleFeedback, bp.tracepointMessage(bp.breaks[i].log))
+				continue // for loop
+			}
 			checkString.WriteString(fmt.Sprintf("break on %s\n", bp.breaks[i]))
 		}
 	}
 	return checkString.String()
 }
 
+// tracepointMessage expands the register placeholders in the log message of
+// a tracepoint. supported placeholders are %A, %X, %Y, %SP and %PC
+func (bp *breakpoints) tracepointMessage(log string) string {
+	cpu := bp.dbg.vcs.CPU
+	r := strings.NewReplacer(
+		"%A", fmt.Sprintf("%02x", cpu.A.Value()),
+		"%X", fmt.Sprintf("%02x", cpu.X.Value()),
+		"%Y", fmt.Sprintf("%02x", cpu.Y.Value()),
+		"%SP", fmt.Sprintf("%02x", cpu.SP.Value()),
+		"%PC", fmt.Sprintf("%04x", cpu.PC.Address()),
+	)
+	return r.Replace(log)
+}
+
 // list currently defined breakpoints.
 func (bp breakpoints) list() {
 	if len(bp.breaks) == 0 {
@@ -283,11 +312,27 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 	// whether to add a bank condition to a singular PC BREAK target
 	addBankCondition := true
 
+	// log message for tracepoints
+	var log string
+
 	// loop over tokens:
 	// - if token is a valid type value then add the breakpoint for the current target
 	// - if it is not a valid type value, try to change the target
 	tok, present := tokens.Get()
 	for present {
+		// the LOG keyword turns the breakpoint into a tracepoint. the log
+		// message is the final token
+		if strings.ToUpper(tok) == "LOG" {
+			log, present = tokens.Get()
+			if !present || log == "" {
+				return fmt.Errorf("tracepoint requires a log message")
+			}
+			if !tokens.IsEnd() {
+				return fmt.Errorf("log message must be the final argument")
+			}
+			break // for loop
+		}
+
 		var val any
 		var err error
 
@@ -398,6 +443,8 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 	}
 
 	for _, nb := range newBreaks {
+		nb.log = log
+
 		// if the break is a singular, undecorated PC target then add a BANK
 		// condition for the current BANK. this is arguably what the user
 		// intends to happen.
@@ -58,3 +58,37 @@ func (trm *mockTerm) testBreakpoints() {
 	trm.sndInput("BREAK CL 100")
 	trm.cmpOutput("")
 }
+
+func (trm *mockTerm) testTracepoints() {
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+
+	// add tracepoint
+	trm.sndInput(`BREAK SL 10 LOG "tracepoint A=%A"`)
+	trm.cmpOutput("")
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(` 0: Scanline->10 LOG "tracepoint A=%A"`)
+
+	trm.sndInput("CPU SET A 0x5a")
+	trm.cmpOutput("")
+
+	// a regular breakpoint later in the frame
+	trm.sndInput("BREAK SL 200")
+	trm.cmpOutput("")
+
+	// the tracepoint is logged every frame but only the regular breakpoint
+	// halts the emulation
+	for range 2 {
+		trm.sndInput("RUN")
+		trm.rcvOutputUntil("break on Scanline->200")
+		trm.expectOutput("tracepoint A=5a")
+		trm.expectNoOutput("break on Scanline->10")
+	}
+
+	// a log message is required
+	trm.sndInput("BREAK SL 20 LOG")
+	trm.cmpOutput("tracepoint requires a log message")
+
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+}
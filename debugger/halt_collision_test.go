@@ -0,0 +1,55 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testBreakCollision() {
+	// the playfield covers the entire screen so the first pixel of player 0
+	// will collide with the playfield
+	//
+	//	LDA #$FF
+	//	STA PF0
+	//	STA PF1
+	//	STA PF2
+	//	STA GRP0
+	//	JMP $F00A
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa9, 0xff, 0x85, 0x0d, 0x85, 0x0e, 0x85, 0x0f, 0x85, 0x1b, 0x4c, 0x0a, 0xf0}))
+
+	trm.sndInput("BREAK COLLISION CXP0FB")
+	trm.cmpOutput("")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on Collision")
+	trm.expectOutput("break on Collision CXP0FB->true")
+
+	// the halt happens on the color clock at which the collision occurs
+	trm.sndInput("TV")
+	trm.cmpOutput("FR=0000 SL=000 CL=158")
+	trm.sndInput("PEEK CXP0FB")
+	trm.cmpOutput("0x0002 (CXP0FB) (TIA) -> 0x80")
+
+	// the collision register is clear on the previous color clock
+	trm.sndInput("STEP BACK CLOCK")
+	trm.rcvOutput()
+	trm.sndInput("TV")
+	trm.cmpOutput("FR=0000 SL=000 CL=157")
+	trm.sndInput("PEEK CXP0FB")
+	trm.cmpOutput("0x0002 (CXP0FB) (TIA) -> 0x00")
+
+	trm.sndInput("QUANTUM INSTRUCTION")
+	trm.cmpOutput("")
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+}
@@ -0,0 +1,53 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testBreakCoProc() {
+	// a DPC+ cartridge of all zero bytes. the ARM program is never run so the
+	// coprocessor registers only change when they are set by the test
+	trm.insertCartridge(newDPCplusFile(trm.t, make([]byte, 32768)))
+
+	trm.sndInput("COPROC SET 2 4")
+	trm.rcvOutput()
+
+	// the coprocessor register does not have the break value so the compound
+	// breakpoint will not halt the emulation
+	trm.sndInput("BREAK CYCLE 1000 & COPROC R2 5")
+	trm.rcvOutput()
+	trm.sndInput("BREAK CYCLE 2000")
+	trm.rcvOutput()
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on")
+	trm.expectOutput("break on Cycle->2000")
+
+	// the coprocessor register now has the break value but the emulation
+	// should not halt until the 6507 condition is also met
+	trm.sndInput("COPROC SET 2 5")
+	trm.rcvOutput()
+	trm.sndInput("BREAK CYCLE 3000 & COPROC R2 5")
+	trm.rcvOutput()
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on")
+	trm.expectOutput("break on Cycle->3000 & COPROC R2->5")
+
+	trm.sndInput("BREAK COPROC R99 5")
+	trm.cmpOutput("coprocessor does not have register R99")
+
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+}
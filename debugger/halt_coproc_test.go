@@ -0,0 +1,67 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testBreakOnProgramEnd() {
+	trm.insertCartridge(newDPCplusFile(trm.t, breakEndCartridge()))
+
+	trm.sndInput("COPROC BREAKEND")
+	trm.cmpOutput("coproc break on program end: OFF")
+
+	trm.sndInput("COPROC BREAKEND ON")
+	trm.cmpOutput("coproc break on program end: ON")
+
+	// the 6507 program calls the coprocessor program, which returns immediately
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("coprocessor program ended")
+
+	trm.sndInput("COPROC YIELD")
+	trm.rcvOutput()
+	trm.expectOutput("yield: Program Ended")
+
+	trm.sndInput("COPROC BREAKEND OFF")
+	trm.cmpOutput("coproc break on program end: OFF")
+}
+
+// breakEndCartridge returns DPC+ cartridge data with a coprocessor program
+// that ends immediately
+func breakEndCartridge() []byte {
+	// a DPC+ cartridge. 3K driver, six 4K banks, 4K data and 1K frequency table
+	data := make([]byte, 32768)
+
+	// the coprocessor program starts near the beginning of the first bank. every
+	// instruction in the bank is BX LR, which ends the program immediately
+	const driverSize = 3072
+	for i := driverSize; i < driverSize+4096; i += 2 {
+		data[i] = 0x70
+		data[i+1] = 0x47
+	}
+
+	// 6507 program in the last bank calls the coprocessor program in a loop.
+	// the program is placed after the DPC+ register addresses
+	//
+	//	LDA #$FF
+	//	STA $F05A
+	//	JMP $F100
+	bank := data[driverSize+5*4096:]
+	copy(bank[0x100:], []byte{0xa9, 0xff, 0x8d, 0x5a, 0xf0, 0x4c, 0x00, 0xf1})
+
+	// reset vector
+	bank[0xffc] = 0x00
+	bank[0xffd] = 0xf1
+
+	return data
+}
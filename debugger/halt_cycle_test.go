@@ -0,0 +1,41 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testBreakCycle() {
+	//	LDA $80
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa5, 0x80, 0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("BREAK CYCLE 1000")
+	trm.cmpOutput("")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on Cycle")
+	trm.expectOutput("break on Cycle->1000")
+
+	// the following cycle is the very next halt. the cycle count changes in
+	// the middle of instructions so this shows that the halt is exact
+	trm.sndInput("BREAK CYCLE 1001")
+	trm.rcvOutput()
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("break on Cycle")
+	trm.expectOutput("break on Cycle->1001")
+
+	trm.sndInput("CLEAR BREAKS")
+	trm.cmpOutput("breakpoints cleared")
+}
@@ -0,0 +1,44 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testTrapHMOVE() {
+	// HMOVE is strobed twice on every scanline. the NOPs between the two
+	// strobes mean that the phase clock, and therefore the delay, is different
+	// for each strobe
+	//
+	//	STA WSYNC
+	//	STA HMOVE
+	//	NOP
+	//	NOP
+	//	STA HMOVE
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0x85, 0x02, 0x85, 0x2a, 0xea, 0xea, 0x85, 0x2a, 0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("TRAP HMOVE")
+	trm.cmpOutput("")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("trap on HMOVE")
+	trm.expectOutput("trap on HMOVE [none->Frame: 0  Scanline: 001  Clock: -60  PClk: 2  Delay: 5]")
+
+	trm.sndInput("RUN")
+	trm.rcvOutputUntil("trap on HMOVE")
+	trm.expectOutput("trap on HMOVE [Frame: 0  Scanline: 001  Clock: -60  PClk: 2  Delay: 5->Frame: 0  Scanline: 001  Clock: -39  PClk: 3  Delay: 3]")
+
+	trm.sndInput("CLEAR TRAPS")
+	trm.cmpOutput("traps cleared")
+}
@@ -17,10 +17,12 @@ package debugger
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/tia/video"
 )
 
 // targetValue represents the underlying value of the target. for example in
@@ -158,6 +160,18 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 				instructionBoundary: true,
 			}
 
+		case "CYCLE":
+			trg = &target{
+				label: "Cycle",
+				value: func() targetValue {
+					return dbg.vcs.CPU.CycleCount
+				},
+
+				// like the CLOCK target, the cycle count can change in the
+				// middle of an instruction
+				notInPlaymode: true,
+			}
+
 		// tv state
 		case "FRAMENUM", "FRAME", "FR":
 			trg = &target{
@@ -208,6 +222,87 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 		case "BANK":
 			trg = bankTarget(dbg)
 
+		// tia state
+		case "HMOVE":
+			trg = &target{
+				label: "HMOVE",
+				value: func() targetValue {
+					return dbg.vcs.TIA.Hmove.LastStrobe
+				},
+			}
+
+		case "AUDIO":
+			trg = &target{
+				label: "AUDIO",
+				value: func() targetValue {
+					return dbg.chipObserver.lastAudioWrite
+				},
+			}
+
+		case "COLLISION", "COL":
+			// the collision register is optional. without it the target
+			// matches a collision in any register
+			label := "Collision"
+			mask := video.CollisionEvent(0xffff)
+
+			subkey, present := tokens.Peek()
+			if present {
+				subkey = strings.ToUpper(subkey)
+				if ev, ok := video.CollisionRegisterEvents(subkey); ok {
+					tokens.Get()
+					label = fmt.Sprintf("Collision %s", subkey)
+					mask = ev
+				}
+			}
+
+			trg = &target{
+				label: label,
+				value: func() targetValue {
+					return dbg.vcs.TIA.Video.Collisions.Latched&mask != 0
+				},
+
+				// the collision latch is set for a single color clock
+				notInPlaymode: true,
+			}
+
+		// coprocessor state
+		case "COPROC":
+			if dbg.vcs.Mem.Cart.GetCoProcBus() == nil {
+				return nil, fmt.Errorf("cartridge does not have a coprocessor")
+			}
+
+			subkey, present := tokens.Get()
+			if !present {
+				return nil, fmt.Errorf("%s target requires a register", keyword)
+			}
+			subkey = strings.ToUpper(subkey)
+
+			// register can be specified with or without the R prefix
+			reg, err := strconv.Atoi(strings.TrimPrefix(subkey, "R"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid target: %s %s", keyword, subkey)
+			}
+			if _, ok := dbg.vcs.Mem.Cart.GetCoProcBus().GetCoProc().Register(reg); !ok {
+				return nil, fmt.Errorf("coprocessor does not have register R%d", reg)
+			}
+
+			trg = &target{
+				label: fmt.Sprintf("COPROC R%d", reg),
+				value: func() targetValue {
+					// the cartridge may have changed since the target was
+					// created. a value of -1 will never match a break value
+					bus := dbg.vcs.Mem.Cart.GetCoProcBus()
+					if bus == nil {
+						return -1
+					}
+					v, ok := bus.GetCoProc().Register(reg)
+					if !ok {
+						return -1
+					}
+					return int(v)
+				},
+			}
+
 		// cpu instruction targeting was originally added as an experiment, to
 		// help investigate a bug in the emulation. I don't think it's much use
 		// but it was an instructive exercise and may come in useful one day.
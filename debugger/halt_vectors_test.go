@@ -0,0 +1,53 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testWatchVectors() {
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("WATCH VECTORS")
+	trm.cmpOutput("")
+
+	trm.sndInput("WATCH VECTORS")
+	trm.cmpOutput("already being watched (NMI/RESET/IRQ vectors (change))")
+
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 0: NMI/RESET/IRQ vectors (change)")
+
+	// vectors haven't changed so the watch is not triggered
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectNoOutput("watch on")
+
+	// write a new value to the reset vector
+	trm.sndInput("POKE 0xfffc 0x34 0x12")
+	trm.rcvOutput()
+
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectOutput("watch on RESET vector [0xf000->0x1234]")
+	trm.expectNoOutput("watch on NMI vector")
+	trm.expectNoOutput("watch on IRQ vector")
+
+	// the watch only triggers on a change of value
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectNoOutput("watch on")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+}
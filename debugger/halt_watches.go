@@ -28,6 +28,10 @@ import (
 type watcher struct {
 	ai dbgmem.AddressInfo
 
+	// a range watch matches any address between ai and rangeEnd inclusive
+	isRange  bool
+	rangeEnd dbgmem.AddressInfo
+
 	// whether to watch for a specific value. a matchValue of false means the
 	// watcher will match regardless of the value
 	matchValue bool
@@ -39,9 +43,38 @@ type watcher struct {
 
 	// whether the watcher should match phantom accesses too
 	phantom bool
+
+	// additional conditions that must also hold for the watch to match
+	conditions []watchCondition
+
+	// a vectors watch matches when any of the NMI, RESET or IRQ vectors change
+	// value, regardless of how the change happened. none of the above fields
+	// are used for a vectors watch
+	vectors      bool
+	vectorValues [len(cpuVectors)]uint16
+}
+
+// the addresses of the NMI, RESET and IRQ vectors. the order of the labels
+// and addresses must match
+var cpuVectors = [...]uint16{0xfffa, 0xfffc, 0xfffe}
+var cpuVectorLabels = [...]string{"NMI", "RESET", "IRQ"}
+
+// watchCondition is an additional condition placed on a watcher. the target
+// can be any breakpoint target or the value at a memory address
+type watchCondition struct {
+	target *target
+	value  targetValue
+}
+
+func (c watchCondition) String() string {
+	return fmt.Sprintf("%s->%s", c.target.label, c.target.stringValue(c.value))
 }
 
 func (w watcher) String() string {
+	if w.vectors {
+		return "NMI/RESET/IRQ vectors (change)"
+	}
+
 	val := ""
 	if w.matchValue {
 		val = fmt.Sprintf(" (value=%#02x)", w.value)
@@ -54,7 +87,50 @@ func (w watcher) String() string {
 	if w.strict {
 		strict = " (strict)"
 	}
-	return fmt.Sprintf("%s %s%s%s", w.ai, event, val, strict)
+	cond := strings.Builder{}
+	for _, c := range w.conditions {
+		cond.WriteString(fmt.Sprintf(" & %s", c))
+	}
+	if w.isRange {
+		return fmt.Sprintf("%s to %s %s%s%s%s", w.ai, w.rangeEnd, event, val, strict, cond.String())
+	}
+	return fmt.Sprintf("%s %s%s%s%s", w.ai, event, val, strict, cond.String())
+}
+
+// matchAddress returns true if the last CPU address matches the address of
+// the watcher or falls inside the range of the watcher
+func (w watcher) matchAddress(literal uint16, mapped uint16) bool {
+	// pick which addresses to compare depending on whether watch is strict
+	if w.strict {
+		if w.isRange {
+			return literal >= w.ai.Address && literal <= w.rangeEnd.Address
+		}
+		return literal == w.ai.Address
+	}
+	if w.isRange {
+		return mapped >= w.ai.MappedAddress && mapped <= w.rangeEnd.MappedAddress
+	}
+	return mapped == w.ai.MappedAddress
+}
+
+// checkConditions returns true if all additional conditions hold
+func (w watcher) checkConditions() bool {
+	for _, c := range w.conditions {
+		if c.target.value() != c.value {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionsString returns a string that can be used to compare the
+// conditions of two watchers
+func (w watcher) conditionsString() string {
+	s := strings.Builder{}
+	for _, c := range w.conditions {
+		s.WriteString(c.String())
+	}
+	return s.String()
 }
 
 // the list of currently defined watches in the system.
@@ -94,6 +170,44 @@ func (wtc *watches) drop(num int) error {
 	return nil
 }
 
+// readVectors returns the current value of the NMI, RESET and IRQ vectors
+func (wtc *watches) readVectors() [len(cpuVectors)]uint16 {
+	var v [len(cpuVectors)]uint16
+	for i, a := range cpuVectors {
+		lo, _ := wtc.dbg.vcs.Mem.Peek(a)
+		hi, _ := wtc.dbg.vcs.Mem.Peek(a + 1)
+		v[i] = uint16(hi)<<8 | uint16(lo)
+	}
+	return v
+}
+
+// checkVectors compares the current value of the vectors with the value
+// recorded by every vectors watch. the recorded value is updated if it has
+// changed
+func (wtc *watches) checkVectors(checkString *strings.Builder) {
+	var v [len(cpuVectors)]uint16
+	var read bool
+
+	for i := range wtc.watches {
+		if !wtc.watches[i].vectors {
+			continue
+		}
+
+		if !read {
+			v = wtc.readVectors()
+			read = true
+		}
+
+		for j := range cpuVectors {
+			if v[j] != wtc.watches[i].vectorValues[j] {
+				checkString.WriteString(fmt.Sprintf("watch on %s vector [%#04x->%#04x]\n",
+					cpuVectorLabels[j], wtc.watches[i].vectorValues[j], v[j]))
+			}
+		}
+		wtc.watches[i].vectorValues = v
+	}
+}
+
 // check compares the current state of the emulation with every watch
 // condition. returns a string listing every condition that matches (separated
 // by \n).
@@ -102,38 +216,44 @@ func (wtc *watches) check() string {
 		return ""
 	}
 
+	checkString := strings.Builder{}
+
+	// vectors can change without the CPU accessing the vector addresses. for
+	// example, by the cartridge switching banks. they must therefore be checked
+	// before the test for a change to the access address
+	wtc.checkVectors(&checkString)
+
 	// no check if access address & write flag haven't changed
 	//
 	// note that the write flag comparison is required otherwise RMW
 	// instructions will not be caught on the write signal (which would mean
 	// that a WRITE watch will never match a RMW instruction)
 	if wtc.lastAddressAccessed == wtc.dbg.vcs.Mem.LastCPUAddressLiteral && wtc.lastAddressWrite == wtc.dbg.vcs.Mem.LastCPUWrite {
-		return ""
+		return checkString.String()
 	}
 
-	checkString := strings.Builder{}
-
 	for _, w := range wtc.watches {
+		if w.vectors {
+			continue
+		}
+
 		// filter phantom accesses
 		if !w.phantom && wtc.dbg.vcs.CPU.PhantomMemAccess {
-			return ""
+			return checkString.String()
 		}
 
-		// pick which addresses to compare depending on whether watch is strict
-		if w.strict {
-			if wtc.dbg.vcs.Mem.LastCPUAddressLiteral != w.ai.Address {
-				continue
-			}
-		} else {
-			if wtc.dbg.vcs.Mem.LastCPUAddressMapped != w.ai.MappedAddress {
-				continue
-			}
+		if !w.matchAddress(wtc.dbg.vcs.Mem.LastCPUAddressLiteral, wtc.dbg.vcs.Mem.LastCPUAddressMapped) {
+			continue
 		}
 
 		if w.matchValue && w.value != wtc.dbg.vcs.Mem.LastCPUData {
 			continue
 		}
 
+		if !w.checkConditions() {
+			continue
+		}
+
 		lai := wtc.dbg.dbgmem.GetAddressInfo(wtc.dbg.vcs.Mem.LastCPUAddressLiteral, !wtc.dbg.vcs.Mem.LastCPUWrite)
 
 		if w.ai.Read {
@@ -184,6 +304,8 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 	arg, _ := tokens.Get()
 	arg = strings.ToUpper(arg)
 	switch arg {
+	case "VECTORS":
+		return wtc.addVectorsWatch()
 	case "READ":
 		read = true
 	case "WRITE":
@@ -218,30 +340,48 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 		tokens.Unget()
 	}
 
+	// a range of addresses rather than a single address. the RANGE keyword is
+	// required because the token following a single address is the watch value
+	arg, _ = tokens.Get()
+	isRange := strings.ToUpper(arg) == "RANGE"
+	if !isRange {
+		tokens.Unget()
+	}
+
 	// get address. required.
 	a, _ := tokens.Get()
-
-	// convert address
-	var ai *dbgmem.AddressInfo
-
-	if read {
-		ai = wtc.dbg.dbgmem.GetAddressInfo(a, true)
-	} else {
-		ai = wtc.dbg.dbgmem.GetAddressInfo(a, false)
+	ai, err := wtc.parseAddress(a, read)
+	if err != nil {
+		return err
 	}
 
-	// mapping of the address was unsuccessful
-	if ai == nil {
-		if read {
-			return fmt.Errorf("invalid watch address (%s) expecting 16-bit address or a read symbol", a)
+	// get end of range. required for range watches
+	var rangeEnd *dbgmem.AddressInfo
+	if isRange {
+		e, _ := tokens.Get()
+		rangeEnd, err = wtc.parseAddress(e, read)
+		if err != nil {
+			return err
+		}
+
+		if strict {
+			if rangeEnd.Address <= ai.Address {
+				return fmt.Errorf("end of watch range (%s) must be greater than the start (%s)", e, a)
+			}
+		} else {
+			if rangeEnd.MappedAddress <= ai.MappedAddress {
+				return fmt.Errorf("end of watch range (%s) must be greater than the start (%s)", e, a)
+			}
 		}
-		return fmt.Errorf("invalid watch address (%s) expecting 16-bit address or a write symbol", a)
 	}
 
 	// get value if possible
 	var val uint64
-	var err error
 	v, useVal := tokens.Get()
+	if useVal && (v == "&" || v == "&&") {
+		useVal = false
+		tokens.Unget()
+	}
 	if useVal {
 		val, err = strconv.ParseUint(v, 0, 8)
 		if err != nil {
@@ -257,6 +397,24 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 		phantom:    phantom,
 	}
 
+	if isRange {
+		nw.isRange = true
+		nw.rangeEnd = *rangeEnd
+	}
+
+	// additional conditions
+	for tok, ok := tokens.Get(); ok; tok, ok = tokens.Get() {
+		if tok != "&" && tok != "&&" {
+			return fmt.Errorf("unexpected token (%s) expecting & to introduce a watch condition", tok)
+		}
+
+		c, err := wtc.parseCondition(tokens)
+		if err != nil {
+			return err
+		}
+		nw.conditions = append(nw.conditions, c)
+	}
+
 	// check to see if watch already exists
 	for _, w := range wtc.watches {
 		// the conditions for a watch matching are very specific: both must
@@ -268,8 +426,10 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 		// function will list all matches. plus, if we combine two watches such
 		// that only the larger set remains, it may confuse the user
 		if w.ai.Address == nw.ai.Address &&
+			w.isRange == nw.isRange && w.rangeEnd.Address == nw.rangeEnd.Address &&
 			w.ai.Read == nw.ai.Read &&
-			w.matchValue == nw.matchValue && w.value == nw.value {
+			w.matchValue == nw.matchValue && w.value == nw.value &&
+			w.conditionsString() == nw.conditionsString() {
 			return fmt.Errorf("already being watched (%s)", w)
 		}
 	}
@@ -279,3 +439,100 @@ func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
 
 	return nil
 }
+
+// parse a watch address. the address can be numeric or a symbol. if the
+// address is a symbol then it must be a symbol of the correct type (read or
+// write) for the watch
+func (wtc *watches) parseAddress(a string, read bool) (*dbgmem.AddressInfo, error) {
+	ai := wtc.dbg.dbgmem.GetAddressInfo(a, read)
+
+	// mapping of the address was unsuccessful
+	if ai == nil {
+		if read {
+			return nil, fmt.Errorf("invalid watch address (%s) expecting 16-bit address or a read symbol", a)
+		}
+		return nil, fmt.Errorf("invalid watch address (%s) expecting 16-bit address or a write symbol", a)
+	}
+
+	return ai, nil
+}
+
+// parse an additional watch condition. the condition is either a breakpoint
+// target or a memory address, followed by the value that the target must
+// have for the watch to match.
+func (wtc *watches) parseCondition(tokens *commandline.Tokens) (watchCondition, error) {
+	tgt, err := parseTarget(wtc.dbg, tokens)
+	if err != nil {
+		// not a target so try to interpret the token as a memory address
+		tokens.Unget()
+		a, ok := tokens.Get()
+		if !ok {
+			return watchCondition{}, fmt.Errorf("watch condition requires a target or address")
+		}
+
+		ai := wtc.dbg.dbgmem.GetAddressInfo(a, true)
+		if ai == nil {
+			return watchCondition{}, fmt.Errorf("invalid watch condition (%s) expecting a target or 16-bit address", a)
+		}
+
+		tgt = &target{
+			label: ai.String(),
+			value: func() targetValue {
+				pai, err := wtc.dbg.dbgmem.Peek(ai.Address)
+				if err != nil {
+					return nil
+				}
+				return int(pai.Data)
+			},
+			format: "%#02x",
+		}
+	}
+
+	v, ok := tokens.Get()
+	if !ok {
+		return watchCondition{}, fmt.Errorf("watch condition (%s) requires a value", tgt.label)
+	}
+
+	var val targetValue
+
+	switch tgt.value().(type) {
+	case string:
+		val = strings.ToUpper(v)
+	case int:
+		n, err := strconv.ParseInt(v, 0, 32)
+		if err != nil {
+			return watchCondition{}, fmt.Errorf("invalid value (%s) for watch condition (%s)", v, tgt.label)
+		}
+		val = int(n)
+	case bool:
+		switch strings.ToLower(v) {
+		case "true":
+			val = true
+		case "false":
+			val = false
+		default:
+			return watchCondition{}, fmt.Errorf("invalid value (%s) for watch condition (%s)", v, tgt.label)
+		}
+	default:
+		return watchCondition{}, fmt.Errorf("unsupported value type (%T) for watch condition (%s)", tgt.value(), tgt.label)
+	}
+
+	return watchCondition{target: tgt, value: val}, nil
+}
+
+// add a vectors watch. the current value of the vectors is recorded so that
+// the watch only matches when a vector changes
+func (wtc *watches) addVectorsWatch() error {
+	for _, w := range wtc.watches {
+		if w.vectors {
+			return fmt.Errorf("already being watched (%s)", w)
+		}
+	}
+
+	wtc.watches = append(wtc.watches, watcher{
+		vectors:      true,
+		vectorValues: wtc.readVectors(),
+	})
+
+	return nil
+}
@@ -68,4 +68,95 @@ func (trm *mockTerm) testWatches() {
 	// last item in list watches should be the new entry
 	trm.sndInput("LIST WATCHES")
 	trm.cmpOutput(" 1: 0x0000 (VSYNC) (TIA) write (value=0x01)")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+
+	// compound watch with a register condition
+	trm.sndInput("WATCH READ 0x90 & X 1")
+	trm.cmpOutput("")
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 0: 0x0090 (RAM) read & X->0x01")
+
+	// the same watch with a different condition is a different watch
+	trm.sndInput("WATCH READ 0x90 & X 2")
+	trm.cmpOutput("")
+	trm.sndInput("WATCH READ 0x90 & X 2")
+	trm.cmpOutput("already being watched (0x0090 (RAM) read & X->0x02)")
+
+	// conditions must have a value
+	trm.sndInput("WATCH READ 0x90 & X")
+	trm.cmpOutput("watch condition (X) requires a value")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+
+	// place two LDA $90 instructions in RAM, followed by a NOP, and point
+	// the PC at them
+	trm.sndInput("POKE 0x80 0xa5 0x90 0xa5 0x90 0xea")
+	trm.cmpOutput("0x0084 (RAM) -> 0xea")
+	trm.sndInput("CPU SET PC 0x80")
+	trm.cmpOutput("")
+
+	// compound watch that requires the X register to be 1
+	trm.sndInput("WATCH READ 0x90 & X 1")
+	trm.cmpOutput("")
+
+	// X register is not 1 so the watch is not triggered
+	trm.sndInput("CPU SET X 0")
+	trm.cmpOutput("")
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectNoOutput("watch at 0x0090")
+
+	// X register is 1 so the watch is triggered
+	trm.sndInput("CPU SET X 1")
+	trm.cmpOutput("")
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectOutput("watch at 0x0090")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+
+	// the watch halted the emulation part way through the instruction so step
+	// again to complete it
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+
+	// range watches
+	trm.sndInput("WATCH READ RANGE 0x90 0x9f")
+	trm.cmpOutput("")
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 0: 0x0090 (RAM) to 0x009f (RAM) read")
+	trm.sndInput("WATCH READ RANGE 0x90 0x9f")
+	trm.cmpOutput("already being watched (0x0090 (RAM) to 0x009f (RAM) read)")
+
+	// a range watch is different to a single address watch
+	trm.sndInput("WATCH READ 0x90")
+	trm.cmpOutput("")
+
+	trm.sndInput("WATCH READ RANGE 0x9f 0x90")
+	trm.cmpOutput("end of watch range (0x90) must be greater than the start (0x9f)")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+
+	// LDA $98 in RAM. the address is in the middle of the range
+	trm.sndInput("POKE 0x80 0xa5 0x98 0xea")
+	trm.cmpOutput("0x0082 (RAM) -> 0xea")
+	trm.sndInput("CPU SET PC 0x80")
+	trm.cmpOutput("")
+
+	trm.sndInput("WATCH READ RANGE 0x90 0x9f")
+	trm.cmpOutput("")
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.expectOutput("watch at 0x0098")
+
+	trm.sndInput("CLEAR WATCHES")
+	trm.cmpOutput("watches cleared")
+
+	trm.sndInput("STEP")
+	trm.rcvOutput()
 }
@@ -276,6 +276,17 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, nonInstructionQuantum bo
 				dbg.liveDisasmEntry = dbg.Disasm.ExecutedEntry(dbg.liveBankInfo, dbg.vcs.CPU.LastResult, false, 0)
 			}
 
+			// report change of bank if the halt is the result of STEP BANK. the
+			// emulation may have halted for another reason in which case the
+			// bank will not have changed
+			if dbg.stepBankFrom != nil {
+				to := dbg.vcs.Mem.Cart.GetBank(dbg.vcs.CPU.PC.Address())
+				if to.Number != dbg.stepBankFrom.Number {
+					dbg.printLine(terminal.StyleFeedback, "bank changed: %s -> %s", dbg.stepBankFrom, to)
+				}
+				dbg.stepBankFrom = nil
+			}
+
 			// always clear volatile breakpoints/traps. if the emulation has halted for any
 			// reason then any existing step trap is stale.
 			dbg.halting.volatileBreakpoints.clear()
@@ -555,6 +566,11 @@ func (dbg *Debugger) termRead(inputter terminal.Input) error {
 		if inputLen > 0 {
 			err = dbg.parseInput(string(dbg.input[:inputLen-1]), inputter.IsInteractive(), false)
 			if err != nil {
+				// a failed assertion ends non-interactive input (ie. scripts)
+				// immediately
+				if errors.Is(err, AssertionFailed) && !inputter.IsInteractive() {
+					return err
+				}
 				dbg.printLine(terminal.StyleError, "%s", err)
 			}
 		}
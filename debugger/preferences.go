@@ -25,6 +25,9 @@ type Preferences struct {
 
 	// last ROM to be loaded into the emulation
 	RecentROM prefs.String
+
+	// pause the emulation in playmode when the host window loses focus
+	AutoPauseOnBlur prefs.Bool
 }
 
 func (p *Preferences) String() string {
@@ -50,6 +53,11 @@ func newPreferences() (*Preferences, error) {
 		return nil, err
 	}
 
+	err = p.dsk.Add("emulation.autopauseonblur", &p.AutoPauseOnBlur)
+	if err != nil {
+		return nil, err
+	}
+
 	err = p.dsk.Load(true)
 	if err != nil {
 		return nil, err
@@ -64,6 +64,30 @@ func (dbg *Debugger) PushSetPause(paused bool) {
 	}
 }
 
+// SetAutoPauseOnBlur sets whether the emulation should pause in playmode when
+// the host window loses focus. The setting is saved with the preferences.
+func (dbg *Debugger) SetAutoPauseOnBlur(enabled bool) {
+	err := dbg.Prefs.AutoPauseOnBlur.Set(enabled)
+	if err != nil {
+		logger.Log(logger.Allow, "debugger", err)
+	}
+}
+
+// NotifyFocus should be called by the GUI whenever the host window gains or
+// loses focus. The emulation is paused or resumed according to the
+// AutoPauseOnBlur preference. Focus changes have no effect in debugger mode.
+func (dbg *Debugger) NotifyFocus(focused bool) {
+	dbg.PushFunction(func() {
+		dbg.autoPause.SetAutoPauseOnBlur(dbg.Prefs.AutoPauseOnBlur.Get().(bool))
+		if dbg.Mode() != govern.ModePlay {
+			return
+		}
+		if state, ok := dbg.autoPause.NotifyFocus(focused, dbg.State()); ok {
+			dbg.setState(state, govern.Normal)
+		}
+	})
+}
+
 // PushTogglePCBreak sets or unsets a PC break at the address rerpresented by the
 // disassembly entry.
 func (dbg *Debugger) PushTogglePCBreak(e *disassembly.Entry) {
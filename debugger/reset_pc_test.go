@@ -0,0 +1,60 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testResetPC() {
+	//	$F000 LDA $80
+	//	$F002 JMP $F000
+	//
+	//	$F100 INX
+	//	$F101 JMP $F100
+	code := make([]byte, 0x104)
+	copy(code, []byte{0xa5, 0x80, 0x4c, 0x00, 0xf0})
+	copy(code[0x100:], []byte{0xe8, 0x4c, 0x00, 0xf1})
+
+	trm.insertCartridge(newTestROM(trm.t, code))
+
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f000 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	trm.sndInput("RESET PC 0xf100")
+	trm.rcvOutput()
+	trm.expectOutput("machine reset")
+	trm.expectOutput("PC overridden to f100")
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f100 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f101 A=00 X=01 Y=00 SP=ff SR=sv-Bdizc")
+
+	// override is applied on every reset
+	trm.sndInput("RESET")
+	trm.rcvOutput()
+	trm.expectOutput("PC overridden to f100")
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f100 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	// PC is loaded from the reset vector once the override is cleared
+	trm.sndInput("RESET PC OFF")
+	trm.cmpOutput("machine reset")
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f000 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	trm.sndInput("RESET PC 0x1ffff")
+	trm.cmpOutput("PC must be a 16bit address (0x1ffff)")
+}
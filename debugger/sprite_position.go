@@ -0,0 +1,50 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"strconv"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// sprites that can be repositioned with the POS option of the PLAYER, MISSILE
+// and BALL commands
+type spritePositioner interface {
+	SetPosition(pixel int)
+}
+
+// spritePosition handles the optional POS argument for the sprite commands.
+// returns false if the argument could not be processed. an error message will
+// have been printed in that case
+func (dbg *Debugger) spritePosition(tokens *commandline.Tokens, sprite spritePositioner) bool {
+	option, ok := tokens.Get()
+	if !ok || option != "POS" {
+		return true
+	}
+
+	arg, _ := tokens.Get()
+	pixel, err := strconv.Atoi(arg)
+	if err != nil || pixel < 0 || pixel >= specification.ClksVisible {
+		dbg.printLine(terminal.StyleError, "position must be a number between 0 and %d", specification.ClksVisible-1)
+		return false
+	}
+
+	sprite.SetPosition(pixel)
+	return true
+}
@@ -0,0 +1,56 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testStepBank() {
+	// an 8k F8 cartridge. each bank switches to the other bank
+	//
+	//	NOP
+	//	NOP
+	//	LDA $FFF9 (or $FFF8 in the second bank)
+	//	NOP
+	//	NOP
+	//	JMP $F000
+	data := make([]byte, 8192)
+	copy(data, []byte{0xea, 0xea, 0xad, 0xf9, 0xff, 0xea, 0xea, 0x4c, 0x00, 0xf0})
+	copy(data[4096:], []byte{0xea, 0xea, 0xad, 0xf8, 0xff, 0xea, 0xea, 0x4c, 0x00, 0xf0})
+
+	// reset vector in both banks
+	data[0xffc] = 0x00
+	data[0xffd] = 0xf0
+	data[0x1ffc] = 0x00
+	data[0x1ffd] = 0xf0
+
+	trm.insertCartridge(newTestFile(trm.t, "bank.bin", data))
+
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f000 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	// emulation halts on the instruction after the LDA that caused the bank
+	// change
+	trm.sndInput("STEP BANK")
+	trm.rcvOutputUntil("bank changed")
+	trm.expectOutput("bank changed: 0 -> 1")
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f005 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+
+	// the second bank switches back to the first bank
+	trm.sndInput("STEP BANK")
+	trm.rcvOutputUntil("bank changed")
+	trm.expectOutput("bank changed: 1 -> 0")
+	trm.sndInput("CPU")
+	trm.cmpOutput("PC=f005 A=00 X=00 Y=00 SP=ff SR=sv-BdiZc")
+}
@@ -0,0 +1,83 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testTIAHmove() {
+	// HMOVE is strobed at the start of the scanline followed by a series of
+	// NOPs
+	//
+	//	STA WSYNC
+	//	STA HMOVE
+	//	NOP (x8)
+	//	JMP $F000
+	trm.insertCartridge(newTestROM(trm.t, []byte{0x85, 0x02, 0x85, 0x2a, 0xea, 0xea, 0xea, 0xea, 0xea, 0xea, 0xea, 0xea, 0x4c, 0x00, 0xf0}))
+
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("latch: not latched")
+	trm.expectOutput("ripple: inactive")
+	trm.expectOutput("last strobe: none")
+
+	// STA WSYNC and STA HMOVE
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("STEP")
+	trm.rcvOutput()
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("latch: latching in 6")
+	trm.expectOutput("ripple: inactive")
+	trm.expectOutput("last strobe: Frame: 0  Scanline: 001  Clock: -60  PClk: 2  Delay: 5")
+
+	trm.sndInput("QUANTUM CLOCK")
+	trm.rcvOutput()
+
+	step := func(n int) {
+		for i := 0; i < n; i++ {
+			trm.sndInput("STEP")
+			trm.rcvOutput()
+		}
+	}
+
+	// the ripple counter starts and then ticks down on every rising edge of
+	// Phi2
+	step(8)
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("latch: latched")
+	trm.expectOutput("ripple: 14 ###############.")
+	trm.expectOutput("phase: _.--.__*--._ [ripple clock]")
+
+	step(1)
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("ripple: 14 ###############.")
+	trm.expectOutput("phase: _.--.__.--*_")
+
+	step(3)
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("ripple: 13 ##############..")
+	trm.expectOutput("phase: _.--.__*--._ [ripple clock]")
+
+	step(5)
+	trm.sndInput("TIA HMOVE")
+	trm.rcvOutput()
+	trm.expectOutput("ripple: 12 #############...")
+
+	trm.sndInput("QUANTUM INSTRUCTION")
+	trm.cmpOutput("")
+}
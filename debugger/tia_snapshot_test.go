@@ -0,0 +1,68 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"strings"
+)
+
+// tiaState returns the output of the TIA and PLAYFIELD commands
+func (trm *mockTerm) tiaState() string {
+	trm.sndInput("TIA")
+	trm.rcvOutput()
+	s := strings.Join(trm.output, "\n")
+	trm.sndInput("PLAYFIELD")
+	trm.rcvOutput()
+	return s + "\n" + strings.Join(trm.output, "\n")
+}
+
+func (trm *mockTerm) testTIASnapshot() {
+	//	LDA #$FF
+	//	STA PF0
+	//	STA PF1
+	//	STA PF2
+	//	JMP $F008
+	trm.insertCartridge(newTestROM(trm.t, []byte{0xa9, 0xff, 0x85, 0x0d, 0x85, 0x0e, 0x85, 0x0f, 0x4c, 0x08, 0xf0}))
+
+	trm.sndInput("TIA RESTORE")
+	trm.rcvOutput()
+	trm.expectOutput("no TIA state has been saved")
+
+	trm.sndInput("TIA SNAPSHOT")
+	trm.rcvOutput()
+	trm.expectOutput("TIA state saved")
+	saved := trm.tiaState()
+
+	// step over the writes to the playfield registers
+	for range 4 {
+		trm.sndInput("STEP")
+		trm.rcvOutput()
+	}
+	if trm.tiaState() == saved {
+		trm.t.Errorf("TIA state has not changed after stepping")
+	}
+
+	trm.sndInput("TIA RESTORE")
+	trm.rcvOutput()
+	trm.expectOutput("TIA state restored")
+	if s := trm.tiaState(); s != saved {
+		trm.t.Errorf("restored TIA state (%s) does not match saved state (%s)", s, saved)
+	}
+
+	// the CPU is not affected by the restore
+	trm.sndInput("ASSERT PC 0xf008")
+	trm.cmpOutput("")
+}
@@ -0,0 +1,118 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// Compare the disassembly against a reference listing. Every difference is
+// written to the output as a single line. Returns the number of differences
+// found.
+//
+// The reference listing is a series of lines in the form:
+//
+//	address operator operand
+//
+// The address is a hexadecimal number, with an optional $ or 0x prefix. The
+// operand is optional. Empty lines and any text after a semi-colon are
+// ignored. Comparison of the operator and operand is not case sensitive.
+//
+// Because the reference listing contains no bank information, an address in
+// the listing matches if the disassembly of any bank at that address matches.
+func (dsm *Disassembly) Compare(output io.Writer, reference io.Reader) (int, error) {
+	var diffs int
+
+	scanner := bufio.NewScanner(reference)
+	for ln := 1; scanner.Scan(); ln++ {
+		s, _, _ := strings.Cut(scanner.Text(), ";")
+		fields := strings.Fields(s)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) > 3 {
+			return diffs, fmt.Errorf("disassembly: compare: too many fields on line %d", ln)
+		}
+
+		a := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(fields[0]), "0x"), "$")
+		v, err := strconv.ParseUint(a, 16, 16)
+		if err != nil {
+			return diffs, fmt.Errorf("disassembly: compare: invalid address (%s) on line %d", fields[0], ln)
+		}
+		addr := uint16(v)
+
+		var operator, operand string
+		if len(fields) > 1 {
+			operator = fields[1]
+		}
+		if len(fields) > 2 {
+			operand = fields[2]
+		}
+
+		// the first entry at the address is used for reporting differences if
+		// no bank matches
+		var first *Entry
+		var match bool
+
+		for b := range dsm.disasmEntries.Entries {
+			e := dsm.disasmEntries.Entries[b][addr&memorymap.CartridgeBits]
+			if e == nil || e.Level < EntryLevelBlessed {
+				continue
+			}
+			if first == nil {
+				first = e
+			}
+			if e.compare(operator, operand) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			continue
+		}
+
+		diffs++
+		if first == nil {
+			output.Write([]byte(fmt.Sprintf("$%04x: no disassembly (expected %s %s)\n", addr, operator, operand)))
+		} else {
+			output.Write([]byte(fmt.Sprintf("$%04x: %s %s (expected %s %s)\n", addr,
+				first.Operator, first.Operand.Resolve(), operator, operand)))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return diffs, fmt.Errorf("disassembly: compare: %w", err)
+	}
+
+	return diffs, nil
+}
+
+// compare operator and operand with entry. the operand matches if it is the
+// same as either the numeric or the symbolic form of the entry's operand
+func (e *Entry) compare(operator string, operand string) bool {
+	if !strings.EqualFold(e.Operator, operator) {
+		return false
+	}
+	return strings.EqualFold(e.Operand.partial, operand) || strings.EqualFold(e.Operand.Resolve(), operand)
+}
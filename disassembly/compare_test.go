@@ -0,0 +1,84 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a minimal 4k ROM
+func compareROM() []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta VSYNC
+		0xa2, 0x10, // f004 ldx #$10
+		0xca,       // f006 dex
+		0xd0, 0xfd, // f007 bne $f006
+		0x4c, 0x00, 0xf0, // f009 jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestCompare(t *testing.T) {
+	prefs.DisableSaving = true
+
+	cartload, err := cartridgeloader.NewLoaderFromData("compare", compareROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	test.DemandSuccess(t, err)
+
+	// matching reference. mixture of case, address formats and symbols
+	matching := `
+; reference listing
+f000 lda #$02
+$f002 STA VSYNC
+0xf004 ldx #$10   ; comment
+f006 dex
+f007 bne $f006
+f009 jmp $f000
+`
+	s := &strings.Builder{}
+	n, err := dsm.Compare(s, strings.NewReader(matching))
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, n, 0)
+	test.ExpectEquality(t, s.String(), "")
+
+	// mismatched reference
+	mismatched := `
+f000 lda #$03
+f002 sta $00
+f004 ldy #$10
+f009 jmp $f000
+`
+	s.Reset()
+	n, err = dsm.Compare(s, strings.NewReader(mismatched))
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, n, 2)
+	test.ExpectEquality(t, s.String(), "$f000: lda #$02 (expected lda #$03)\n$f004: ldx #$10 (expected ldy #$10)\n")
+
+	// malformed reference
+	_, err = dsm.Compare(s, strings.NewReader("foo lda #$02"))
+	test.ExpectFailure(t, err)
+}
@@ -18,6 +18,7 @@ package disassembly
 import (
 	"bytes"
 	"fmt"
+	"strings"
 )
 
 // String returns a very basic representation of an Entry. Provided for
@@ -34,6 +35,27 @@ type ColumnAttr struct {
 	ByteCode bool
 	Cycles   bool
 	Label    bool
+	Notes    bool
+}
+
+func (attr ColumnAttr) String() string {
+	var s []string
+	if attr.ByteCode {
+		s = append(s, "bytecode")
+	}
+	if attr.Cycles {
+		s = append(s, "cycles")
+	}
+	if attr.Label {
+		s = append(s, "label")
+	}
+	if attr.Notes {
+		s = append(s, "notes")
+	}
+	if len(s) == 0 {
+		return "columns: none"
+	}
+	return fmt.Sprintf("columns: %s", strings.Join(s, ", "))
 }
 
 // StringColumnated returns a columnated string representation of the Entry.
@@ -74,5 +96,10 @@ func (e *Entry) StringColumnated(attr ColumnAttr) string {
 		b.Write([]byte(e.GetField(FldCycles)))
 	}
 
+	if attr.Notes && e.Notes() != "" {
+		b.Write([]byte(" "))
+		b.Write([]byte(e.GetField(FldNotes)))
+	}
+
 	return b.String()
 }
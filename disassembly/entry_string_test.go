@@ -0,0 +1,65 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestColumns(t *testing.T) {
+	prefs.DisableSaving = true
+
+	cartload, err := cartridgeloader.NewLoaderFromData("columns", compareROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	test.DemandSuccess(t, err)
+
+	e := dsm.GetEntryByAddress(0xf000)
+	test.DemandSuccess(t, e != nil)
+
+	// the default columns are the same as the columns used before the columns
+	// were configurable
+	test.ExpectEquality(t, dsm.Prefs.Columns(), disassembly.ColumnAttr{Cycles: true, Label: true})
+
+	// all columns
+	dsm.Prefs.SetColumns(disassembly.ColumnAttr{ByteCode: true, Cycles: true, Label: true, Notes: true})
+	full := e.StringColumnated(dsm.Prefs.Columns())
+	test.ExpectSuccess(t, strings.Contains(full, e.GetField(disassembly.FldBytecode)))
+	test.ExpectSuccess(t, strings.HasSuffix(full, e.GetField(disassembly.FldCycles)))
+
+	// compact output omits the bytecode and cycles columns
+	dsm.Prefs.SetColumns(disassembly.ColumnAttr{Label: true})
+	test.ExpectEquality(t, dsm.Prefs.Columns(), disassembly.ColumnAttr{Label: true})
+	test.ExpectEquality(t, dsm.Prefs.Columns().String(), "columns: label")
+
+	compact := e.StringColumnated(dsm.Prefs.Columns())
+	test.ExpectFailure(t, strings.Contains(compact, e.GetField(disassembly.FldBytecode)))
+	test.ExpectFailure(t, strings.HasSuffix(compact, e.GetField(disassembly.FldCycles)))
+	test.ExpectSuccess(t, strings.Contains(compact, e.GetField(disassembly.FldOperator)))
+	test.ExpectSuccess(t, strings.Contains(compact, e.GetField(disassembly.FldOperand)))
+	test.ExpectSuccess(t, len(compact) < len(full))
+
+	// no optional columns
+	dsm.Prefs.SetColumns(disassembly.ColumnAttr{})
+	test.ExpectEquality(t, dsm.Prefs.Columns().String(), "columns: none")
+}
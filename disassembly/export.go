@@ -0,0 +1,211 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the maximum number of values in a single byte directive
+const exportBytesPerLine = 8
+
+// exportLine is a single line of the exported source. if entry is nil then the
+// line is a data byte
+type exportLine struct {
+	addr  uint16
+	entry *Entry
+	data  uint8
+}
+
+// Export writes the disassembly as DASM compatible source to io.Writer. The
+// output can be reassembled into a binary that is identical to the original
+// cartridge data.
+//
+// Each bank is placed at the correct file position with an ORG directive and
+// relocated to its cartridge address with an RORG directive. Labels from the
+// symbol table are included and are used as the operand for flow control
+// instructions when possible. Anything that is not a blessed instruction is
+// output as a byte directive.
+//
+// Undocumented instructions are output as byte directives because the
+// mnemonics for those instructions vary between assemblers.
+func (dsm *Disassembly) Export(output io.Writer) error {
+	banks, err := dsm.vcs.Mem.Cart.CopyBanks()
+	if err != nil {
+		return fmt.Errorf("disassembly: export: %w", err)
+	}
+
+	if len(banks) == 0 || len(banks) != len(dsm.disasmEntries.Entries) {
+		return fmt.Errorf("disassembly: export: no disassembly to export")
+	}
+
+	// decide on what to output for each bank
+	lines := make([][]exportLine, len(banks))
+	for _, bank := range banks {
+		b := bank.Number
+		origin := bank.Origins[0]&memorymap.CartridgeBits | dsm.Prefs.mirrorOrigin
+
+		for i := 0; i < len(bank.Data); {
+			addr := origin + uint16(i)
+			e := dsm.disasmEntries.Entries[b][addr&memorymap.CartridgeBits]
+
+			if exportable(e) && i+e.Result.Defn.Bytes <= len(bank.Data) {
+				lines[b] = append(lines[b], exportLine{addr: addr, entry: e})
+				i += e.Result.Defn.Bytes
+			} else {
+				lines[b] = append(lines[b], exportLine{addr: addr, data: bank.Data[i]})
+				i++
+			}
+		}
+	}
+
+	// labels are only output if they are at the start of a line. labels that
+	// appear in more than one bank are suffixed with the bank number because
+	// DASM labels are global
+	labels := make([]map[uint16]string, len(banks))
+	count := make(map[string]int)
+	for b := range lines {
+		labels[b] = make(map[uint16]string)
+		for _, l := range lines[b] {
+			if s, ok := dsm.Sym.GetLabel(b, l.addr); ok {
+				labels[b][l.addr&memorymap.CartridgeBits] = s.Symbol
+				count[s.Symbol]++
+			}
+		}
+	}
+	for b := range labels {
+		for addr, s := range labels[b] {
+			if count[s] > 1 {
+				labels[b][addr] = fmt.Sprintf("%s_B%d", s, b)
+			}
+		}
+	}
+
+	w := &strings.Builder{}
+	w.WriteString("\tprocessor 6502\n")
+
+	var offset int
+	for _, bank := range banks {
+		b := bank.Number
+		origin := bank.Origins[0]&memorymap.CartridgeBits | dsm.Prefs.mirrorOrigin
+
+		w.WriteString("\n")
+		w.WriteString(fmt.Sprintf("; bank %d\n", b))
+		w.WriteString(fmt.Sprintf("\tORG $%04x\n", offset))
+		w.WriteString(fmt.Sprintf("\tRORG $%04x\n", origin))
+		offset += len(bank.Data)
+
+		var data []string
+		flush := func() {
+			if len(data) > 0 {
+				w.WriteString(fmt.Sprintf("\t.byte %s\n", strings.Join(data, ",")))
+				data = data[:0]
+			}
+		}
+
+		for _, l := range lines[b] {
+			if s, ok := labels[b][l.addr&memorymap.CartridgeBits]; ok {
+				flush()
+				w.WriteString(s)
+				w.WriteString("\n")
+			}
+
+			if l.entry == nil {
+				data = append(data, fmt.Sprintf("$%02x", l.data))
+				if len(data) >= exportBytesPerLine {
+					flush()
+				}
+				continue
+			}
+
+			flush()
+			w.WriteString(fmt.Sprintf("\t%s\n", exportInstruction(l.entry, l.addr, labels[b])))
+		}
+		flush()
+	}
+
+	_, err = io.WriteString(output, w.String())
+	if err != nil {
+		return fmt.Errorf("disassembly: export: %w", err)
+	}
+
+	return nil
+}
+
+// exportable returns true if the entry can be output as an instruction
+func exportable(e *Entry) bool {
+	if e == nil || e.Level < EntryLevelBlessed || e.Result.Defn == nil {
+		return false
+	}
+	return !e.Result.Defn.Undocumented && e.Result.ByteCount == e.Result.Defn.Bytes
+}
+
+// exportLabel returns the label for the address if the address is in the
+// cartridge and the label is in the exported source
+func exportLabel(addr uint16, labels map[uint16]string) (string, bool) {
+	if _, area := memorymap.MapAddress(addr, true); area != memorymap.Cartridge {
+		return "", false
+	}
+	s, ok := labels[addr&memorymap.CartridgeBits]
+	return s, ok
+}
+
+// exportInstruction returns the DASM source for the entry at the address. the
+// address is required because the address in the entry may refer to a
+// different mirror. labels are indexed by the address masked with
+// memorymap.CartridgeBits
+func exportInstruction(e *Entry, addr uint16, labels map[uint16]string) string {
+	defn := e.Result.Defn
+	operator := defn.Operator.String()
+	data := e.Result.InstructionData
+
+	switch defn.AddressingMode {
+	case instructions.Implied:
+		return operator
+
+	case instructions.Relative:
+		dest := absoluteBranchDestination(addr, data)
+		if s, ok := exportLabel(dest, labels); ok {
+			return fmt.Sprintf("%s %s", operator, s)
+		}
+		return fmt.Sprintf("%s $%04x", operator, dest)
+
+	case instructions.Immediate, instructions.ZeroPage, instructions.ZeroPageIndexedX,
+		instructions.ZeroPageIndexedY, instructions.IndexedIndirect, instructions.IndirectIndexed:
+		return fmt.Sprintf("%s %s", operator, addrModeDecoration(fmt.Sprintf("$%02x", data), defn.AddressingMode))
+	}
+
+	// absolute addressing
+	operand := fmt.Sprintf("$%04x", data)
+	if defn.AddressingMode == instructions.Absolute && (defn.Effect == instructions.Flow || defn.Effect == instructions.Subroutine) {
+		if s, ok := exportLabel(data, labels); ok {
+			operand = s
+		}
+	}
+
+	// DASM will use zero page addressing for addresses that can be expressed
+	// in a single byte unless told otherwise
+	if data <= 0xff && defn.AddressingMode != instructions.Indirect {
+		operator = fmt.Sprintf("%s.w", operator)
+	}
+
+	return fmt.Sprintf("%s %s", operator, addrModeDecoration(operand, defn.AddressingMode))
+}
@@ -0,0 +1,235 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a 4k ROM with a mixture of instructions and data
+func exportROM() []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta $00
+		0x20, 0x10, 0xf0, // f004 jsr $f010
+		0xa2, 0x10, // f007 ldx #$10
+		0xca,       // f009 dex
+		0xd0, 0xfd, // f00a bne $f009
+		0x4c, 0x00, 0xf0, // f00c jmp $f000
+		0x12,             // f00f data
+		0x8d, 0x81, 0x00, // f010 sta.w $0081
+		0xbd, 0x00, 0xf1, // f013 lda $f100,x
+		0x60, // f016 rts
+	}
+
+	rom := hardwaretest.ROM(prg)
+
+	// data table
+	copy(rom[0x100:], []byte{0x01, 0x02, 0x03, 0x04})
+
+	return rom
+}
+
+// assemble is a stub assembler that understands only the subset of DASM
+// syntax produced by the Export() function
+func assemble(src string) ([]byte, error) {
+	defns := instructions.GetDefinitions()
+
+	lookup := func(operator string, mode instructions.AddressingMode) *instructions.Definition {
+		for _, d := range defns {
+			if !d.Undocumented && d.Operator.String() == operator && d.AddressingMode == mode {
+				return d
+			}
+		}
+		return nil
+	}
+
+	labels := make(map[string]uint16)
+
+	value := func(s string) (uint16, bool) {
+		if strings.HasPrefix(s, "$") {
+			v, err := strconv.ParseUint(s[1:], 16, 16)
+			return uint16(v), err == nil
+		}
+		v, ok := labels[s]
+		return v, ok
+	}
+
+	var bin []byte
+
+	// two passes. the first pass collects the labels
+	for pass := 0; pass < 2; pass++ {
+		var offset int
+		var pc uint16
+
+		emit := func(b ...byte) {
+			for _, v := range b {
+				for len(bin) <= offset {
+					bin = append(bin, 0)
+				}
+				bin[offset] = v
+				offset++
+				pc++
+			}
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(src))
+		for ln := 1; scanner.Scan(); ln++ {
+			s, _, _ := strings.Cut(scanner.Text(), ";")
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+
+			// labels start in the first column
+			if s[0] != ' ' && s[0] != '\t' {
+				labels[strings.TrimSpace(s)] = pc
+				continue
+			}
+
+			fields := strings.Fields(s)
+			operand := strings.Join(fields[1:], "")
+
+			switch fields[0] {
+			case "processor":
+				continue
+			case "ORG":
+				v, _ := value(operand)
+				offset = int(v)
+				pc = v
+				continue
+			case "RORG":
+				pc, _ = value(operand)
+				continue
+			case ".byte":
+				for _, d := range strings.Split(operand, ",") {
+					v, ok := value(d)
+					if !ok {
+						return nil, fmt.Errorf("line %d: bad byte value", ln)
+					}
+					emit(uint8(v))
+				}
+				continue
+			}
+
+			operator, wide := strings.CutSuffix(fields[0], ".w")
+
+			var mode instructions.AddressingMode
+			var v uint16
+			var known bool
+
+			switch {
+			case operand == "":
+				mode = instructions.Implied
+			case strings.HasPrefix(operand, "#"):
+				mode = instructions.Immediate
+				v, known = value(operand[1:])
+			case strings.HasSuffix(operand, ",X)"):
+				mode = instructions.IndexedIndirect
+				v, known = value(strings.TrimSuffix(operand[1:], ",X)"))
+			case strings.HasSuffix(operand, "),Y"):
+				mode = instructions.IndirectIndexed
+				v, known = value(strings.TrimSuffix(operand[1:], "),Y"))
+			case strings.HasPrefix(operand, "("):
+				mode = instructions.Indirect
+				v, known = value(strings.TrimSuffix(operand[1:], ")"))
+			case strings.HasSuffix(operand, ",X"):
+				mode = instructions.AbsoluteIndexedX
+				v, known = value(strings.TrimSuffix(operand, ",X"))
+				if known && !wide && v <= 0xff && lookup(operator, instructions.ZeroPageIndexedX) != nil {
+					mode = instructions.ZeroPageIndexedX
+				}
+			case strings.HasSuffix(operand, ",Y"):
+				mode = instructions.AbsoluteIndexedY
+				v, known = value(strings.TrimSuffix(operand, ",Y"))
+				if known && !wide && v <= 0xff && lookup(operator, instructions.ZeroPageIndexedY) != nil {
+					mode = instructions.ZeroPageIndexedY
+				}
+			default:
+				mode = instructions.Absolute
+				v, known = value(operand)
+				if lookup(operator, instructions.Relative) != nil {
+					mode = instructions.Relative
+				} else if known && !wide && v <= 0xff {
+					mode = instructions.ZeroPage
+				}
+			}
+
+			if !known && operand != "" && pass == 1 {
+				return nil, fmt.Errorf("line %d: unknown operand %s", ln, operand)
+			}
+
+			d := lookup(operator, mode)
+			if d == nil {
+				return nil, fmt.Errorf("line %d: unknown instruction %s", ln, strings.TrimSpace(s))
+			}
+
+			if mode == instructions.Relative {
+				v = v - (pc + 2)
+			}
+
+			switch d.Bytes {
+			case 1:
+				emit(d.OpCode)
+			case 2:
+				emit(d.OpCode, uint8(v))
+			case 3:
+				emit(d.OpCode, uint8(v), uint8(v>>8))
+			}
+		}
+	}
+
+	return bin, nil
+}
+
+func TestExport(t *testing.T) {
+	prefs.DisableSaving = true
+
+	rom := exportROM()
+
+	cartload, err := cartridgeloader.NewLoaderFromData("export", rom, "4K", "", nil)
+	test.DemandSuccess(t, err)
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	test.DemandSuccess(t, err)
+
+	s := &strings.Builder{}
+	err = dsm.Export(s)
+	test.DemandSuccess(t, err)
+
+	// flow control instructions use labels from the symbol table
+	test.ExpectSuccess(t, strings.Contains(s.String(), "\tjsr L1010\n"))
+	test.ExpectSuccess(t, strings.Contains(s.String(), "\tbne L1009\n"))
+
+	// zero page address used with absolute addressing must be forced to
+	// absolute addressing in the source
+	test.ExpectSuccess(t, strings.Contains(s.String(), "\tsta.w $0081\n"))
+
+	bin, err := assemble(s.String())
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, len(bin), len(rom))
+	test.ExpectEquality(t, string(bin), string(rom))
+}
@@ -19,6 +19,9 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 )
 
 // GrepScope limits the scope of the search.
@@ -76,3 +79,63 @@ func (dsm *Disassembly) Grep(output io.Writer, scope GrepScope, search string, c
 
 	return nil
 }
+
+// References searches the disassembly for instructions that refer to the
+// specified address. Matching instructions are written to the output and the
+// number of matches is returned.
+//
+// Instructions that read, write or jump to the address are all considered to
+// be references. Addresses are compared after mapping, so references to
+// mirrors of the address will also be found. For indexed addressing modes
+// only the base address of the instruction is considered.
+func (dsm *Disassembly) References(output io.Writer, addr uint16) (int, error) {
+	var ct int
+
+	includeBankNumber := len(dsm.disasmEntries.Entries) > 1
+
+	for b := range dsm.disasmEntries.Entries {
+		bankNumberPrinted := false
+
+		for _, e := range dsm.disasmEntries.Entries[b] {
+			if e == nil || e.Level < EntryLevelBlessed {
+				continue
+			}
+
+			if ref, ok := e.reference(); ok {
+				read := e.Result.Defn.Effect != instructions.Write
+				ma, _ := memorymap.MapAddress(addr, read)
+				mr, _ := memorymap.MapAddress(ref, read)
+				if ma != mr {
+					continue
+				}
+
+				ct++
+				if includeBankNumber && !bankNumberPrinted {
+					bankNumberPrinted = true
+					output.Write([]byte(fmt.Sprintf("Bank %d\n", b)))
+				}
+				output.Write([]byte(e.StringColumnated(ColumnAttr{})))
+				output.Write([]byte("\n"))
+			}
+		}
+	}
+
+	return ct, nil
+}
+
+// reference returns the address referred to by the entry's operand. returns
+// false if the instruction does not refer to an address
+func (e *Entry) reference() (uint16, bool) {
+	if e.Result.Defn == nil || e.Result.ByteCount != e.Result.Defn.Bytes {
+		return 0, false
+	}
+
+	switch e.Result.Defn.AddressingMode {
+	case instructions.Implied, instructions.Immediate:
+		return 0, false
+	case instructions.Relative:
+		return absoluteBranchDestination(e.Result.Address, e.Result.InstructionData), true
+	}
+
+	return e.Result.InstructionData, true
+}
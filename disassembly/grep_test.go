@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a 4k ROM with several references to address $80
+func referencesROM() []byte {
+	prg := []byte{
+		0xa9, 0x80, // f000 lda #$80
+		0x85, 0x80, // f002 sta $80
+		0xe6, 0x80, // f004 inc $80
+		0xa5, 0x81, // f006 lda $81
+		0xad, 0x80, 0x00, // f008 lda $0080
+		0xb5, 0x80, // f00b lda $80,X
+		0x4c, 0x00, 0xf0, // f00d jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestReferences(t *testing.T) {
+	prefs.DisableSaving = true
+
+	cartload, err := cartridgeloader.NewLoaderFromData("references", referencesROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	test.DemandSuccess(t, err)
+
+	// the immediate mode LDA and the reference to $81 should not be found
+	s := &strings.Builder{}
+	n, err := dsm.References(s, 0x0080)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, n, 4)
+	test.ExpectEquality(t, strings.Count(s.String(), "\n"), 4)
+	test.ExpectSuccess(t, strings.Contains(s.String(), "$f002"))
+	test.ExpectSuccess(t, strings.Contains(s.String(), "$f004"))
+	test.ExpectSuccess(t, strings.Contains(s.String(), "$f008"))
+	test.ExpectSuccess(t, strings.Contains(s.String(), "$f00b"))
+
+	// reference to the cartridge address is found using any mirror
+	s.Reset()
+	n, err = dsm.References(s, 0x1000)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, n, 1)
+	test.ExpectSuccess(t, strings.Contains(s.String(), "$f00d"))
+
+	// no references
+	s.Reset()
+	n, err = dsm.References(s, 0x0082)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, n, 0)
+	test.ExpectEquality(t, s.String(), "")
+}
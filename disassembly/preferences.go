@@ -31,6 +31,13 @@ type Preferences struct {
 	FxxxMirror prefs.Bool
 	Symbols    prefs.Bool
 
+	// the optional columns to include when the disassembly is output as text.
+	// see Columns() function
+	ColByteCode prefs.Bool
+	ColCycles   prefs.Bool
+	ColLabel    prefs.Bool
+	ColNotes    prefs.Bool
+
 	// the lowest value to use when formatting address values. changed by the
 	// preferences system
 	mirrorOrigin uint16
@@ -76,6 +83,22 @@ func newPreferences(dsm *Disassembly) (*Preferences, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = p.dsk.Add("disassembly.columns.bytecode", &p.ColByteCode)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("disassembly.columns.cycles", &p.ColCycles)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("disassembly.columns.label", &p.ColLabel)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("disassembly.columns.notes", &p.ColNotes)
+	if err != nil {
+		return nil, err
+	}
 
 	err = p.dsk.Load(true)
 	if err != nil {
@@ -89,9 +112,32 @@ func newPreferences(dsm *Disassembly) (*Preferences, error) {
 func (p *Preferences) SetDefaults() {
 	p.FxxxMirror.Set(true)
 	p.Symbols.Set(true)
+	p.ColByteCode.Set(false)
+	p.ColCycles.Set(true)
+	p.ColLabel.Set(true)
+	p.ColNotes.Set(false)
 	p.mirrorOrigin = memorymap.OriginCartFxxxMirror
 }
 
+// Columns returns the ColumnAttr for the optional columns as specified by the
+// preferences.
+func (p *Preferences) Columns() ColumnAttr {
+	return ColumnAttr{
+		ByteCode: p.ColByteCode.Get().(bool),
+		Cycles:   p.ColCycles.Get().(bool),
+		Label:    p.ColLabel.Get().(bool),
+		Notes:    p.ColNotes.Get().(bool),
+	}
+}
+
+// SetColumns sets the optional column preferences from the ColumnAttr.
+func (p *Preferences) SetColumns(attr ColumnAttr) {
+	p.ColByteCode.Set(attr.ByteCode)
+	p.ColCycles.Set(attr.Cycles)
+	p.ColLabel.Set(attr.Label)
+	p.ColNotes.Set(attr.Notes)
+}
+
 // Load disassembly preferences and apply to the current disassembly.
 func (p *Preferences) Load() error {
 	return p.dsk.Load(false)
@@ -0,0 +1,206 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package gifwriter allows writing of video data to disk as an animated GIF
+// file.
+package gifwriter
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"math"
+	"os"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// GIFRenderer implements the television.PixelRenderer interface. Frames are
+// accumulated and written to disk as an animated GIF when EndRendering() is
+// called.
+//
+// Every frame in the GIF is the same size as the visible area of the first
+// frame that is recorded.
+type GIFRenderer struct {
+	filename string
+
+	// the number of frames to skip after every recorded frame
+	frameSkip int
+
+	// recording stops once the animation would be longer than maxDuration
+	maxDuration time.Duration
+
+	frameInfo television.FrameInfo
+	frameNum  int
+
+	// the crop rectangle of the first recorded frame
+	crop image.Rectangle
+
+	// palette for the current specification and the index into the palette for
+	// every ColorSignal
+	paletteID string
+	palette   color.Palette
+	lookup    [256]uint8
+
+	// the length of the animation so far. measured in 100ths of a second
+	// because that is the unit used by the GIF format
+	duration int
+	full     bool
+
+	anim gif.GIF
+}
+
+// NewGIFRenderer is the preferred method of initialisation for the GIFRenderer
+// type. A frameSkip value of zero means that every frame is recorded. The
+// maxDuration value bounds the size of the GIF file.
+func NewGIFRenderer(filename string, frameSkip int, maxDuration time.Duration) (*GIFRenderer, error) {
+	if frameSkip < 0 {
+		return nil, fmt.Errorf("gifwriter: frame skip cannot be negative")
+	}
+	if maxDuration <= 0 {
+		return nil, fmt.Errorf("gifwriter: maximum duration must be greater than zero")
+	}
+
+	gr := &GIFRenderer{
+		filename:    fmt.Sprintf("%s.gif", filename),
+		frameSkip:   frameSkip,
+		maxDuration: maxDuration,
+		frameInfo:   television.NewFrameInfo(specification.SpecNTSC),
+	}
+	return gr, nil
+}
+
+// setPalette creates the GIF palette for the specification. the palette is
+// only recreated if the specification has changed
+func (gr *GIFRenderer) setPalette(spec specification.Spec) {
+	if gr.paletteID == spec.ID && gr.palette != nil {
+		return
+	}
+	gr.paletteID = spec.ID
+
+	// video black is always the first entry in the palette. the colors for
+	// each specification contain duplicate entries which are only added once
+	index := map[color.RGBA]uint8{specification.VideoBlack: 0}
+	gr.palette = color.Palette{specification.VideoBlack}
+	for _, c := range spec.Colors {
+		if _, ok := index[c]; !ok && len(gr.palette) < 256 {
+			index[c] = uint8(len(gr.palette))
+			gr.palette = append(gr.palette, c)
+		}
+	}
+
+	for i := range gr.lookup {
+		if signal.ColorSignal(i) == signal.VideoBlack || i >= len(spec.Colors) {
+			gr.lookup[i] = 0
+		} else if idx, ok := index[spec.Colors[i]]; ok {
+			gr.lookup[i] = idx
+		} else {
+			gr.lookup[i] = uint8(gr.palette.Index(spec.Colors[i]))
+		}
+	}
+}
+
+// NewFrame implements the television.PixelRenderer interface
+func (gr *GIFRenderer) NewFrame(frameInfo television.FrameInfo) error {
+	gr.frameInfo = frameInfo
+	return nil
+}
+
+// NewScanline implements the television.PixelRenderer interface
+func (gr *GIFRenderer) NewScanline(scanline int) error {
+	return nil
+}
+
+// SetPixels implements the television.PixelRenderer interface
+func (gr *GIFRenderer) SetPixels(sig []signal.SignalAttributes, last int) error {
+	if gr.full {
+		return nil
+	}
+
+	skip := gr.frameNum%(gr.frameSkip+1) != 0
+	gr.frameNum++
+	if skip {
+		return nil
+	}
+
+	// the delay for each image in the GIF is the length of time until the next
+	// recorded frame
+	refreshRate := float64(gr.frameInfo.RefreshRate)
+	if refreshRate <= 0 {
+		refreshRate = float64(gr.frameInfo.Spec.RefreshRate)
+	}
+	delay := int(math.Round(100 * float64(gr.frameSkip+1) / refreshRate))
+
+	if time.Duration(gr.duration+delay)*10*time.Millisecond > gr.maxDuration {
+		gr.full = true
+		return nil
+	}
+	gr.duration += delay
+
+	if len(gr.anim.Image) == 0 {
+		gr.crop = gr.frameInfo.Crop()
+	}
+	gr.setPalette(gr.frameInfo.Spec)
+
+	img := image.NewPaletted(image.Rect(0, 0, gr.crop.Dx(), gr.crop.Dy()), gr.palette)
+	for y := 0; y < gr.crop.Dy(); y++ {
+		for x := 0; x < gr.crop.Dx(); x++ {
+			i := (gr.crop.Min.Y+y)*specification.ClksScanline + gr.crop.Min.X + x
+			if i >= len(sig) {
+				continue
+			}
+
+			// handle VBLANK by setting pixels to black. we also manually
+			// handle NoSignal in the same way
+			if sig[i].VBlank || sig[i].Index == signal.NoSignal {
+				continue
+			}
+			img.Pix[y*img.Stride+x] = gr.lookup[sig[i].Color]
+		}
+	}
+
+	gr.anim.Image = append(gr.anim.Image, img)
+	gr.anim.Delay = append(gr.anim.Delay, delay)
+
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface
+func (gr *GIFRenderer) Reset() {
+}
+
+// EndRendering implements the television.PixelRenderer interface
+func (gr *GIFRenderer) EndRendering() error {
+	if len(gr.anim.Image) == 0 {
+		return fmt.Errorf("gifwriter: no frames to write")
+	}
+
+	f, err := os.Create(gr.filename)
+	if err != nil {
+		return fmt.Errorf("gifwriter: %w", err)
+	}
+	defer f.Close()
+
+	err = gif.EncodeAll(f, &gr.anim)
+	if err != nil {
+		return fmt.Errorf("gifwriter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package gifwriter_test
+
+import (
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/gifwriter"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// render the number of frames and return the decoded GIF
+func render(t *testing.T, frames int, frameSkip int, maxDuration time.Duration) *gif.GIF {
+	t.Helper()
+
+	fn := filepath.Join(t.TempDir(), "clip")
+	gr, err := gifwriter.NewGIFRenderer(fn, frameSkip, maxDuration)
+	test.DemandSuccess(t, err)
+
+	// every pixel in the frame is the same color
+	sig := make([]signal.SignalAttributes, specification.AbsoluteMaxClks)
+	for i := range sig {
+		sig[i].Index = i
+		sig[i].Color = 0x0e
+	}
+
+	frameInfo := television.NewFrameInfo(specification.SpecNTSC)
+	test.DemandSuccess(t, gr.NewFrame(frameInfo))
+	for range frames {
+		test.DemandSuccess(t, gr.SetPixels(sig, len(sig)))
+		test.DemandSuccess(t, gr.NewFrame(frameInfo))
+	}
+	test.DemandSuccess(t, gr.EndRendering())
+
+	f, err := os.Open(fn + ".gif")
+	test.DemandSuccess(t, err)
+	defer f.Close()
+
+	anim, err := gif.DecodeAll(f)
+	test.DemandSuccess(t, err)
+
+	return anim
+}
+
+func TestGIFRenderer(t *testing.T) {
+	crop := television.NewFrameInfo(specification.SpecNTSC).Crop()
+
+	// every other frame is recorded
+	anim := render(t, 10, 1, time.Second)
+	test.ExpectEquality(t, len(anim.Image), 5)
+	test.ExpectEquality(t, anim.Config.Width, specification.ClksVisible)
+	test.ExpectEquality(t, anim.Config.Height, crop.Dy())
+	for _, img := range anim.Image {
+		test.ExpectEquality(t, img.Bounds().Dx(), specification.ClksVisible)
+		test.ExpectEquality(t, img.Bounds().Dy(), crop.Dy())
+	}
+
+	// the color of the pixels is taken from the specification
+	r, g, b, _ := anim.Image[0].At(0, 0).RGBA()
+	col := specification.SpecNTSC.GetColor(0x0e)
+	test.ExpectEquality(t, uint8(r>>8), col.R)
+	test.ExpectEquality(t, uint8(g>>8), col.G)
+	test.ExpectEquality(t, uint8(b>>8), col.B)
+
+	// the duration of the animation is limited. each NTSC frame is rounded to
+	// 20ms so only five frames fit into 100ms
+	anim = render(t, 10, 0, 100*time.Millisecond)
+	test.ExpectEquality(t, len(anim.Image), 5)
+}
@@ -304,6 +304,7 @@ func emulate(mode string, sync *mainSync, args []string) error {
 		fmt.Sprintf("television specification: %s", strings.Join(specification.ReqSpecList, ", ")))
 	flgs.BoolVar(&opts.FpsCap, "fpscap", true, "cap FPS to emulation TV")
 	flgs.IntVar(&opts.Multiload, "multiload", -1, "force multiload byte (supercharger only; 0 to 255")
+	flgs.BoolVar(&opts.TurboLoad, "turboload", false, "load supercharger tapes at full speed (supercharger only)")
 	flgs.StringVar(&opts.Mapping, "mapping", "AUTO", "force cartridge mapper selection")
 	flgs.StringVar(&opts.Bank, "bank", "AUTO", "selected cartridge bank on reset")
 	flgs.StringVar(&opts.Left, "left", "AUTO", "left player port: AUTO, STICK, PADDLE, KEYPAD, GAMEPAD")
@@ -70,4 +70,8 @@ const (
 	// request a screenshot to be taken
 	// optional argument is the filename for the screenshot
 	ReqScreenshot FeatureReq = "ReqScreenshot" // [optional] filename
+
+	// silence one or both of the audio channels in the sound output. the
+	// emulated audio is not affected
+	ReqAudioMute FeatureReq = "ReqAudioMute" // mix.Mute
 )
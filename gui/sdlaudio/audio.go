@@ -17,6 +17,7 @@ package sdlaudio
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
@@ -63,6 +64,10 @@ type Audio struct {
 
 	stereoCh0Buffer []uint8
 	stereoCh1Buffer []uint8
+
+	// channels that are silenced when mixing. set with MuteChannels() from
+	// the GUI goroutine and read in SetAudio() in the emulation goroutine
+	mute atomic.Uint32
 }
 
 const stereoBufferLen = 1024
@@ -122,13 +127,14 @@ func (aud *Audio) MoreAudio() bool {
 
 // SetAudio implements the protocol.AudioMixer interface.
 func (aud *Audio) SetAudio(sig []signal.SignalAttributes) error {
+	mute := mix.Mute(aud.mute.Load())
+
 	for _, s := range sig {
 		if !s.AudioUpdate {
 			continue
 		}
 
-		v0 := s.AudioChannel0
-		v1 := s.AudioChannel1
+		v0, v1 := mute.Apply(s.AudioChannel0, s.AudioChannel1)
 
 		aud.stereoCh0Buffer = aud.stereoCh0Buffer[1:]
 		aud.stereoCh0Buffer = append(aud.stereoCh0Buffer, v0)
@@ -244,3 +250,9 @@ func (aud *Audio) Mute(muted bool) {
 	}
 	sdl.PauseAudioDevice(aud.id, muted)
 }
+
+// MuteChannels silences the specified audio channels when they are mixed. The
+// audio device itself remains active.
+func (aud *Audio) MuteChannels(mute mix.Mute) {
+	aud.mute.Store(uint32(mute))
+}
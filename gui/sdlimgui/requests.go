@@ -23,6 +23,7 @@ import (
 	"github.com/jetsetilly/gopher2600/coprocessor/developer/dwarf"
 	"github.com/jetsetilly/gopher2600/debugger/govern"
 	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio/mix"
 	"github.com/jetsetilly/gopher2600/notifications"
 )
 
@@ -134,6 +135,12 @@ func (img *SdlImgui) serviceSetFeature(request featureRequest) {
 			srcWin.gotoSourceLine(ln)
 		}
 
+	case gui.ReqAudioMute:
+		err = argLen(request.args, 1)
+		if err == nil {
+			img.audio.MuteChannels(request.args[0].(mix.Mute))
+		}
+
 	case gui.ReqScreenshot:
 		switch len(request.args) {
 		case 0:
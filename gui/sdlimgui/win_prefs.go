@@ -613,6 +613,15 @@ Illegal accesses will be logged even if program does not abort.`)
 		win.img.dbg.VCS().Env.Prefs.ARM.MisalignedAccessIsFault.Set(misalignedAccessIsFault)
 	}
 
+	strictAlignment := win.img.dbg.VCS().Env.Prefs.ARM.StrictAlignment.Get().(bool)
+	if imgui.Checkbox("Strict Alignment", &strictAlignment) {
+		win.img.dbg.VCS().Env.Prefs.ARM.StrictAlignment.Set(strictAlignment)
+	}
+	win.img.imguiTooltipSimple(`Log 32bit accesses to addresses that are not word aligned.
+
+For the ARM7TDMI, a word read from a misaligned address will be rotated
+in the same way as the real hardware.`)
+
 	undefinedSymbolWarning := win.img.dbg.VCS().Env.Prefs.ARM.UndefinedSymbolWarning.Get().(bool)
 	if imgui.Checkbox("Undefined Symbols Warning", &undefinedSymbolWarning) {
 		win.img.dbg.VCS().Env.Prefs.ARM.UndefinedSymbolWarning.Set(undefinedSymbolWarning)
@@ -0,0 +1,78 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a 4k ROM that runs the program in a continuous loop
+func attractROM(prg []byte) []byte {
+	return hardwaretest.ROM(append(prg, 0x4c, 0x00, 0xf0)) // jmp $f000
+}
+
+// newAttractVCS creates a VCS with the ROM attached and the AttractFrames
+// preference set to the number of frames
+func newAttractVCS(t *testing.T, rom []byte, frames int) *hardware.VCS {
+	t.Helper()
+
+	vcs := hardwaretest.NewVCS(t, rom)
+	test.DemandSuccess(t, vcs.Env.Prefs.AttractFrames.Set(frames))
+
+	return vcs
+}
+
+func TestAttractMode(t *testing.T) {
+	prefs.DisableSaving = true
+
+	// program that never reads the controllers
+	vcs := newAttractVCS(t, attractROM([]byte{
+		0xea, // nop
+	}), 5)
+	test.ExpectFailure(t, vcs.IsInAttractMode())
+	test.DemandSuccess(t, vcs.RunForFrameCount(4, nil))
+	test.ExpectFailure(t, vcs.IsInAttractMode())
+	test.DemandSuccess(t, vcs.RunForFrameCount(2, nil))
+	test.ExpectSuccess(t, vcs.IsInAttractMode())
+
+	// program that polls the joystick
+	vcs = newAttractVCS(t, attractROM([]byte{
+		0xad, 0x80, 0x02, // lda SWCHA
+	}), 5)
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+	test.ExpectFailure(t, vcs.IsInAttractMode())
+
+	// program that polls the fire button
+	vcs = newAttractVCS(t, attractROM([]byte{
+		0x24, 0x0c, // bit INPT4
+	}), 5)
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+	test.ExpectFailure(t, vcs.IsInAttractMode())
+
+	// a reset starts the count again
+	vcs = newAttractVCS(t, attractROM([]byte{
+		0xea, // nop
+	}), 5)
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+	test.ExpectSuccess(t, vcs.IsInAttractMode())
+	test.DemandSuccess(t, vcs.Reset())
+	test.ExpectFailure(t, vcs.IsInAttractMode())
+}
@@ -0,0 +1,153 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+)
+
+// ChipWrite is a single change to a chip register along with the television
+// coordinates at which the change was observed.
+type ChipWrite struct {
+	Coords coords.TelevisionCoords
+	chipbus.ChangedRegister
+}
+
+// ReadChipWrites reads a list of chip writes in the CSV format written by the
+// debugger's TIA LOG command. The first record is the header and is ignored.
+func ReadChipWrites(r io.Reader) ([]ChipWrite, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("chip writes: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var writes []ChipWrite
+
+	for i, rec := range records[1:] {
+		if len(rec) != 5 {
+			return nil, fmt.Errorf("chip writes: record %d: wrong number of fields", i+1)
+		}
+
+		var w ChipWrite
+
+		w.Coords.Frame, err = strconv.Atoi(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("chip writes: record %d: %w", i+1, err)
+		}
+		w.Coords.Scanline, err = strconv.Atoi(rec[1])
+		if err != nil {
+			return nil, fmt.Errorf("chip writes: record %d: %w", i+1, err)
+		}
+		w.Coords.Clock, err = strconv.Atoi(rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("chip writes: record %d: %w", i+1, err)
+		}
+
+		var ok bool
+		w.Register = cpubus.Register(rec[3])
+		w.Address, ok = cpubus.WriteAddressByRegister[w.Register]
+		if !ok {
+			return nil, fmt.Errorf("chip writes: record %d: unknown register %s", i+1, rec[3])
+		}
+
+		v, err := strconv.ParseUint(rec[4], 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("chip writes: record %d: %w", i+1, err)
+		}
+		w.Value = uint8(v)
+
+		writes = append(writes, w)
+	}
+
+	return writes, nil
+}
+
+// ReplayChipWrites resets the VCS and then drives the TIA with the list of
+// chip writes. The CPU is not used so a cartridge does not need to be
+// attached. Writes to the RIOT are ignored.
+//
+// Each write is applied on the CPU cycle with the matching television
+// coordinates. The writes must be in the order in which they were recorded.
+// A write with coordinates that the television has already passed is skipped
+// and replaying continues with the next write. Replaying ends once the
+// television has moved past the frame of the final write.
+//
+// Returns an error if any of the writes were skipped or could not be applied.
+func (vcs *VCS) ReplayChipWrites(writes []ChipWrite) error {
+	err := vcs.Reset()
+	if err != nil {
+		return err
+	}
+
+	// only writes to the TIA are replayed
+	var tiaWrites []ChipWrite
+	for _, w := range writes {
+		if _, ok := cpubus.TIAWriteRegisters[w.Address]; ok {
+			tiaWrites = append(tiaWrites, w)
+		}
+	}
+
+	if len(tiaWrites) == 0 {
+		return nil
+	}
+
+	lastFrame := tiaWrites[len(tiaWrites)-1].Coords.Frame
+
+	var idx int
+	var skipped int
+	for vcs.TV.GetCoords().Frame <= lastFrame {
+		// writes observed by VCS.Run() have the coordinates of the start of
+		// the CPU cycle. writes observed by VCS.Step() have the coordinates of
+		// the third color clock of the CPU cycle. we accept either
+		start := vcs.TV.GetCoords()
+
+		// skip writes that are behind the beam. these writes can never be
+		// matched and would prevent any of the following writes from being
+		// applied
+		for idx < len(tiaWrites) && coords.GreaterThan(start, tiaWrites[idx].Coords) {
+			skipped++
+			idx++
+		}
+
+		vcs.TIA.QuickStep(1)
+		vcs.TIA.QuickStep(2)
+		third := vcs.TV.GetCoords()
+
+		if idx < len(tiaWrites) && (coords.Equal(tiaWrites[idx].Coords, start) || coords.Equal(tiaWrites[idx].Coords, third)) {
+			vcs.TIA.Step(tiaWrites[idx].ChangedRegister, 3)
+			idx++
+		} else {
+			vcs.TIA.QuickStep(3)
+		}
+	}
+
+	if skipped > 0 || idx < len(tiaWrites) {
+		return fmt.Errorf("chip writes: %d of %d writes skipped and %d could not be replayed", skipped, len(tiaWrites), len(tiaWrites)-idx)
+	}
+
+	return nil
+}
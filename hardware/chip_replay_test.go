@@ -0,0 +1,139 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/digest"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a 4k ROM that draws a frame of 259 scanlines with a changing background
+// color and a playfield pattern
+func replayROM() []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta VSYNC
+		0x85, 0x02, // f004 sta WSYNC
+		0x85, 0x02, // f006 sta WSYNC
+		0x85, 0x02, // f008 sta WSYNC
+		0xa9, 0x00, // f00a lda #$00
+		0x85, 0x00, // f00c sta VSYNC
+		0xa9, 0xaa, // f00e lda #$aa
+		0x85, 0x0e, // f010 sta PF1
+		0xa2, 0x00, // f012 ldx #$00
+		0x85, 0x02, // f014 sta WSYNC
+		0x86, 0x09, // f016 stx COLUBK
+		0xe8,       // f018 inx
+		0xd0, 0xf9, // f019 bne $f014
+		0x4c, 0x00, 0xf0, // f01b jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+// chipWrites records every chip write observed by the VCS
+type chipWrites struct {
+	vcs    *hardware.VCS
+	writes []hardware.ChipWrite
+}
+
+func (c *chipWrites) ObserveChipWrite(reg chipbus.ChangedRegister) {
+	c.writes = append(c.writes, hardware.ChipWrite{
+		Coords:          c.vcs.TV.GetCoords(),
+		ChangedRegister: reg,
+	})
+}
+
+// newDigestVCS creates a VCS with a video digest attached to the television.
+// the ROM can be nil
+func newDigestVCS(t *testing.T, rom []byte) (*hardware.VCS, *digest.Video) {
+	t.Helper()
+
+	vcs := hardwaretest.NewVCS(t, rom)
+	dig, err := digest.NewVideo(vcs.TV)
+	test.DemandSuccess(t, err)
+
+	return vcs, dig
+}
+
+func TestChipReplay(t *testing.T) {
+	prefs.DisableSaving = true
+
+	const endFrame = 3
+
+	// capture the chip writes for the first few frames
+	vcs, dig := newDigestVCS(t, replayROM())
+
+	capture := &chipWrites{vcs: vcs}
+	vcs.SetChipWriteObserver(capture)
+
+	err := vcs.Run(func() (govern.State, error) {
+		if vcs.TV.GetCoords().Frame >= endFrame {
+			return govern.Ending, nil
+		}
+		return govern.Running, nil
+	})
+	test.DemandSuccess(t, err)
+	test.ExpectSuccess(t, len(capture.writes) > 0)
+
+	// write the captured writes in the same format as the debugger's chip log
+	// and read them back
+	var s strings.Builder
+	s.WriteString("frame,scanline,clock,register,value\n")
+	for _, w := range capture.writes {
+		s.WriteString(fmt.Sprintf("%d,%d,%d,%s,%#02x\n", w.Coords.Frame, w.Coords.Scanline, w.Coords.Clock, w.Register, w.Value))
+	}
+
+	writes, err := hardware.ReadChipWrites(strings.NewReader(s.String()))
+	test.DemandSuccess(t, err)
+	test.DemandEquality(t, len(writes), len(capture.writes))
+	for i := range writes {
+		test.ExpectEquality(t, writes[i], capture.writes[i])
+	}
+
+	// replay the writes into a machine with no cartridge
+	replay, replayDig := newDigestVCS(t, nil)
+	test.DemandSuccess(t, replay.ReplayChipWrites(writes))
+
+	test.ExpectEquality(t, replay.TV.GetCoords().Frame, vcs.TV.GetCoords().Frame)
+	test.ExpectEquality(t, replayDig.Hash(), dig.Hash())
+
+	// a write that is out of order is behind the beam when it is reached. it
+	// is skipped and the remaining writes are still replayed
+	outOfOrder := make([]hardware.ChipWrite, 0, len(writes)+1)
+	outOfOrder = append(outOfOrder, writes[:10]...)
+	outOfOrder = append(outOfOrder, writes[0])
+	outOfOrder = append(outOfOrder, writes[10:]...)
+
+	replay, replayDig = newDigestVCS(t, nil)
+	err = replay.ReplayChipWrites(outOfOrder)
+	test.ExpectFailure(t, err)
+	test.ExpectSuccess(t, strings.Contains(err.Error(), fmt.Sprintf("1 of %d writes skipped", len(outOfOrder))))
+	test.ExpectEquality(t, replayDig.Hash(), dig.Hash())
+
+	// an unknown register is an error
+	_, err = hardware.ReadChipWrites(strings.NewReader("frame,scanline,clock,register,value\n1,0,0,FOO,0x00\n"))
+	test.ExpectFailure(t, err)
+}
@@ -18,6 +18,7 @@ package cpu
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
@@ -77,8 +78,36 @@ type CPU struct {
 
 	// the cpu has encounted a KIL instruction. requires a Reset()
 	Killed bool
+
+	// CallDepth is the number of JSR instructions that have not been matched
+	// by an RTS instruction. It can be negative if the program has manipulated
+	// the stack, for example by pushing an address and using RTS as a jump
+	CallDepth int
+
+	// the value of the stack pointer immediately after every unmatched JSR
+	// instruction. the most recent JSR is at the end of the slice
+	callStack []uint8
+
+	// CycleCount is the number of CPU cycles since the last Reset(). cycles
+	// where the RDY flag is false or where the CPU has been killed are
+	// counted
+	CycleCount int
+
+	// notified of every instruction completed by the CPU. see
+	// SetInstructionObserver()
+	instructionObserver InstructionObserver
 }
 
+// InstructionObserver is notified of every instruction completed by the CPU.
+type InstructionObserver interface {
+	ObserveInstruction(result execution.Result)
+}
+
+// the maximum number of entries in the callStack. the stack pointer is an
+// eight bit value so there can never be more than 128 return addresses on the
+// stack
+const maxCallStack = 128
+
 const (
 	// NMI is the address where the non-maskable interrupt address is stored.
 	NMI = uint16(0xfffa)
@@ -117,6 +146,7 @@ func NewCPU(mem Memory) *CPU {
 // Snapshot creates a copy of the CPU in its current state.
 func (mc *CPU) Snapshot() *CPU {
 	n := *mc
+	n.callStack = slices.Clone(mc.callStack)
 	return &n
 }
 
@@ -132,6 +162,21 @@ func (mc *CPU) String() string {
 		mc.SP.Label(), mc.SP, mc.Status.Label(), mc.Status)
 }
 
+// SetInstructionObserver sets the observer that will be notified of every
+// instruction completed by the CPU. A value of nil removes any existing
+// observer.
+//
+// The observer is part of the CPU so it will be copied by Snapshot().
+func (mc *CPU) SetInstructionObserver(obs InstructionObserver) {
+	mc.instructionObserver = obs
+}
+
+// InstructionObserver returns the current instruction observer. Returns nil
+// if there is no observer.
+func (mc *CPU) InstructionObserver() InstructionObserver {
+	return mc.instructionObserver
+}
+
 // SetRDY sets the CPU RDY flag. equivalent to pin 3 of the 6507
 func (mc *CPU) SetRDY(rdy bool) {
 	mc.RdyFlg = rdy
@@ -156,9 +201,30 @@ func (mc *CPU) Reset() {
 	mc.RdyFlg = true
 	mc.cycleCallback = nil
 
+	mc.CallDepth = 0
+	mc.callStack = mc.callStack[:0]
+	mc.CycleCount = 0
+
 	// not touching NoFlowControl
 }
 
+// CallStack returns the stack address of the return address pushed by every
+// unmatched JSR instruction, starting with the most recent. The return address
+// is stored in little-endian order and is one less than the address the RTS
+// instruction will return to.
+//
+// There may be fewer entries than the CallDepth. Either because the CallDepth
+// is negative or because of the limit on the number of entries that are
+// tracked. Also, if the program has manipulated the stack then the stack
+// addresses may no longer contain a return address.
+func (mc *CPU) CallStack() []uint16 {
+	s := make([]uint16, 0, len(mc.callStack))
+	for i := len(mc.callStack) - 1; i >= 0; i-- {
+		s = append(s, 0x0100|uint16(mc.callStack[i]+1))
+	}
+	return s
+}
+
 // HasReset checks whether the CPU has recently been reset.
 func (mc *CPU) HasReset() bool {
 	return mc.LastResult.Address == 0 && mc.LastResult.Defn == nil
@@ -200,6 +266,12 @@ func (mc *CPU) LoadPC(directAddress uint16) error {
 	return nil
 }
 
+// cycle counts the CPU cycle and calls the cycleCallback function
+func (mc *CPU) cycle() error {
+	mc.CycleCount++
+	return mc.cycleCallback()
+}
+
 // read8Bit returns 8bit value from the specified address
 //
 // side-effects:
@@ -214,7 +286,7 @@ func (mc *CPU) read8Bit(address uint16, phantom bool) (uint8, error) {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return 0, err
 	}
@@ -250,7 +322,7 @@ func (mc *CPU) read16Bit(address uint16) (uint16, error) {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return 0, err
 	}
@@ -262,7 +334,7 @@ func (mc *CPU) read16Bit(address uint16) (uint16, error) {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return 0, err
 	}
@@ -331,7 +403,7 @@ func (mc *CPU) read8BitPC(effect read8BitPCeffect) error {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return err
 	}
@@ -365,7 +437,7 @@ func (mc *CPU) read16BitPC() error {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return err
 	}
@@ -386,7 +458,7 @@ func (mc *CPU) read16BitPC() error {
 
 	// +1 cycle
 	mc.LastResult.Cycles++
-	err = mc.cycleCallback()
+	err = mc.cycle()
 	if err != nil {
 		return err
 	}
@@ -485,6 +557,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 	// the CPU does nothing if it is in the KIL state. however, the other
 	// parts of the VCS continue
 	if mc.Killed {
+		mc.CycleCount++
 		return cycleCallback()
 	}
 
@@ -499,6 +572,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 
 	// do nothing and return nothing if ready flag is false
 	if !mc.RdyFlg {
+		mc.CycleCount++
 		return cycleCallback()
 	}
 
@@ -655,7 +729,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 
 			// +1 cycle
 			mc.LastResult.Cycles++
-			err = mc.cycleCallback()
+			err = mc.cycle()
 			if err != nil {
 				return err
 			}
@@ -673,7 +747,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 
 			// +1 cycle
 			mc.LastResult.Cycles++
-			err = mc.cycleCallback()
+			err = mc.cycle()
 			if err != nil {
 				return err
 			}
@@ -868,6 +942,15 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		return fmt.Errorf("cpu: unknown addressing mode for %s", defn.Operator)
 	}
 
+	// note the effective address for instructions that access memory
+	switch defn.Effect {
+	case instructions.Read, instructions.Write, instructions.RMW:
+		if defn.AddressingMode != instructions.Implied && defn.AddressingMode != instructions.Immediate {
+			mc.LastResult.EffectiveAddress = address
+			mc.LastResult.HasEffectiveAddress = true
+		}
+	}
+
 	// read value from memory using address found in AddressingMode switch above only when:
 	// a) addressing mode is not 'implied' or 'immediate'
 	//	- for immediate modes, we already have the value in lieu of an address
@@ -897,7 +980,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			}
 
 			mc.LastResult.Cycles++
-			err = mc.cycleCallback()
+			err = mc.cycle()
 			if err != nil {
 				return err
 			}
@@ -938,7 +1021,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		}
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -947,7 +1030,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// +1 cycle
 		mc.SP.Add(1, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -969,7 +1052,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		}
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -978,7 +1061,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// +1 cycle
 		mc.SP.Add(1, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1055,7 +1138,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1067,7 +1150,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1079,7 +1162,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1293,7 +1376,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1310,7 +1393,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		}
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1323,7 +1406,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		}
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1344,6 +1427,12 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			mc.PC.Load(address)
 		}
 
+		mc.CallDepth++
+		if len(mc.callStack) >= maxCallStack {
+			mc.callStack = mc.callStack[1:]
+		}
+		mc.callStack = append(mc.callStack, mc.SP.Value())
+
 	case instructions.Rts:
 		// dummy read of address at current SP before the pointer
 		// is advanced for the real 16bit read
@@ -1368,6 +1457,11 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			mc.PC.Add(1)
 		}
 
+		mc.CallDepth--
+		if len(mc.callStack) > 0 {
+			mc.callStack = mc.callStack[:len(mc.callStack)-1]
+		}
+
 		// +1 cycle
 		_, err = mc.read8Bit(mc.PC.Address(), false)
 
@@ -1381,7 +1475,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// +1 cycle
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1394,7 +1488,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// +1 cycle
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1408,7 +1502,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// +1 cycle
 		mc.SP.Add(0xff, false)
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1435,7 +1529,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 		// not sure when this cycle should occur
 		// +1 cycle
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1525,7 +1619,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1606,7 +1700,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1627,7 +1721,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1644,7 +1738,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1661,7 +1755,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 			return err
 		}
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1692,7 +1786,7 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 
 		// +1 cycle
 		mc.LastResult.Cycles++
-		err = mc.cycleCallback()
+		err = mc.cycle()
 		if err != nil {
 			return err
 		}
@@ -1701,6 +1795,9 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 	// finalise result
 	if mc.LastResult.Defn != nil {
 		mc.LastResult.Final = true
+		if mc.instructionObserver != nil {
+			mc.instructionObserver.ObserveInstruction(mc.LastResult)
+		}
 	}
 
 	// validity check. there's no need to enable unless you've just added a new
@@ -584,6 +584,58 @@ func testSubroutineInstructions(t *testing.T, mc *cpu.CPU, mem *testMem) {
 	rtest.EquateRegisters(t, mc.SP.Register, 255)
 }
 
+func testCallDepth(t *testing.T, mc *cpu.CPU, mem *testMem) {
+	var origin uint16
+	mem.Clear()
+	mc.Reset()
+
+	// return address on the stack for each entry in the call stack
+	returnAddresses := func() []uint16 {
+		var r []uint16
+		for _, a := range mc.CallStack() {
+			lo, _ := mem.Read(a)
+			hi, _ := mem.Read(a + 1)
+			r = append(r, (uint16(hi)<<8|uint16(lo))+1)
+		}
+		return r
+	}
+
+	// nested JSRs
+	_ = mem.putInstructions(origin, 0x20, 0x00, 0x02, 0x60)
+	_ = mem.putInstructions(0x0200, 0x20, 0x00, 0x03, 0x60)
+	_ = mem.putInstructions(0x0300, 0x60)
+
+	step(t, mc) // JSR $0200
+	test.ExpectEquality(t, mc.CallDepth, 1)
+	step(t, mc) // JSR $0300
+	test.ExpectEquality(t, mc.CallDepth, 2)
+
+	r := returnAddresses()
+	test.DemandEquality(t, len(r), 2)
+	test.ExpectEquality(t, r[0], 0x0203)
+	test.ExpectEquality(t, r[1], 0x0003)
+
+	step(t, mc) // RTS
+	test.ExpectEquality(t, mc.CallDepth, 1)
+	r = returnAddresses()
+	test.DemandEquality(t, len(r), 1)
+	test.ExpectEquality(t, r[0], 0x0003)
+
+	step(t, mc) // RTS
+	test.ExpectEquality(t, mc.CallDepth, 0)
+	test.ExpectEquality(t, len(mc.CallStack()), 0)
+
+	// RTS without a JSR
+	rtest.EquateRegisters(t, mc.PC, 0x0003)
+	step(t, mc) // RTS
+	test.ExpectEquality(t, mc.CallDepth, -1)
+	test.ExpectEquality(t, len(mc.CallStack()), 0)
+
+	// reset clears call depth
+	mc.Reset()
+	test.ExpectEquality(t, mc.CallDepth, 0)
+}
+
 func testDecimalMode(t *testing.T, mc *cpu.CPU, mem *testMem) {
 	var origin uint16
 	mem.Clear()
@@ -623,6 +675,53 @@ func testKIL(t *testing.T, mc *cpu.CPU, mem *testMem) {
 	rtest.EquateRegisters(t, mc.A, 0x00)
 }
 
+func testEffectiveAddress(t *testing.T, mc *cpu.CPU, mem *testMem) {
+	var origin uint16
+	mem.Clear()
+	mc.Reset()
+
+	mem.putInstructions(0x0185, 0x55)
+
+	// LDX immediate; LDA absolute,X
+	origin = mem.putInstructions(origin, 0xa2, 0x05, 0xbd, 0x80, 0x01)
+	step(t, mc) // LDX #$05
+	test.ExpectEquality(t, mc.LastResult.HasEffectiveAddress, false)
+	step(t, mc) // LDA $0180,X
+	rtest.EquateRegisters(t, mc.A, 0x55)
+	test.ExpectEquality(t, mc.LastResult.HasEffectiveAddress, true)
+	test.ExpectEquality(t, mc.LastResult.EffectiveAddress, 0x0185)
+
+	// STA zero page,X (with wraparound)
+	_ = mem.putInstructions(origin, 0x95, 0xfe)
+	step(t, mc) // STA $fe,X
+	test.ExpectEquality(t, mc.LastResult.HasEffectiveAddress, true)
+	test.ExpectEquality(t, mc.LastResult.EffectiveAddress, 0x0003)
+	mem.assert(t, 0x0003, 0x55)
+}
+
+func testCycleCount(t *testing.T, mc *cpu.CPU, mem *testMem) {
+	var origin uint16
+	mem.Clear()
+	mc.Reset()
+	test.ExpectEquality(t, mc.CycleCount, 0)
+
+	// NOP; LDA absolute
+	_ = mem.putInstructions(origin, 0xea, 0xad, 0x00, 0x01)
+	step(t, mc) // NOP
+	test.ExpectEquality(t, mc.CycleCount, 2)
+	step(t, mc) // LDA $0100
+	test.ExpectEquality(t, mc.CycleCount, 6)
+
+	// cycles are counted when the RDY flag is false
+	mc.SetRDY(false)
+	step(t, mc)
+	test.ExpectEquality(t, mc.CycleCount, 7)
+	mc.SetRDY(true)
+
+	mc.Reset()
+	test.ExpectEquality(t, mc.CycleCount, 0)
+}
+
 func TestCPU(t *testing.T) {
 	mem := newTestMem()
 	mc := cpu.NewCPU(mem)
@@ -640,7 +739,10 @@ func TestCPU(t *testing.T) {
 	testJumps(t, mc, mem)
 	testComparisonInstructions(t, mc, mem)
 	testSubroutineInstructions(t, mc, mem)
+	testCallDepth(t, mc, mem)
 	testDecimalMode(t, mc, mem)
 	testBRK(t, mc, mem)
 	testKIL(t, mc, mem)
+	testEffectiveAddress(t, mc, mem)
+	testCycleCount(t, mc, mem)
 }
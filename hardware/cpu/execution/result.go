@@ -63,6 +63,12 @@ type Result struct {
 	// may be different
 	Cycles int
 
+	// the address in memory that was accessed by the instruction after any
+	// indexing or indirection has taken place. only valid if
+	// HasEffectiveAddress is true
+	EffectiveAddress    uint16
+	HasEffectiveAddress bool
+
 	// whether an extra cycle was required because of 8 bit adder overflow
 	PageFault bool
 
@@ -85,6 +91,8 @@ func (r *Result) Reset() {
 	r.Address = 0
 	r.InstructionData = 0
 	r.Cycles = 0
+	r.EffectiveAddress = 0
+	r.HasEffectiveAddress = false
 	r.PageFault = false
 	r.CPUBug = ""
 	r.Final = false
@@ -5,260 +5,260 @@ package instructions
 // GetDefinitions returns the table of instruction definitions for the 6507
 func GetDefinitions() []*Definition {
 	return []*Definition{
-		&Definition{OpCode: 0x0, Operator: 16, Bytes: 1, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 0, PageSensitive: false, Effect: 5, Undocumented: true},
-		&Definition{OpCode: 0x1, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x3, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x5, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x6, Operator: 6, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x7, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x8, Operator: 47, Bytes: 1, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 0, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x9, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa, Operator: 6, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb, Operator: 3, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xd, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe, Operator: 6, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xf, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x10, Operator: 15, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x11, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x12, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x13, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x14, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x15, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x16, Operator: 6, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x17, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x18, Operator: 19, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x19, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x1a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x1b, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x1c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x1d, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x1e, Operator: 6, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x1f, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x20, Operator: 36, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 4, Undocumented: true},
-		&Definition{OpCode: 0x21, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x22, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x23, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x24, Operator: 12, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x25, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x26, Operator: 51, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x27, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x28, Operator: 49, Bytes: 1, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x29, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x2a, Operator: 51, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x2b, Operator: 3, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x2c, Operator: 12, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x2d, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x2e, Operator: 51, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x2f, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x30, Operator: 13, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x31, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x32, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x33, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x34, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x35, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x36, Operator: 51, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x37, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x38, Operator: 59, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x39, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x3a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x3b, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x3c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x3d, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x3e, Operator: 51, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x3f, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x40, Operator: 54, Bytes: 1, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 0, PageSensitive: false, Effect: 5, Undocumented: true},
-		&Definition{OpCode: 0x41, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x42, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x43, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x44, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x45, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x46, Operator: 43, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x47, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x48, Operator: 46, Bytes: 1, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 0, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x49, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x4a, Operator: 43, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x4b, Operator: 7, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x4c, Operator: 35, Bytes: 3, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 3, PageSensitive: false, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x4d, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x4e, Operator: 43, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x4f, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x50, Operator: 17, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x51, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x52, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x53, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x54, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x55, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x56, Operator: 43, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x57, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x58, Operator: 21, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x59, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x5a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x5b, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x5c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x5d, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x5e, Operator: 43, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x5f, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x60, Operator: 55, Bytes: 1, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 0, PageSensitive: false, Effect: 4, Undocumented: true},
-		&Definition{OpCode: 0x61, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x62, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x63, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x64, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x65, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x66, Operator: 52, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x67, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x68, Operator: 48, Bytes: 1, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x69, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x6a, Operator: 52, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x6b, Operator: 5, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x6c, Operator: 35, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 5, PageSensitive: false, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x6d, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x6e, Operator: 52, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x6f, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x70, Operator: 18, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x71, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x72, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x73, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x74, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x75, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x76, Operator: 52, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x77, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x78, Operator: 61, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x79, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x7a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x7b, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x7c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x7d, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x7e, Operator: 52, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0x7f, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0x80, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x81, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x82, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x83, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x84, Operator: 68, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x85, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x86, Operator: 67, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x87, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x88, Operator: 29, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x89, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x8a, Operator: 73, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x8b, Operator: 76, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x8c, Operator: 68, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x8d, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x8e, Operator: 67, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x8f, Operator: 56, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x90, Operator: 9, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0x91, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 7, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x92, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0x93, Operator: 2, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 7, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x94, Operator: 68, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x95, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x96, Operator: 67, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x97, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x98, Operator: 75, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x99, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x9a, Operator: 74, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0x9b, Operator: 69, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x9c, Operator: 63, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 8, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x9d, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 8, PageSensitive: false, Effect: 1, Undocumented: true},
-		&Definition{OpCode: 0x9e, Operator: 62, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0x9f, Operator: 2, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: false},
-		&Definition{OpCode: 0xa0, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa1, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa2, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa3, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xa4, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa5, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa6, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa7, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xa8, Operator: 71, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xa9, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xaa, Operator: 70, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xab, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xac, Operator: 42, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xad, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xae, Operator: 41, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xaf, Operator: 39, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xb0, Operator: 10, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0xb1, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xb3, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xb4, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb5, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb6, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb7, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xb8, Operator: 22, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xb9, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xba, Operator: 72, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xbb, Operator: 38, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xbc, Operator: 42, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xbd, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xbe, Operator: 41, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xbf, Operator: 39, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xc0, Operator: 25, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xc1, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xc2, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xc3, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xc4, Operator: 25, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xc5, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xc6, Operator: 27, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xc7, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xc8, Operator: 33, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xc9, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xca, Operator: 28, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xcb, Operator: 8, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xcc, Operator: 25, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xcd, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xce, Operator: 27, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xcf, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xd0, Operator: 14, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0xd1, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xd2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xd3, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xd4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xd5, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xd6, Operator: 27, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xd7, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xd8, Operator: 20, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xd9, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xda, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xdb, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xdc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xdd, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xde, Operator: 27, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xdf, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xe0, Operator: 24, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe1, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe2, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xe3, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xe4, Operator: 24, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe5, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe6, Operator: 31, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xe7, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xe8, Operator: 32, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xe9, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xea, Operator: 0, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xeb, Operator: 58, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xec, Operator: 24, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xed, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xee, Operator: 31, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xef, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xf0, Operator: 11, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: true},
-		&Definition{OpCode: 0xf1, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xf2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xf3, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xf4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xf5, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xf6, Operator: 31, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xf7, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xf8, Operator: 60, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xf9, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xfa, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xfb, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: false},
-		&Definition{OpCode: 0xfc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
-		&Definition{OpCode: 0xfd, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
-		&Definition{OpCode: 0xfe, Operator: 31, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
-		&Definition{OpCode: 0xff, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false}}
+		&Definition{OpCode: 0x0, Operator: 16, Bytes: 1, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 0, PageSensitive: false, Effect: 5, Undocumented: false},
+		&Definition{OpCode: 0x1, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x3, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x5, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x6, Operator: 6, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x7, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x8, Operator: 47, Bytes: 1, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 0, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x9, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa, Operator: 6, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb, Operator: 3, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xd, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe, Operator: 6, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xf, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x10, Operator: 15, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x11, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x12, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x13, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x14, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x15, Operator: 45, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x16, Operator: 6, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x17, Operator: 64, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x18, Operator: 19, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x19, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x1a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x1b, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x1c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x1d, Operator: 45, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x1e, Operator: 6, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x1f, Operator: 64, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x20, Operator: 36, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 4, Undocumented: false},
+		&Definition{OpCode: 0x21, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x22, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x23, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x24, Operator: 12, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x25, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x26, Operator: 51, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x27, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x28, Operator: 49, Bytes: 1, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x29, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x2a, Operator: 51, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x2b, Operator: 3, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x2c, Operator: 12, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x2d, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x2e, Operator: 51, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x2f, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x30, Operator: 13, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x31, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x32, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x33, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x34, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x35, Operator: 4, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x36, Operator: 51, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x37, Operator: 50, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x38, Operator: 59, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x39, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x3a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x3b, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x3c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x3d, Operator: 4, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x3e, Operator: 51, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x3f, Operator: 50, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x40, Operator: 54, Bytes: 1, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 0, PageSensitive: false, Effect: 5, Undocumented: false},
+		&Definition{OpCode: 0x41, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x42, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x43, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x44, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x45, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x46, Operator: 43, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x47, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x48, Operator: 46, Bytes: 1, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 0, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x49, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x4a, Operator: 43, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x4b, Operator: 7, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x4c, Operator: 35, Bytes: 3, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 3, PageSensitive: false, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x4d, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x4e, Operator: 43, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x4f, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x50, Operator: 17, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x51, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x52, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x53, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x54, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x55, Operator: 30, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x56, Operator: 43, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x57, Operator: 65, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x58, Operator: 21, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x59, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x5a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x5b, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x5c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x5d, Operator: 30, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x5e, Operator: 43, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x5f, Operator: 65, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x60, Operator: 55, Bytes: 1, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 0, PageSensitive: false, Effect: 4, Undocumented: false},
+		&Definition{OpCode: 0x61, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x62, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x63, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x64, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x65, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x66, Operator: 52, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x67, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x68, Operator: 48, Bytes: 1, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x69, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x6a, Operator: 52, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x6b, Operator: 5, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x6c, Operator: 35, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 5, PageSensitive: false, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x6d, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x6e, Operator: 52, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x6f, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x70, Operator: 18, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x71, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x72, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x73, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x74, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x75, Operator: 1, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x76, Operator: 52, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x77, Operator: 53, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x78, Operator: 61, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x79, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x7a, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x7b, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x7c, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x7d, Operator: 1, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x7e, Operator: 52, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0x7f, Operator: 53, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0x80, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x81, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x82, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x83, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x84, Operator: 68, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x85, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x86, Operator: 67, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x87, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x88, Operator: 29, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x89, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x8a, Operator: 73, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x8b, Operator: 76, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x8c, Operator: 68, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x8d, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x8e, Operator: 67, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x8f, Operator: 56, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x90, Operator: 9, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0x91, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 7, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x92, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0x93, Operator: 2, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 7, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x94, Operator: 68, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x95, Operator: 66, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x96, Operator: 67, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x97, Operator: 56, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x98, Operator: 75, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x99, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x9a, Operator: 74, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0x9b, Operator: 69, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x9c, Operator: 63, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 8, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x9d, Operator: 66, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 8, PageSensitive: false, Effect: 1, Undocumented: false},
+		&Definition{OpCode: 0x9e, Operator: 62, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0x9f, Operator: 2, Bytes: 3, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 9, PageSensitive: false, Effect: 1, Undocumented: true},
+		&Definition{OpCode: 0xa0, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa1, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa2, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa3, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xa4, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa5, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa6, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa7, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xa8, Operator: 71, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xa9, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xaa, Operator: 70, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xab, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xac, Operator: 42, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xad, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xae, Operator: 41, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xaf, Operator: 39, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xb0, Operator: 10, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0xb1, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xb3, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xb4, Operator: 42, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb5, Operator: 40, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb6, Operator: 41, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb7, Operator: 39, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 11, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xb8, Operator: 22, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xb9, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xba, Operator: 72, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xbb, Operator: 38, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xbc, Operator: 42, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xbd, Operator: 40, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xbe, Operator: 41, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xbf, Operator: 39, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xc0, Operator: 25, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xc1, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xc2, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xc3, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xc4, Operator: 25, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xc5, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xc6, Operator: 27, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xc7, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xc8, Operator: 33, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xc9, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xca, Operator: 28, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xcb, Operator: 8, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xcc, Operator: 25, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xcd, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xce, Operator: 27, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xcf, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xd0, Operator: 14, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0xd1, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xd2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xd3, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xd4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xd5, Operator: 23, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xd6, Operator: 27, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xd7, Operator: 26, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xd8, Operator: 20, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xd9, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xda, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xdb, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xdc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xdd, Operator: 23, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xde, Operator: 27, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xdf, Operator: 26, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xe0, Operator: 24, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe1, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 6, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe2, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xe3, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 6, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xe4, Operator: 24, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe5, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 3, Formatted: "3"}, AddressingMode: 4, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe6, Operator: 31, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xe7, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 4, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xe8, Operator: 32, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xe9, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xea, Operator: 0, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xeb, Operator: 58, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 1, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xec, Operator: 24, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xed, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 3, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xee, Operator: 31, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xef, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 3, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xf0, Operator: 11, Bytes: 2, Cycles: Cycles{Value: 2, Formatted: "2/3"}, AddressingMode: 2, PageSensitive: true, Effect: 3, Undocumented: false},
+		&Definition{OpCode: 0xf1, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 5, Formatted: "5"}, AddressingMode: 7, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xf2, Operator: 37, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xf3, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 8, Formatted: "8"}, AddressingMode: 7, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xf4, Operator: 44, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xf5, Operator: 57, Bytes: 2, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 10, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xf6, Operator: 31, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xf7, Operator: 34, Bytes: 2, Cycles: Cycles{Value: 6, Formatted: "6"}, AddressingMode: 10, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xf8, Operator: 60, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xf9, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 9, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xfa, Operator: 44, Bytes: 1, Cycles: Cycles{Value: 2, Formatted: "2"}, AddressingMode: 0, PageSensitive: false, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xfb, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 9, PageSensitive: false, Effect: 2, Undocumented: true},
+		&Definition{OpCode: 0xfc, Operator: 44, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: true},
+		&Definition{OpCode: 0xfd, Operator: 57, Bytes: 3, Cycles: Cycles{Value: 4, Formatted: "4"}, AddressingMode: 8, PageSensitive: true, Effect: 0, Undocumented: false},
+		&Definition{OpCode: 0xfe, Operator: 31, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: false},
+		&Definition{OpCode: 0xff, Operator: 34, Bytes: 3, Cycles: Cycles{Value: 7, Formatted: "7"}, AddressingMode: 8, PageSensitive: false, Effect: 2, Undocumented: true}}
 }
@@ -0,0 +1,75 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package hardwaretest contains helper functions for tests that need a VCS
+// running a small test program.
+package hardwaretest
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// ROM returns a 4K cartridge containing the program. The program is placed at
+// the start of the cartridge and the reset vector points to it, so the program
+// should be assembled for address $F000.
+func ROM(prg []byte) []byte {
+	rom := make([]byte, 4096)
+	copy(rom, prg)
+
+	// reset vector
+	rom[0xffc] = 0x00
+	rom[0xffd] = 0xf0
+
+	return rom
+}
+
+// NewVCS creates a VCS with an NTSC television and attaches the ROM as a 4K
+// cartridge. No cartridge is attached if the ROM is nil. The television is
+// ended when the test finishes.
+func NewVCS(t testing.TB, rom []byte) *hardware.VCS {
+	t.Helper()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tv.End() })
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rom == nil {
+		return vcs
+	}
+
+	cartload, err := cartridgeloader.NewLoaderFromData(t.Name(), rom, "4K", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = vcs.AttachCartridge(cartload, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return vcs
+}
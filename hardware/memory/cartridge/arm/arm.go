@@ -142,7 +142,8 @@ type ARMState struct {
 	variableMemtop        uint32
 
 	// once the stack has been found to have collided there are no more attempts
-	// to protect the stack
+	// to protect the stack until the stack pointer has risen back above the top
+	// of variable memory
 	stackHasCollided bool
 
 	// cycle counting
@@ -185,11 +186,22 @@ type ARMState struct {
 
 	// the first 16bits of the most recent 32bit instruction
 	instruction32bitOpcodeHi uint16
+
+	// return addresses of the functions called during the current execution.
+	// see updateCallStack()
+	callStack []uint32
+
+	// the address of the most recent PC that left program memory. only valid
+	// if pcOutOfRange is true. reset at the start of every Run()
+	outOfRangePC uint32
+	pcOutOfRange bool
 }
 
 // Snapshot implements the mapper.CartMapper interface.
 func (s *ARMState) Snapshot() *ARMState {
 	n := *s
+	n.callStack = make([]uint32, len(s.callStack))
+	copy(n.callStack, s.callStack)
 	return &n
 }
 
@@ -225,6 +237,8 @@ type ARM struct {
 	// updated on every call to run()
 	abortOnMemoryFault      bool
 	misalignedAccessIsFault bool
+	strictAlignment         bool
+	pcOutOfRangeIsError     bool
 
 	// the speed at which the arm is running at and the required stretching for
 	// access to flash memory. speed is in MHz. Access latency of Flash memory is
@@ -301,8 +315,14 @@ type ARM struct {
 	// profiler for executed instructions. measures cycles counts
 	profiler *coprocessor.CartCoProcProfiler
 
+	// number of cycles consumed since the last call to ProcessProfiling()
+	profilingCycles float32
+
 	// enable breakpoint checking
 	breakpointsEnabled bool
+
+	// yield after every instruction. see StepInstruction()
+	singleStep bool
 }
 
 // NewARM is the preferred method of initialisation for the ARM type.
@@ -358,6 +378,11 @@ func (arm *ARM) ProcessorID() string {
 	return string(arm.mmap.ARMArchitecture)
 }
 
+// MemoryModel returns the memory map used by the ARM.
+func (arm *ARM) MemoryModel() architecture.Map {
+	return arm.mmap
+}
+
 // ImmediateMode returns whether the most recent execution was in immediate mode
 // or not.
 func (arm *ARM) ImmediateMode() bool {
@@ -446,6 +471,9 @@ func (arm *ARM) resetRegisters() {
 	// if the PC value has changed then the reset procedure is treated like a branch
 	arm.state.branchedExecution = preResetPC != arm.state.registers[rPC]
 
+	// the call stack is meaningless after a reset
+	arm.state.callStack = arm.state.callStack[:0]
+
 	// reset prefectch cycle value
 	arm.state.prefetchCycle = S
 }
@@ -488,6 +516,15 @@ func (arm *ARM) updatePrefs() {
 
 	arm.abortOnMemoryFault = arm.env.Prefs.ARM.AbortOnMemoryFault.Get().(bool)
 	arm.misalignedAccessIsFault = arm.env.Prefs.ARM.MisalignedAccessIsFault.Get().(bool)
+	arm.strictAlignment = arm.env.Prefs.ARM.StrictAlignment.Get().(bool)
+	arm.pcOutOfRangeIsError = arm.env.Prefs.ARM.PCOutOfRangeIsError.Get().(bool)
+}
+
+// UpdatePrefs implements the coprocessor.CartCoProcPreferences interface.
+// Changes to the ARM preferences are applied immediately rather than at the
+// start of the next execution.
+func (arm *ARM) UpdatePrefs() {
+	arm.updatePrefs()
 }
 
 func (arm *ARM) String() string {
@@ -624,6 +661,79 @@ func (arm *ARM) SetInitialRegisters(args ...uint32) error {
 	return nil
 }
 
+// ResetRegisters returns the ARM to the state it is in immediately after
+// creation. The registers will be set according to the ResetVectors() function
+// on the next call to Run(). Peripherals are also reset but memory is not
+// affected.
+func (arm *ARM) ResetRegisters() {
+	arm.state.yield.Type = coprocessor.YieldProgramEnded
+	arm.resetPeripherals()
+	arm.resetRegisters()
+}
+
+// SeedRegister sets a general register to a value before the next call to
+// Run(). Unlike SetInitialRegisters() the other general registers are not
+// affected, unless the previous program execution has ended, in which case the
+// registers are reset first. This means that several registers can be seeded
+// with repeated calls to the function.
+//
+// As with SetInitialRegisters(), the function will return with an error if SP,
+// LR or PC are attempted to be set.
+func (arm *ARM) SeedRegister(register int, value uint32) error {
+	if register < 0 || register >= rSP {
+		return fmt.Errorf("ARM7: trying to set registers SP, LR or PC")
+	}
+
+	// the registers would otherwise be reset on the next call to Run()
+	if arm.state.yield.Type == coprocessor.YieldProgramEnded {
+		err := arm.SetInitialRegisters()
+		if err != nil {
+			return err
+		}
+	}
+
+	arm.state.registers[register] = value
+
+	return nil
+}
+
+// LastRunCycles implements the coprocessor.CartCoProcCycles interface
+func (arm *ARM) LastRunCycles() float32 {
+	return arm.state.cyclesTotal * arm.cycleRegulator
+}
+
+// StatusFlags implements the coprocessor.CartCoProcStatus interface
+func (arm *ARM) StatusFlags() string {
+	return arm.state.status.flags()
+}
+
+// SetStatusFlag implements the coprocessor.CartCoProcStatus interface. Only
+// the N, Z, C and V flags can be changed. The IT state of the ARMv7-M
+// architecture is not affected.
+//
+// As with SeedRegister(), if the previous program execution has ended then
+// the registers are reset first so that the change survives the next call to
+// Run().
+func (arm *ARM) SetStatusFlag(flag string, set bool) error {
+	switch strings.ToUpper(flag) {
+	case "N", "Z", "C", "V":
+	default:
+		return fmt.Errorf("ARM7: unrecognised status flag: %s", flag)
+	}
+
+	// the status flags would otherwise be reset on the next call to Run()
+	if arm.state.yield.Type == coprocessor.YieldProgramEnded {
+		err := arm.SetInitialRegisters()
+		if err != nil {
+			return err
+		}
+	}
+
+	arm.state.status.setFlag(flag, set)
+
+	return nil
+}
+
 // StartProfiling starts a profiling session
 func (arm *ARM) StartProfiling() {
 	if arm.dev != nil {
@@ -634,8 +744,9 @@ func (arm *ARM) StartProfiling() {
 // ProcessProfiling ends a profiling session
 func (arm *ARM) ProcessProfiling() {
 	if arm.dev != nil {
-		arm.dev.ProcessProfiling()
+		arm.dev.ProcessProfiling(arm.profilingCycles)
 	}
+	arm.profilingCycles = 0
 }
 
 // Run will execute an ARM program from the current PC address, unless the
@@ -672,6 +783,9 @@ func (arm *ARM) Run() (coprocessor.CoProcYield, float32) {
 	// reset cycles count
 	arm.state.cyclesTotal = 0
 
+	// forget about any previous out of range PC
+	arm.state.pcOutOfRange = false
+
 	// arm.state.prefetchCycle reset in reset() function. we don't want to change
 	// the value if we're resuming from a yield
 
@@ -721,6 +835,32 @@ func (arm *ARM) Run() (coprocessor.CoProcYield, float32) {
 	return arm.run()
 }
 
+// StepInstruction executes a single instruction from the current PC address
+// and then yields. A 32bit instruction is treated as a single instruction.
+//
+// Breakpoints are not checked during the step. Returns the number of ARM cycles
+// consumed and any error that caused the ARM to yield abnormally.
+func (arm *ARM) StepInstruction() (float32, error) {
+	arm.singleStep = true
+	breakpointsEnabled := arm.breakpointsEnabled
+	arm.breakpointsEnabled = false
+
+	defer func() {
+		arm.singleStep = false
+		arm.breakpointsEnabled = breakpointsEnabled
+	}()
+
+	yld, cycles := arm.Run()
+	if yld.Error != nil {
+		return cycles, fmt.Errorf("ARM7: %s: %w", yld.Type, yld.Error)
+	}
+	if !yld.Type.Normal() {
+		return cycles, fmt.Errorf("ARM7: %s", yld.Type)
+	}
+
+	return cycles, nil
+}
+
 // Interrupt indicates that the ARM execution should cease after the current
 // instruction has been executed. The ARM will then yield with the reson
 // YieldSyncWithVCS.
@@ -810,6 +950,9 @@ func (arm *ARM) run() (coprocessor.CoProcYield, float32) {
 		if arm.state.branchedExecution {
 			arm.checkProgramMemory(false)
 			if arm.state.yield.Type != coprocessor.YieldRunning {
+				if arm.state.programMemory == nil {
+					arm.outOfRange()
+				}
 				break // for loop
 			}
 		}
@@ -826,6 +969,7 @@ func (arm *ARM) run() (coprocessor.CoProcYield, float32) {
 		if memIdx >= len(*arm.state.programMemory)-1 {
 			arm.state.yield.Type = coprocessor.YieldExecutionError
 			arm.state.yield.Error = fmt.Errorf("execution reached end of program memory")
+			arm.outOfRange()
 			break // for loop
 		}
 
@@ -889,6 +1033,9 @@ func (arm *ARM) run() (coprocessor.CoProcYield, float32) {
 			arm.state.stackFrame = expectedSP
 		}
 
+		// maintain call stack
+		arm.updateCallStack(expectedLR)
+
 		// disassemble if appropriate
 		if arm.disasm != nil {
 			if !arm.state.instruction32bitDecoding {
@@ -952,7 +1099,9 @@ func (arm *ARM) run() (coprocessor.CoProcYield, float32) {
 				arm.resetYield()
 			}
 		} else {
-			if !arm.state.yield.Type.Normal() {
+			// the stack pointer is also checked if the stack has collided
+			// previously. this gives the stack the opportunity to recover
+			if !arm.state.yield.Type.Normal() || arm.state.stackHasCollided {
 				if arm.state.registers[rSP] != expectedSP {
 					arm.stackProtectCheckSP()
 					if arm.state.yield.Type == coprocessor.YieldStackError {
@@ -977,12 +1126,51 @@ func (arm *ARM) run() (coprocessor.CoProcYield, float32) {
 				arm.resetYield()
 			}
 		}
+
+		// yield after a single instruction if requested. the second halfword
+		// of a 32bit instruction must be executed before yielding
+		if arm.singleStep && !arm.state.instruction32bitDecoding {
+			if arm.state.yield.Type == coprocessor.YieldRunning {
+				arm.state.yield.Type = coprocessor.YieldSyncWithVCS
+			}
+		}
 	}
 
+	// note address of the most recent instruction for the benefit of the
+	// emulation driver
+	arm.state.yield.Addr = arm.state.instructionPC
+
+	// cycles for the profiling session. like the cycles in the profiler
+	// entries these are not stretched by the cycle regulator
+	arm.profilingCycles += arm.state.cyclesTotal
+
 	// cycles are stretched by the cycle regulator
 	return arm.state.yield, arm.state.cyclesTotal * arm.cycleRegulator
 }
 
+// outOfRange is called when the PC has left program memory. the yield will
+// already describe the error. if the PCOutOfRangeIsError preference is false
+// then the error is replaced and the program is ended quietly
+func (arm *ARM) outOfRange() {
+	arm.state.outOfRangePC = arm.state.executingPC
+	arm.state.pcOutOfRange = true
+
+	if arm.pcOutOfRangeIsError {
+		return
+	}
+
+	logger.Logf(arm.env, "ARM7", "PC out of range (%08x): aborting thumb program early", arm.state.outOfRangePC)
+	arm.state.yield.Type = coprocessor.YieldProgramEnded
+	arm.state.yield.Error = nil
+}
+
+// OutOfRangePC returns the address of the PC that caused the most recent
+// execution to leave program memory. The boolean return value is false if the
+// PC did not go out of range during the most recent execution.
+func (arm *ARM) OutOfRangePC() (uint32, bool) {
+	return arm.state.outOfRangePC, arm.state.pcOutOfRange
+}
+
 func (arm *ARM) checkBreakpoints() {
 	// check breakpoints unless they are disabled. we also don't want to match
 	// if we're in the middle of decoding a 32bit instruction
@@ -0,0 +1,457 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/coprocessor/developer/faults"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm/architecture"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// testTV is a minimal implementation of the environment.Television interface
+type testTV struct{}
+
+func (_ testTV) GetSpecID() string                    { return "NTSC" }
+func (_ testTV) GetReqSpecID() string                 { return "NTSC" }
+func (_ testTV) SetRotation(_ specification.Rotation) {}
+func (_ testTV) GetCoords() coords.TelevisionCoords   { return coords.TelevisionCoords{} }
+
+// testMemory implements the SharedMemory interface with a single block of
+// flash memory (for the program) and a single block of SRAM
+type testMemory struct {
+	mmap  architecture.Map
+	flash []byte
+	sram  []byte
+}
+
+const testStackTop = 0x40000ffc
+
+// the program is loaded into flash memory at this offset
+const testProgramOffset = 0x100
+
+func newTestMemory(mmap architecture.Map, program []uint16) *testMemory {
+	mem := &testMemory{
+		mmap:  mmap,
+		flash: make([]byte, 0x1000),
+		sram:  make([]byte, 0x1000),
+	}
+	for i, op := range program {
+		binary.LittleEndian.PutUint16(mem.flash[testProgramOffset+i*2:], op)
+	}
+	return mem
+}
+
+func (mem *testMemory) MapAddress(addr uint32, write bool, executing bool) (*[]byte, uint32) {
+	if addr >= mem.mmap.FlashOrigin && addr < mem.mmap.FlashOrigin+uint32(len(mem.flash)) {
+		return &mem.flash, mem.mmap.FlashOrigin
+	}
+	if addr >= mem.mmap.SRAMOrigin && addr < mem.mmap.SRAMOrigin+uint32(len(mem.sram)) {
+		return &mem.sram, mem.mmap.SRAMOrigin
+	}
+	return nil, 0
+}
+
+func (mem *testMemory) ResetVectors() (uint32, uint32, uint32) {
+	return testStackTop, mem.mmap.FlashOrigin + uint32(len(mem.flash)) - 4, mem.mmap.FlashOrigin + testProgramOffset
+}
+
+func (mem *testMemory) IsExecutable(addr uint32) bool {
+	return addr >= mem.mmap.FlashOrigin && addr < mem.mmap.FlashOrigin+uint32(len(mem.flash))
+}
+
+// newTestARM creates an ARM7TDMI with the program loaded into flash memory
+func newTestARM(t *testing.T, program []uint16) (*ARM, *testMemory) {
+	t.Helper()
+
+	prefs.DisableSaving = true
+
+	env, err := environment.NewEnvironment(environment.MainEmulation, testTV{}, nil, nil)
+	test.DemandSuccess(t, err)
+
+	mmap := architecture.NewMap(architecture.Harmony)
+	mem := newTestMemory(mmap, program)
+	return NewARM(env, mmap, mem, nil), mem
+}
+
+func TestClockPreference(t *testing.T) {
+	arm, _ := newTestARM(t, nil)
+
+	// flash latency for the Harmony architecture is 50ns, or 20MHz
+	latencyInMhz := (1 / (arm.mmap.FlashLatency / 1000000000)) / 1000000
+
+	for _, mhz := range []float64{10.0, 20.0, 70.0, 100.0, 168.0} {
+		err := arm.env.Prefs.ARM.Clock.Set(mhz)
+		test.DemandSuccess(t, err)
+		arm.UpdatePrefs()
+
+		test.ExpectEquality(t, arm.Clk, float32(mhz))
+		test.ExpectEquality(t, arm.clklenFlash, float32(math.Ceil(mhz/latencyInMhz)))
+	}
+
+	// spot check some values
+	arm.env.Prefs.ARM.Clock.Set(70.0)
+	arm.UpdatePrefs()
+	test.ExpectEquality(t, arm.clklenFlash, float32(4))
+
+	arm.env.Prefs.ARM.Clock.Set(20.0)
+	arm.UpdatePrefs()
+	test.ExpectEquality(t, arm.clklenFlash, float32(1))
+}
+
+func TestMAMPreference(t *testing.T) {
+	// a loop that loads a word from flash memory on every iteration
+	program := []uint16{
+		0x2000, // MOV R0, #0
+		0x4901, // LDR R1, [PC, #4]
+		0x3001, // ADD R0, #1
+		0x2864, // CMP R0, #100
+		0xd1fb, // BNE to the LDR instruction
+	}
+
+	// cycle count for the first 200 instructions of the program with the MAM
+	// preference set to mode
+	cycles := func(mode architecture.MAMCR) float32 {
+		t.Helper()
+
+		arm, _ := newTestARM(t, program)
+		test.DemandSuccess(t, arm.env.Prefs.ARM.Clock.Set(70.0))
+		test.DemandSuccess(t, arm.env.Prefs.ARM.MAM.Set(int(mode)))
+
+		var total float32
+		for range 200 {
+			c, err := arm.StepInstruction()
+			test.DemandSuccess(t, err)
+			total += c
+		}
+
+		test.ExpectEquality(t, arm.state.mam.mamcr, mode)
+		return total
+	}
+
+	disabled := cycles(architecture.MAMdisabled)
+	partial := cycles(architecture.MAMpartial)
+	full := cycles(architecture.MAMfull)
+
+	// program fetches are accelerated by the partial and full modes. data
+	// accesses are only accelerated by the full mode
+	test.ExpectSuccess(t, partial < disabled)
+	test.ExpectSuccess(t, full < partial)
+}
+
+func TestLoadMultipleCycles(t *testing.T) {
+	program := []uint16{
+		0x2080, // MOV R0, #$80
+		0xc81e, // LDMIA R0!, {R1-R4}
+	}
+
+	// cycle count for the LDMIA instruction with the MAM preference set to mode
+	cycles := func(mode architecture.MAMCR) float32 {
+		t.Helper()
+
+		arm, _ := newTestARM(t, program)
+		test.DemandSuccess(t, arm.env.Prefs.ARM.Clock.Set(70.0))
+		test.DemandSuccess(t, arm.env.Prefs.ARM.MAM.Set(int(mode)))
+
+		_, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+		c, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+		return c
+	}
+
+	// the four words loaded from flash are in the same MAM line. with the MAM
+	// fully enabled, only the first word is subject to the flash latency of
+	// four cycles. the remaining three words are latched and take one cycle
+	// each. the internal cycle takes the total to eight cycles
+	test.ExpectEquality(t, cycles(architecture.MAMfull), float32(8))
+	test.ExpectSuccess(t, cycles(architecture.MAMfull) < cycles(architecture.MAMdisabled))
+}
+
+func TestStepInstruction(t *testing.T) {
+	arm, mem := newTestARM(t, []uint16{
+		0x2001,         // MOV R0, #1
+		0x2102,         // MOV R1, #2
+		0x1842,         // ADD R2, R0, R1
+		0xf000, 0xf803, // BL to the MOV R3 instruction below
+		0x46c0, // NOP
+		0x46c0, // NOP
+		0x46c0, // NOP
+		0x2303, // MOV R3, #3
+	})
+
+	origin := mem.mmap.FlashOrigin + testProgramOffset
+
+	expectedPC := []uint32{origin, origin + 2, origin + 4, origin + 6, origin + 16}
+	for _, pc := range expectedPC {
+		_, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+		test.ExpectEquality(t, arm.state.instructionPC, pc)
+	}
+
+	test.ExpectEquality(t, arm.state.registers[0], uint32(1))
+	test.ExpectEquality(t, arm.state.registers[1], uint32(2))
+	test.ExpectEquality(t, arm.state.registers[2], uint32(3))
+	test.ExpectEquality(t, arm.state.registers[3], uint32(3))
+
+	// link register points to the instruction after the BL instruction
+	test.ExpectEquality(t, arm.state.registers[rLR], (origin+10)|1)
+}
+
+func TestYieldAddress(t *testing.T) {
+	arm, mem := newTestARM(t, []uint16{
+		0x2001, // MOV R0, #1
+		0x2102, // MOV R1, #2
+	})
+
+	origin := mem.mmap.FlashOrigin + testProgramOffset
+
+	// single stepping yields with the YieldSyncWithVCS reason and the address
+	// of the instruction just executed
+	for _, pc := range []uint32{origin, origin + 2} {
+		_, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+		test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldSyncWithVCS)
+		test.ExpectEquality(t, arm.state.yield.Addr, pc)
+	}
+
+	test.ExpectEquality(t, arm.state.yield.String(), fmt.Sprintf("Sync with VCS at %08x", origin+2))
+}
+
+func TestImmediateMode(t *testing.T) {
+	arm, _ := newTestARM(t, []uint16{
+		0x2001, // MOV R0, #1
+		0x2102, // MOV R1, #2
+	})
+
+	// the cycle counting functions are chosen at the start of each run so a
+	// change to the preference has no effect until the next run
+	test.DemandSuccess(t, arm.env.Prefs.ARM.Immediate.Set(false))
+	_, err := arm.StepInstruction()
+	test.DemandSuccess(t, err)
+	test.ExpectFailure(t, arm.ImmediateMode())
+
+	test.DemandSuccess(t, arm.env.Prefs.ARM.Immediate.Set(true))
+	test.ExpectFailure(t, arm.ImmediateMode())
+
+	c := arm.state.stretchedCycles
+	arm.Icycle()
+	test.ExpectEquality(t, arm.state.stretchedCycles, c+1)
+
+	// the next run uses the stub functions which do not count cycles
+	_, err = arm.StepInstruction()
+	test.DemandSuccess(t, err)
+	test.ExpectSuccess(t, arm.ImmediateMode())
+
+	c = arm.state.stretchedCycles
+	arm.Icycle()
+	test.ExpectEquality(t, arm.state.stretchedCycles, c)
+}
+
+// testDeveloper is a minimal implementation of the coprocessor.CartCoProcDeveloper
+// interface with a single breakpoint
+type testDeveloper struct {
+	breakpoint uint32
+}
+
+func (_ *testDeveloper) MemoryFault(_ string, _ faults.Category, _ uint32, _ uint32) {}
+func (_ *testDeveloper) HighAddress() uint32                                         { return 0 }
+func (dev *testDeveloper) CheckBreakpoint(addr uint32) bool                          { return addr == dev.breakpoint }
+func (_ *testDeveloper) Profiling() *coprocessor.CartCoProcProfiler                  { return nil }
+func (_ *testDeveloper) StartProfiling()                                             {}
+func (_ *testDeveloper) ProcessProfiling(_ float32)                                  {}
+func (_ *testDeveloper) OnYield(_ uint32, _ coprocessor.CoProcYield)                 {}
+
+func TestBreakpointsEnable(t *testing.T) {
+	arm, mem := newTestARM(t, []uint16{
+		0x2001, // MOV R0, #1
+		0x2102, // MOV R1, #2
+		0x4770, // BX LR
+	})
+
+	origin := mem.mmap.FlashOrigin + testProgramOffset
+	arm.SetDeveloper(&testDeveloper{breakpoint: origin + 2})
+
+	// program runs to completion when breakpoints are disabled
+	arm.BreakpointsEnable(false)
+	yld, _ := arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[1], uint32(2))
+
+	// program halts on the breakpoint when breakpoints are enabled
+	arm.BreakpointsEnable(true)
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldBreakpoint)
+	test.ExpectEquality(t, yld.Error.Error(), fmt.Sprintf("%08x", origin+2))
+}
+
+func TestSeedRegister(t *testing.T) {
+	arm, _ := newTestARM(t, []uint16{
+		0x1840, // ADD R0, R0, R1
+		0x4770, // BX LR
+	})
+
+	// run once so that the program has ended
+	yld, _ := arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+
+	test.ExpectSuccess(t, arm.SeedRegister(0, 5))
+	test.ExpectSuccess(t, arm.SeedRegister(1, 7))
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[0], uint32(12))
+
+	// registers are reset on the next run if they are not seeded
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[0], uint32(0))
+
+	// SP, LR and PC cannot be seeded
+	test.ExpectFailure(t, arm.SeedRegister(rSP, 0))
+	test.ExpectFailure(t, arm.SeedRegister(rLR, 0))
+	test.ExpectFailure(t, arm.SeedRegister(rPC, 0))
+}
+
+func TestStatusFlags(t *testing.T) {
+	arm, _ := newTestARM(t, []uint16{
+		0xd001, // BEQ to the MOV R0, #2 instruction below
+		0x2001, // MOV R0, #1
+		0x4770, // BX LR
+		0x2002, // MOV R0, #2
+		0x4770, // BX LR
+	})
+
+	// branch is not taken when the zero flag is clear
+	yld, _ := arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[0], uint32(1))
+	test.ExpectEquality(t, arm.StatusFlags(), "nzcvq")
+
+	// setting the zero flag causes the branch to be taken
+	test.ExpectSuccess(t, arm.SetStatusFlag("Z", true))
+	test.ExpectEquality(t, arm.StatusFlags(), "nZcvq")
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[0], uint32(2))
+
+	// flags are reset on the next run if they are not set
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[0], uint32(1))
+
+	// flag names are not case sensitive
+	test.ExpectSuccess(t, arm.SetStatusFlag("n", true))
+	test.ExpectSuccess(t, arm.SetStatusFlag("c", true))
+	test.ExpectSuccess(t, arm.SetStatusFlag("v", true))
+	test.ExpectEquality(t, arm.StatusFlags(), "NzCVq")
+	test.ExpectSuccess(t, arm.SetStatusFlag("C", false))
+	test.ExpectEquality(t, arm.StatusFlags(), "NzcVq")
+
+	// the saturation flag and unknown flags cannot be set
+	test.ExpectFailure(t, arm.SetStatusFlag("Q", true))
+	test.ExpectFailure(t, arm.SetStatusFlag("X", true))
+	test.ExpectEquality(t, arm.StatusFlags(), "NzcVq")
+
+	// IT state is not affected by changes to the flags
+	arm.state.status.itCond = 0b0001
+	arm.state.status.itMask = 0b1100
+	test.ExpectSuccess(t, arm.SetStatusFlag("Z", true))
+	test.ExpectEquality(t, arm.state.status.itCond, uint8(0b0001))
+	test.ExpectEquality(t, arm.state.status.itMask, uint8(0b1100))
+}
+
+func TestStrictAlignment(t *testing.T) {
+	program := []uint16{
+		0x2040, // MOV R0, #$40
+		0x0600, // LSL R0, R0, #24
+		0x3001, // ADD R0, #1
+		0x6801, // LDR R1, [R0, #0]
+		0x4770, // BX LR
+	}
+
+	// without strict alignment the misaligned read is made from the aligned
+	// address
+	arm, mem := newTestARM(t, program)
+	copy(mem.sram, []byte{0x11, 0x22, 0x33, 0x44})
+	test.DemandSuccess(t, arm.env.Prefs.ARM.StrictAlignment.Set(false))
+	yld, _ := arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[1], uint32(0x44332211))
+
+	// with strict alignment the word at the aligned address is rotated right
+	// by eight bits for each byte of misalignment
+	arm, mem = newTestARM(t, program)
+	copy(mem.sram, []byte{0x11, 0x22, 0x33, 0x44})
+	test.DemandSuccess(t, arm.env.Prefs.ARM.StrictAlignment.Set(true))
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectEquality(t, arm.state.registers[1], uint32(0x11443322))
+
+	// rotation for the other misaligned addresses
+	test.ExpectEquality(t, arm.read32bit(mem.mmap.SRAMOrigin+2, false), uint32(0x22114433))
+	test.ExpectEquality(t, arm.read32bit(mem.mmap.SRAMOrigin+3, false), uint32(0x33221144))
+
+	// aligned reads and reads that require alignment are not rotated
+	test.ExpectEquality(t, arm.read32bit(mem.mmap.SRAMOrigin, false), uint32(0x44332211))
+	test.ExpectEquality(t, arm.read32bit(mem.mmap.SRAMOrigin+1, true), uint32(0x44332211))
+}
+
+func TestPCOutOfRange(t *testing.T) {
+	program := []uint16{
+		0x2041, // MOV R0, #$41
+		0x0600, // LSL R0, R0, #24
+		0x3001, // ADD R0, #1
+		0x4700, // BX R0
+	}
+
+	// by default a wild jump is a memory fault
+	arm, _ := newTestARM(t, program)
+	yld, _ := arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldMemoryAccessError)
+	test.ExpectEquality(t, yld.Error.Error(), "program memory: does not exist: 41000001 (PC: 00000106)")
+	pc, ok := arm.OutOfRangePC()
+	test.ExpectSuccess(t, ok)
+	test.ExpectEquality(t, pc, uint32(0x41000000))
+
+	// the lenient policy ends the program early
+	arm, _ = newTestARM(t, program)
+	test.DemandSuccess(t, arm.env.Prefs.ARM.PCOutOfRangeIsError.Set(false))
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	test.ExpectSuccess(t, yld.Error == nil)
+	pc, ok = arm.OutOfRangePC()
+	test.ExpectSuccess(t, ok)
+	test.ExpectEquality(t, pc, uint32(0x41000000))
+
+	// a program that stays in range does not record an out of range PC
+	arm, _ = newTestARM(t, []uint16{
+		0x2001, // MOV R0, #1
+		0x4770, // BX LR
+	})
+	yld, _ = arm.Run()
+	test.ExpectEquality(t, yld.Type, coprocessor.YieldProgramEnded)
+	_, ok = arm.OutOfRangePC()
+	test.ExpectFailure(t, ok)
+}
@@ -0,0 +1,64 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+// the maximum number of return addresses recorded in the call stack. if the
+// limit is reached the oldest entries are discarded
+const maxCallStackDepth = 256
+
+// CallStack returns the list of return addresses for the current coprocessor
+// execution. The most recent call is last in the list.
+//
+// The call stack is reconstructed by noting branches that also set the link
+// register (BL and BLX instructions) and branches to a return address already
+// in the call stack.
+func (arm *ARM) CallStack() []uint32 {
+	cs := make([]uint32, len(arm.state.callStack))
+	copy(cs, arm.state.callStack)
+	return cs
+}
+
+// updateCallStack should be called after every complete instruction. the
+// expectedLR argument is the value of the LR register before the instruction
+// was executed
+func (arm *ARM) updateCallStack(expectedLR uint32) {
+	if !arm.state.branchedExecution || arm.state.instruction32bitDecoding {
+		return
+	}
+
+	// a branch that sets the LR register to the address of the next
+	// instruction is a function call
+	if arm.state.registers[rLR] != expectedLR && arm.state.registers[rLR]&0xfffffffe == arm.state.executingPC+2 {
+		if len(arm.state.callStack) >= maxCallStackDepth {
+			arm.state.callStack = arm.state.callStack[1:]
+		}
+		arm.state.callStack = append(arm.state.callStack, arm.state.registers[rLR]&0xfffffffe)
+		return
+	}
+
+	// a branch to a return address in the call stack is a function return.
+	// searching from the top of the stack means that tail calls (which don't
+	// push a return address) and returns from ARM interrupts (which return to
+	// the address in the LR register) are handled correctly. a return to an
+	// address further down the stack unwinds all the intervening calls
+	target := arm.state.registers[rPC] - 2
+	for i := len(arm.state.callStack) - 1; i >= 0; i-- {
+		if arm.state.callStack[i] == target {
+			arm.state.callStack = arm.state.callStack[:i]
+			return
+		}
+	}
+}
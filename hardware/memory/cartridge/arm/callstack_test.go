@@ -0,0 +1,71 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestCallStack(t *testing.T) {
+	// program fills memory with NOP instructions
+	program := make([]uint16, 32)
+	for i := range program {
+		program[i] = 0x46c0
+	}
+
+	// main
+	program[0x00] = 0xf000 // BL funcA
+	program[0x01] = 0xf806
+	program[0x02] = 0xe7fe // B .
+
+	// funcA
+	program[0x08] = 0xb500 // PUSH {LR}
+	program[0x09] = 0xf000 // BL funcB
+	program[0x0a] = 0xf805
+	program[0x0b] = 0xbd00 // POP {PC}
+
+	// funcB
+	program[0x10] = 0xe006 // B funcC (tail call)
+
+	// funcC
+	program[0x18] = 0x4770 // BX LR
+
+	arm, mem := newTestARM(t, program)
+	origin := mem.mmap.FlashOrigin + testProgramOffset
+
+	expected := [][]uint32{
+		{origin + 0x04},                // BL funcA
+		{origin + 0x04},                // PUSH {LR}
+		{origin + 0x04, origin + 0x16}, // BL funcB
+		{origin + 0x04, origin + 0x16}, // B funcC
+		{origin + 0x04},                // BX LR
+		{},                             // POP {PC}
+		{},                             // B .
+	}
+
+	for _, e := range expected {
+		_, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+
+		cs := arm.CallStack()
+		test.DemandEquality(t, len(cs), len(e))
+		for i := range e {
+			test.ExpectEquality(t, cs[i], e[i])
+		}
+	}
+}
@@ -22,6 +22,10 @@ import (
 type cycleOrder struct {
 	queue [20]cycleType
 	idx   int
+
+	// the number of data reads and writes on the bus
+	reads  int
+	writes int
 }
 
 func (q cycleOrder) String() string {
@@ -35,6 +39,8 @@ func (q cycleOrder) String() string {
 
 func (q *cycleOrder) reset() {
 	q.idx = 0
+	q.reads = 0
+	q.writes = 0
 }
 
 func (q cycleOrder) len() int {
@@ -46,6 +52,17 @@ func (q *cycleOrder) add(c cycleType) {
 	q.idx++
 }
 
+// addBusAccess counts the data accesses on the bus. instruction fetches are
+// not counted
+func (q *cycleOrder) addBusAccess(bus busAccess) {
+	switch bus {
+	case dataRead:
+		q.reads++
+	case dataWrite:
+		q.writes++
+	}
+}
+
 // BranchTrail indicates how the BrainTrail buffer was used for a cycle.
 type BranchTrail int
 
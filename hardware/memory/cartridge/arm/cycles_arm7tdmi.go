@@ -44,6 +44,7 @@ func (arm *ARM) sCycle_ARM7TDMI(bus busAccess, addr uint32) {
 
 	if arm.disasm != nil {
 		arm.state.cycleOrder.add(S)
+		arm.state.cycleOrder.addBusAccess(bus)
 	}
 	arm.state.lastCycle = S
 
@@ -114,6 +115,7 @@ func (arm *ARM) nCycle_ARM7TDMI(bus busAccess, addr uint32) {
 
 	if arm.disasm != nil {
 		arm.state.cycleOrder.add(N)
+		arm.state.cycleOrder.addBusAccess(bus)
 	}
 	arm.state.lastCycle = N
 
@@ -25,7 +25,7 @@ func (arm *ARM) iCycle_ARMv7_M() {
 	arm.state.lastCycle = I
 }
 
-func (arm *ARM) sCycle_ARMv7_M(_ busAccess, addr uint32) {
+func (arm *ARM) sCycle_ARMv7_M(bus busAccess, addr uint32) {
 	// comments in cycles_arm7tdmi.go
 
 	if arm.state.lastCycle == I {
@@ -35,6 +35,7 @@ func (arm *ARM) sCycle_ARMv7_M(_ busAccess, addr uint32) {
 
 	if arm.disasm != nil {
 		arm.state.cycleOrder.add(S)
+		arm.state.cycleOrder.addBusAccess(bus)
 	}
 	arm.state.lastCycle = S
 
@@ -46,7 +47,7 @@ func (arm *ARM) sCycle_ARMv7_M(_ busAccess, addr uint32) {
 	arm.state.stretchedCycles += arm.clklenFlash
 }
 
-func (arm *ARM) nCycle_ARMv7_M(_ busAccess, addr uint32) {
+func (arm *ARM) nCycle_ARMv7_M(bus busAccess, addr uint32) {
 	// comments in cycles_arm7tdmi.go
 
 	mclkFlash := 1.0
@@ -59,6 +60,7 @@ func (arm *ARM) nCycle_ARMv7_M(_ busAccess, addr uint32) {
 
 	if arm.disasm != nil {
 		arm.state.cycleOrder.add(N)
+		arm.state.cycleOrder.addBusAccess(bus)
 	}
 	arm.state.lastCycle = N
 
@@ -110,6 +110,47 @@ func StaticDisassemble(config StaticDisassembleConfig) error {
 	return nil
 }
 
+// Peeker is used by PeekDisassemble() to read coprocessor memory. It is a
+// subset of the coprocessor.CartCoProc interface
+type Peeker interface {
+	Peek(addr uint32) (uint32, bool)
+}
+
+// PeekDisassemble disassembles count instructions starting at the specified
+// address. A 32bit instruction counts as a single instruction. Memory is read
+// with the Peek() function and is assumed to be little-endian.
+//
+// Fewer than count entries will be returned if the end of memory is reached.
+func PeekDisassemble(mem Peeker, addr uint32, count int) []DisasmEntry {
+	// instructions are always on a 16bit boundary
+	addr &= 0xfffffffe
+
+	// read enough memory for count 32bit instructions
+	data := make([]byte, 0, count*4)
+	for a := addr; len(data) < cap(data); a += 4 {
+		v, ok := mem.Peek(a)
+		if !ok {
+			break // for loop
+		}
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+
+	entries := make([]DisasmEntry, 0, count)
+
+	_ = StaticDisassemble(StaticDisassembleConfig{
+		Data:      data,
+		Origin:    addr,
+		ByteOrder: binary.LittleEndian,
+		Callback: func(e DisasmEntry) {
+			if len(entries) < count {
+				entries = append(entries, e)
+			}
+		},
+	})
+
+	return entries
+}
+
 // disasmVerbose provides more detail for the disasm entry
 func (arm *ARM) disasmVerbose(entry DisasmEntry) string {
 	var s strings.Builder
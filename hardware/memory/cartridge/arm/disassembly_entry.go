@@ -70,6 +70,11 @@ type DisasmEntry struct {
 	BranchTrail BranchTrail
 	MergedIS    bool
 
+	// the number of data reads and writes performed on the bus by the
+	// instruction. instruction fetches are not counted
+	BusReads  int
+	BusWrites int
+
 	// whether this entry was executed in immediate mode. if this field is true
 	// then the Cycles and "cycle details" fields will be zero
 	ImmediateMode bool
@@ -124,6 +129,8 @@ func (arm *ARM) completeDisasmEntry(e *DisasmEntry, opcode uint16, includeLiveIn
 		e.MAMCR = int(arm.state.mam.mamcr)
 		e.BranchTrail = arm.state.branchTrail
 		e.MergedIS = arm.state.mergedIS
+		e.BusReads = arm.state.cycleOrder.reads
+		e.BusWrites = arm.state.cycleOrder.writes
 		e.ImmediateMode = arm.immediateMode
 	}
 }
@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// testDisassembler implements the coprocessor.CartCoProcDisassembler interface
+type testDisassembler struct {
+	entries []DisasmEntry
+}
+
+func (dsm *testDisassembler) Start() {}
+
+func (dsm *testDisassembler) Step(e coprocessor.CartCoProcDisasmEntry) {
+	dsm.entries = append(dsm.entries, e.(DisasmEntry))
+}
+
+func (dsm *testDisassembler) End(_ coprocessor.CartCoProcDisasmSummary) {}
+
+func TestBusAccessCount(t *testing.T) {
+	arm, _ := newTestARM(t, []uint16{
+		0x2040, // MOV R0, #$40
+		0x0600, // LSL R0, R0, #24
+		0x6801, // LDR R1, [R0]
+		0xc81e, // LDMIA R0!, {R1-R4}
+		0xc006, // STMIA R0!, {R1, R2}
+	})
+
+	// bus accesses are not counted in immediate mode
+	test.DemandSuccess(t, arm.env.Prefs.ARM.Immediate.Set(false))
+
+	dsm := &testDisassembler{}
+	arm.SetDisassembler(dsm)
+
+	for i := 0; i < 5; i++ {
+		_, err := arm.StepInstruction()
+		test.DemandSuccess(t, err)
+	}
+	test.DemandEquality(t, len(dsm.entries), 5)
+
+	// MOV and LSL do not access the bus
+	test.ExpectEquality(t, dsm.entries[0].BusReads, 0)
+	test.ExpectEquality(t, dsm.entries[0].BusWrites, 0)
+	test.ExpectEquality(t, dsm.entries[1].BusReads, 0)
+	test.ExpectEquality(t, dsm.entries[1].BusWrites, 0)
+
+	test.ExpectEquality(t, dsm.entries[2].Operator, "ldr")
+	test.ExpectEquality(t, dsm.entries[2].BusReads, 1)
+	test.ExpectEquality(t, dsm.entries[2].BusWrites, 0)
+
+	// each register loaded by LDMIA is a separate read
+	test.ExpectEquality(t, dsm.entries[3].Operator, "ldmia")
+	test.ExpectEquality(t, dsm.entries[3].BusReads, 4)
+	test.ExpectEquality(t, dsm.entries[3].BusWrites, 0)
+
+	test.ExpectEquality(t, dsm.entries[4].Operator, "stmia")
+	test.ExpectEquality(t, dsm.entries[4].BusReads, 0)
+	test.ExpectEquality(t, dsm.entries[4].BusWrites, 2)
+}
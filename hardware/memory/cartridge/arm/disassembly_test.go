@@ -0,0 +1,61 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestPeekDisassemble(t *testing.T) {
+	arm, mem := newTestARM(t, []uint16{
+		0x2001,         // MOV R0, #1
+		0x1842,         // ADD R2, R0, R1
+		0xf000, 0xf803, // BL
+		0xc81e, // LDMIA R0!, {R1-R4}
+		0x4770, // BX LR
+	})
+
+	origin := mem.mmap.FlashOrigin + testProgramOffset
+
+	entries := PeekDisassemble(arm, origin, 4)
+	test.DemandEquality(t, len(entries), 4)
+
+	expected := []struct {
+		addr     uint32
+		operator string
+		is32bit  bool
+	}{
+		{addr: origin, operator: "mov"},
+		{addr: origin + 2, operator: "add"},
+		{addr: origin + 4, operator: "bl", is32bit: true},
+		{addr: origin + 8, operator: "ldmia"},
+	}
+
+	for i, e := range expected {
+		test.ExpectEquality(t, entries[i].Addr, e.addr)
+		test.ExpectEquality(t, entries[i].Operator, e.operator)
+		test.ExpectEquality(t, entries[i].Is32bit, e.is32bit)
+	}
+
+	// window starting part way through the program. the address is forced to
+	// a 16bit boundary
+	entries = PeekDisassemble(arm, origin+9, 2)
+	test.DemandEquality(t, len(entries), 2)
+	test.ExpectEquality(t, entries[0].Operator, "ldmia")
+	test.ExpectEquality(t, entries[1].Operator, "bx")
+}
@@ -17,9 +17,11 @@ package arm
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/jetsetilly/gopher2600/coprocessor"
 	"github.com/jetsetilly/gopher2600/coprocessor/developer/faults"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 func (arm *ARM) memoryFault(event string, fault faults.Category, addr uint32) {
@@ -52,6 +54,12 @@ func (arm *ARM) misalignedAccess(event string, addr uint32) {
 	}
 }
 
+// strictAlignmentAccess logs a 32bit access to an address that is not word
+// aligned. only called when the StrictAlignment preference is set
+func (arm *ARM) strictAlignmentAccess(event string, addr uint32) {
+	logger.Logf(arm.env, "ARM7", "%s: misaligned address: %08x (PC: %08x)", event, addr, arm.state.instructionPC)
+}
+
 func (arm *ARM) read8bit(addr uint32) uint8 {
 	if addr < arm.mmap.NullAccessBoundary {
 		arm.nullAccess("Read 8bit", addr)
@@ -259,13 +267,37 @@ func (arm *ARM) read32bit(addr uint32, requiresAlignment bool) uint32 {
 	}
 
 	// check 32 bit alignment
-	if (requiresAlignment || !arm.mmap.MisalignedAccesses) && !IsAlignedTo32bits(addr) {
-		arm.misalignedAccess("Read 32bit", addr)
-		if !arm.mmap.MisalignedAccesses {
-			addr = AlignTo32bits(addr)
+	if !IsAlignedTo32bits(addr) {
+		if arm.strictAlignment {
+			arm.strictAlignmentAccess("Read 32bit", addr)
+		}
+
+		if requiresAlignment || !arm.mmap.MisalignedAccesses {
+			arm.misalignedAccess("Read 32bit", addr)
+			if !arm.mmap.MisalignedAccesses {
+				// a single word read from a misaligned address on an
+				// architecture that doesn't allow misaligned accesses is
+				// made from the aligned address. the data is then rotated so
+				// that the addressed byte is in the least significant bits
+				// of the word. multiple word reads are not rotated
+				//
+				// "4.9.1 Single Data Transfer" in "ARM7TDMI-S Technical
+				// Reference Manual"
+				if arm.strictAlignment && !requiresAlignment {
+					rotate := int(addr&0x03) << 3
+					return bits.RotateLeft32(arm.read32bitData(AlignTo32bits(addr)), -rotate)
+				}
+				addr = AlignTo32bits(addr)
+			}
 		}
 	}
 
+	return arm.read32bitData(addr)
+}
+
+// read32bitData reads the 32bit value at the address without any alignment
+// checks
+func (arm *ARM) read32bitData(addr uint32) uint32 {
 	mem, origin := arm.mem.MapAddress(addr, false, false)
 	if mem == nil {
 		if arm.mmap.HasMAM {
@@ -314,10 +346,16 @@ func (arm *ARM) write32bit(addr uint32, val uint32, requiresAlignment bool) {
 	}
 
 	// check 32 bit alignment
-	if (requiresAlignment || !arm.mmap.MisalignedAccesses) && !IsAlignedTo32bits(addr) {
-		arm.misalignedAccess("Write 32bit", addr)
-		if !arm.mmap.MisalignedAccesses {
-			addr = AlignTo32bits(addr)
+	if !IsAlignedTo32bits(addr) {
+		if arm.strictAlignment {
+			arm.strictAlignmentAccess("Write 32bit", addr)
+		}
+
+		if requiresAlignment || !arm.mmap.MisalignedAccesses {
+			arm.misalignedAccess("Write 32bit", addr)
+			if !arm.mmap.MisalignedAccesses {
+				addr = AlignTo32bits(addr)
+			}
 		}
 	}
 
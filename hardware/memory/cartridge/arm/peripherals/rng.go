@@ -16,8 +16,6 @@
 package peripherals
 
 import (
-	"math/rand"
-
 	"github.com/jetsetilly/gopher2600/environment"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm/architecture"
 	"github.com/jetsetilly/gopher2600/logger"
@@ -99,7 +97,7 @@ func (r *RNG) Read(addr uint32) (uint32, bool) {
 		val = 0b1
 	case r.mmap.RNGDR:
 		// data register
-		val = rand.Uint32()
+		val = r.env.Random.NoRewindUint32()
 	default:
 		return 0, false
 	}
@@ -20,11 +20,14 @@ import (
 
 	"github.com/jetsetilly/gopher2600/coprocessor"
 	"github.com/jetsetilly/gopher2600/coprocessor/developer/faults"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 func (arm *ARM) stackProtectCheckSP() {
-	// do nothing if stack has already collided
+	// if the stack has already collided then the only thing to do is to check
+	// whether it has recovered
 	if arm.state.stackHasCollided {
+		arm.stackProtectCheckRecovery()
 		return
 	}
 
@@ -67,6 +70,26 @@ func (arm *ARM) stackProtectCheckSP() {
 	}
 }
 
+// stackProtectCheckRecovery resets the stackHasCollided flag if the stack
+// pointer has risen back above the top of variable memory. this means that
+// stack protection and illegal access checking will resume
+func (arm *ARM) stackProtectCheckRecovery() {
+	stackMemory, stackOrigin := arm.mem.MapAddress(arm.state.registers[rSP], true, false)
+	if stackMemory == nil || stackMemory == arm.state.programMemory {
+		return
+	}
+
+	if arm.state.protectVariableMemTop {
+		_, variableOrigin := arm.mem.MapAddress(arm.state.variableMemtop, true, false)
+		if stackOrigin == variableOrigin && arm.state.registers[rSP] <= arm.state.variableMemtop {
+			return
+		}
+	}
+
+	arm.state.stackHasCollided = false
+	logger.Logf(arm.env, "ARM7", "stack has recovered (SP %08x) from earlier collision", arm.state.registers[rSP])
+}
+
 func (arm *ARM) stackProtectCheckProgramMemory() {
 	if arm.state.stackHasCollided {
 		return
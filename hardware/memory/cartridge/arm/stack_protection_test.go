@@ -0,0 +1,62 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package arm
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/coprocessor"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestStackCollisionRecovery(t *testing.T) {
+	arm, mem := newTestARM(t, nil)
+
+	arm.state.protectVariableMemTop = true
+	arm.state.variableMemtop = mem.mmap.SRAMOrigin + 0x800
+
+	// illegal access is reported before stack collision
+	arm.illegalAccess("test", 0x80000000)
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldMemoryAccessError)
+	arm.resetYield()
+
+	// stack pointer collides with variable memory
+	arm.state.registers[rSP] = arm.state.variableMemtop - 0x100
+	arm.stackProtectCheckSP()
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldStackError)
+	test.ExpectSuccess(t, arm.state.stackHasCollided)
+	arm.resetYield()
+
+	// illegal access is not reported while the stack has collided
+	arm.illegalAccess("test", 0x80000000)
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldRunning)
+
+	// stack pointer is still below the top of variable memory
+	arm.state.registers[rSP] = arm.state.variableMemtop
+	arm.stackProtectCheckSP()
+	test.ExpectSuccess(t, arm.state.stackHasCollided)
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldRunning)
+
+	// stack pointer recovers
+	arm.state.registers[rSP] = arm.state.variableMemtop + 0x100
+	arm.stackProtectCheckSP()
+	test.ExpectFailure(t, arm.state.stackHasCollided)
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldRunning)
+
+	// illegal access is reported once again
+	arm.illegalAccess("test", 0x80000000)
+	test.ExpectEquality(t, arm.state.yield.Type, coprocessor.YieldMemoryAccessError)
+}
@@ -16,6 +16,7 @@
 package arm
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -47,8 +48,13 @@ type status struct {
 }
 
 func (sr status) String() string {
+	return fmt.Sprintf("Status: %s", sr.flags())
+}
+
+// flags returns the condition flags as a string. an upper case letter
+// indicates that the flag is set and a lower case letter that it is clear
+func (sr status) flags() string {
 	s := strings.Builder{}
-	s.WriteString("Status: ")
 
 	if sr.negative {
 		s.WriteRune('N')
@@ -79,6 +85,21 @@ func (sr status) String() string {
 	return s.String()
 }
 
+// setFlag sets or clears the named condition flag. the IT state fields are
+// not affected. unrecognised flags are ignored
+func (sr *status) setFlag(flag string, set bool) {
+	switch strings.ToUpper(flag) {
+	case "N":
+		sr.negative = set
+	case "Z":
+		sr.zero = set
+	case "C":
+		sr.carry = set
+	case "V":
+		sr.overflow = set
+	}
+}
+
 func (sr *status) reset() {
 	sr.negative = false
 	sr.zero = false
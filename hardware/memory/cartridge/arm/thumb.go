@@ -1746,9 +1746,9 @@ func (arm *ARM) decodeThumbMultipleLoadStore(opcode uint16) decodeFunction {
 					// - S cycles on subsequent matches
 					// - fillPipeline() will be called if PC register is matched
 					if numMatches == 1 {
-						arm.Ncycle(dataWrite, addr)
+						arm.Ncycle(dataRead, addr)
 					} else {
-						arm.Scycle(dataWrite, addr)
+						arm.Scycle(dataRead, addr)
 					}
 
 					arm.state.registers[i] = arm.read32bit(addr, true)
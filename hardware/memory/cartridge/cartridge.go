@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
@@ -227,10 +228,20 @@ func (cart *Cartridge) Attach(cartload cartridgeloader.Loader) error {
 	// automatic fingerprinting of cartridge
 	if mapping == "" || mapping == "AUTO" {
 		auto = true
-		mapping, err = cart.fingerprint(cartload)
+		detection, err := cart.fingerprint(cartload)
 		if err != nil {
 			return fmt.Errorf("cartridge: %w", err)
 		}
+		mapping = detection.Mapping
+
+		// make the detection result available through the loader
+		if cartload.Detection != nil {
+			*cartload.Detection = detection
+		}
+
+		if detection.IsUncertain() {
+			logger.Logf(cart.env, "cartridge", "uncertain mapper detection: %s", detection)
+		}
 
 		// reset loader stream after fingerprinting
 		err = cartload.Reset()
@@ -241,7 +252,7 @@ func (cart *Cartridge) Attach(cartload cartridgeloader.Loader) error {
 
 	switch mapping {
 	case unrecognisedMapper:
-		return fmt.Errorf("cartridge: unrecognised mapper")
+		return fmt.Errorf("cartridge: %w", cartridgeloader.UnsupportedMapper)
 	case "2K":
 		cart.mapper, err = newAtari2k(cart.env, cartload)
 	case "4K":
@@ -342,6 +353,9 @@ func (cart *Cartridge) Attach(cartload cartridgeloader.Loader) error {
 
 	case "ELF":
 		cart.mapper, err = elf.NewElf(cart.env, cartload, false)
+
+	default:
+		return fmt.Errorf("cartridge: %w (%s)", cartridgeloader.UnsupportedMapper, mapping)
 	}
 	if err != nil {
 		return fmt.Errorf("cartridge: %w", err)
@@ -409,6 +423,13 @@ func (cart *Cartridge) GetBank(addr uint16) mapper.BankInfo {
 	return bank
 }
 
+// CurrentBank returns the number of the bank currently mapped to the specified
+// address. For most purposes the address will be the current value of the
+// CPU's program counter. A shorter form of GetBank(addr).Number
+func (cart *Cartridge) CurrentBank(addr uint16) int {
+	return cart.GetBank(addr).Number
+}
+
 // SetBank sets the current bank of the cartridge
 func (cart *Cartridge) SetBank(bank string) error {
 	if set, ok := cart.mapper.(mapper.SelectableBank); ok {
@@ -525,6 +546,58 @@ func (cart *Cartridge) GetCartHotspotsBus() mapper.CartHotspotsBus {
 	return nil
 }
 
+// HotspotInfo describes a single hotspot address in the cartridge.
+type HotspotInfo struct {
+	Address     uint16
+	Description string
+}
+
+// Hotspots returns the hotspot addresses reported by the cartridge mapper,
+// sorted by address. The description of each hotspot is the symbol, the action
+// and whether the hotspot is triggered by reading and/or writing the address.
+// Returns nil if the mapper does not report any hotspots.
+func (cart *Cartridge) Hotspots() []HotspotInfo {
+	bus := cart.GetCartHotspotsBus()
+	if bus == nil {
+		return nil
+	}
+
+	read := bus.ReadHotspots()
+	write := bus.WriteHotspots()
+
+	var hotspots []HotspotInfo
+
+	add := func(addr uint16, info mapper.CartHotspotInfo, access string) {
+		hotspots = append(hotspots, HotspotInfo{
+			Address:     addr,
+			Description: fmt.Sprintf("%s %s (%s)", info.Symbol, info.Action, access),
+		})
+	}
+
+	// hotspots that are the same for reading and writing are listed once
+	for addr, r := range read {
+		if w, ok := write[addr]; ok && w == r {
+			add(addr, r, "read/write")
+		} else {
+			add(addr, r, "read")
+		}
+	}
+	for addr, w := range write {
+		if r, ok := read[addr]; !ok || r != w {
+			add(addr, w, "write")
+		}
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Address == hotspots[j].Address {
+			return hotspots[i].Description < hotspots[j].Description
+		}
+		return hotspots[i].Address < hotspots[j].Address
+	})
+
+	return hotspots
+}
+
 // GetCoProcBus returns interface to the coprocessor interface or nil if no
 // coprocessor is available on the cartridge.
 func (cart *Cartridge) GetCoProcBus() coprocessor.CartCoProcBus {
@@ -577,6 +650,14 @@ func (cart *Cartridge) ROMDump() (string, error) {
 	return "", fmt.Errorf("cartridge: %s does not support ROM dumping", cart.mapper.ID())
 }
 
+// ResetCoProc implements the coprocessor.CartCoProcResetter interface.
+func (cart *Cartridge) ResetCoProc() error {
+	if r, ok := cart.mapper.(coprocessor.CartCoProcResetter); ok {
+		return r.ResetCoProc()
+	}
+	return fmt.Errorf("cartridge: %s does not support resetting the coprocessor", cart.mapper.ID())
+}
+
 // SetYieldHook implements the coprocessor.CartCoProcBus interface.
 func (cart *Cartridge) SetYieldHook(hook coprocessor.CartYieldHook) {
 	if cart.hasCoProcBus {
@@ -0,0 +1,269 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cartridge_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestCurrentBank(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// 8k cartridge with the F8 bankswitching scheme
+	cartload, err := cartridgeloader.NewLoaderFromData("currentbank", make([]byte, 8192), "F8", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	cart := vcs.Mem.Cart
+	test.ExpectEquality(t, cart.NumBanks(), 2)
+
+	// explicit bank setting
+	test.DemandSuccess(t, cart.SetBank("0"))
+	test.ExpectEquality(t, cart.CurrentBank(0xf000), 0)
+	test.DemandSuccess(t, cart.SetBank("1"))
+	test.ExpectEquality(t, cart.CurrentBank(0xf000), 1)
+
+	// bankswitching by accessing the hotspots
+	_, err = vcs.Mem.Read(0x1ff8)
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, cart.CurrentBank(0xf000), 0)
+
+	_, err = vcs.Mem.Read(0x1ff9)
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, cart.CurrentBank(0xf000), 1)
+
+	// the current bank is the same as reported by GetBank()
+	test.ExpectEquality(t, cart.CurrentBank(0xf000), cart.GetBank(0xf000).Number)
+}
+
+func TestHotspots(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// 8k cartridge with the F8 bankswitching scheme
+	cartload, err := cartridgeloader.NewLoaderFromData("hotspots", make([]byte, 8192), "F8", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	hotspots := vcs.Mem.Cart.Hotspots()
+	test.ExpectEquality(t, len(hotspots), 2)
+	test.ExpectEquality(t, hotspots[0], cartridge.HotspotInfo{Address: 0x1ff8, Description: "BANK0 bankswitch (read/write)"})
+	test.ExpectEquality(t, hotspots[1], cartridge.HotspotInfo{Address: 0x1ff9, Description: "BANK1 bankswitch (read/write)"})
+
+	// 4k cartridges have no hotspots
+	cartload, err = cartridgeloader.NewLoaderFromData("hotspots", make([]byte, 4096), "4K", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.ExpectEquality(t, len(vcs.Mem.Cart.Hotspots()), 0)
+}
+
+func TestAttachErrors(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+	cart := vcs.Mem.Cart
+
+	attach := func(data []byte, mapping string) error {
+		t.Helper()
+		cartload, err := cartridgeloader.NewLoaderFromData("errors", data, mapping, "", nil)
+		test.DemandSuccess(t, err)
+		return cart.Attach(cartload)
+	}
+
+	// too few bytes for the requested mapper
+	err = attach(make([]byte, 100), "4K")
+	test.ExpectSuccess(t, errors.Is(err, cartridgeloader.WrongROMSize))
+
+	err = attach(make([]byte, 100), "F8")
+	test.ExpectSuccess(t, errors.Is(err, cartridgeloader.WrongROMSize))
+
+	// size that can't be fingerprinted
+	err = attach(make([]byte, 5000), "AUTO")
+	test.ExpectSuccess(t, errors.Is(err, cartridgeloader.UnsupportedMapper))
+
+	// unknown mapper
+	err = attach(make([]byte, 4096), "XYZ")
+	test.ExpectSuccess(t, errors.Is(err, cartridgeloader.UnsupportedMapper))
+
+	// the ELF mapper reads the data from the file
+	fn := filepath.Join(t.TempDir(), "bogus.elf")
+	test.DemandSuccess(t, os.WriteFile(fn, []byte("\x7fELF this is not really an ELF file"), 0644))
+
+	cartload, err := cartridgeloader.NewLoaderFromFilename(fn, "ELF", "", nil)
+	test.DemandSuccess(t, err)
+	err = cart.Attach(cartload)
+	test.ExpectSuccess(t, errors.Is(err, cartridgeloader.MalformedELF))
+	test.ExpectFailure(t, errors.Is(err, cartridgeloader.WrongROMSize))
+
+	// a correctly sized ROM attaches without error
+	err = attach(make([]byte, 4096), "4K")
+	test.ExpectSuccess(t, err)
+}
+
+func TestDetection(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// a 4k cartridge can only be one thing
+	cartload, err := cartridgeloader.NewLoaderFromData("certain", make([]byte, 4096), "AUTO", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.ExpectEquality(t, cartload.Detection.Mapping, "4K")
+	test.ExpectEquality(t, cartload.Detection.Confidence, 100)
+	test.ExpectEquality(t, len(cartload.Detection.Candidates), 1)
+	test.ExpectEquality(t, cartload.Detection.IsUncertain(), false)
+
+	// an 8k cartridge containing the fingerprints for both the parker bros and
+	// UA mappers
+	data := make([]byte, 8192)
+	copy(data[0x100:], []byte{0x8d, 0xe0, 0x1f}) // STA $1FE0
+	copy(data[0x200:], []byte{0x8d, 0x40, 0x02}) // STA $240
+
+	cartload, err = cartridgeloader.NewLoaderFromData("ambiguous", data, "AUTO", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.ExpectEquality(t, vcs.Mem.Cart.ID(), "E0")
+
+	d := cartload.Detection
+	test.ExpectEquality(t, d.Mapping, "E0")
+	test.ExpectEquality(t, len(d.Candidates), 3)
+	test.ExpectEquality(t, d.Candidates[0].Mapping, "E0")
+	test.ExpectEquality(t, d.Candidates[1].Mapping, "UA")
+	test.ExpectEquality(t, d.Candidates[2].Mapping, "F8")
+	test.ExpectInequality(t, d.Candidates[0].Score, d.Candidates[1].Score)
+	test.ExpectInequality(t, d.Candidates[1].Score, d.Candidates[2].Score)
+	test.ExpectEquality(t, d.IsUncertain(), true)
+
+	// an 8k cartridge with no fingerprints at all is chosen only because it is
+	// the fallback mapper for that size
+	cartload, err = cartridgeloader.NewLoaderFromData("fallback", make([]byte, 8192), "AUTO", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	d = cartload.Detection
+	test.ExpectEquality(t, d.Mapping, "F8")
+	test.ExpectEquality(t, len(d.Candidates), 1)
+	test.ExpectEquality(t, d.Confidence < 20, true)
+	test.ExpectEquality(t, d.IsUncertain(), true)
+
+	// a 32k cartridge with a definitive FA2 fingerprint is not made less
+	// certain by the presence of the weaker tigervision fingerprint
+	data = make([]byte, 32768)
+	copy(data[0x20:], []byte{0x1e, 0xab, 0xad, 0x10})
+	for i := 0; i < 10; i++ {
+		copy(data[0x400+i*2:], []byte{0x85, 0x3f}) // STA $3F
+	}
+
+	cartload, err = cartridgeloader.NewLoaderFromData("definitive", data, "AUTO", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	d = cartload.Detection
+	test.ExpectEquality(t, d.Mapping, "FA2")
+	test.ExpectEquality(t, d.Confidence, 100)
+	test.ExpectEquality(t, len(d.Candidates), 3)
+	test.ExpectEquality(t, d.Candidates[1].Mapping, "3F")
+	test.ExpectEquality(t, d.IsUncertain(), false)
+}
+
+func TestDiff(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// 8k cartridge with the F8 bankswitching scheme
+	data := make([]byte, 8192)
+	for i := range data {
+		data[i] = uint8(i)
+	}
+
+	cartload, err := cartridgeloader.NewLoaderFromData("diff", data, "F8", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	dir := t.TempDir()
+
+	// no differences with the original data
+	fn := filepath.Join(dir, "original.bin")
+	test.DemandSuccess(t, os.WriteFile(fn, data, 0644))
+
+	diff, err := vcs.Mem.Cart.Diff(fn)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, len(diff), 0)
+
+	// patch one byte in the second bank
+	patched := make([]byte, len(data))
+	copy(patched, data)
+	patched[4096+0x123] = 0xff
+
+	fn = filepath.Join(dir, "patched.bin")
+	test.DemandSuccess(t, os.WriteFile(fn, patched, 0644))
+
+	diff, err = vcs.Mem.Cart.Diff(fn)
+	test.ExpectSuccess(t, err)
+	test.DemandEquality(t, len(diff), 1)
+	test.ExpectEquality(t, diff[0].Bank, 1)
+	test.ExpectEquality(t, diff[0].Offset, 0x123)
+	test.ExpectEquality(t, diff[0].Cart, uint8(0x23))
+	test.ExpectEquality(t, diff[0].ROM, uint8(0xff))
+
+	// ROM file of a different size
+	fn = filepath.Join(dir, "short.bin")
+	test.DemandSuccess(t, os.WriteFile(fn, data[:4096], 0644))
+
+	_, err = vcs.Mem.Cart.Diff(fn)
+	test.ExpectFailure(t, err)
+}
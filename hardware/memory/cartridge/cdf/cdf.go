@@ -92,7 +92,7 @@ func NewCDF(env *environment.Environment, loader cartridgeloader.Loader, version
 
 	// size check
 	if cart.NumBanks()*cart.bankSize > loader.Size() {
-		return nil, fmt.Errorf("CDF: not enough bytes in cartridge data")
+		return nil, fmt.Errorf("CDF: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.version, err = newVersion(env.Prefs.ARM.Model.Get().(string), version, data)
@@ -401,6 +401,12 @@ func (cart *cdf) AccessVolatile(addr uint16, data uint8, poke bool) error {
 				}
 				runArm()
 			}
+
+			// let the yield hook know that the program has ended. the response
+			// is unimportant because there is nothing more to execute
+			if cart.state.yield.Type == coprocessor.YieldProgramEnded {
+				_ = cart.yieldHook.CartYield(cart.state.yield)
+			}
 		}
 
 	default:
@@ -713,6 +719,15 @@ func (cart *cdf) GetCoProc() coprocessor.CartCoProc {
 	return cart.arm
 }
 
+// ResetCoProc implements the coprocessor.CartCoProcResetter interface.
+func (cart *cdf) ResetCoProc() error {
+	if cart.state.callfn.IsActive() {
+		return fmt.Errorf("%s: coprocessor is running", cart.mappingID)
+	}
+	cart.arm.ResetRegisters()
+	return nil
+}
+
 // SetYieldHook implements the coprocessor.CartCoProcBus interface.
 func (cart *cdf) SetYieldHook(hook coprocessor.CartYieldHook) {
 	cart.yieldHook = hook
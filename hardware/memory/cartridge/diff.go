@@ -0,0 +1,94 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cartridge
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+)
+
+// BankDifference is a single byte that differs between a cartridge bank and
+// the equivalent byte in a ROM file.
+type BankDifference struct {
+	Bank   int
+	Offset int
+	Cart   uint8
+	ROM    uint8
+}
+
+func (d BankDifference) String() string {
+	return fmt.Sprintf("bank %d: offset %#04x: %#02x -> %#02x", d.Bank, d.Offset, d.Cart, d.ROM)
+}
+
+// DiffBanks compares the data in each bank with the ROM data. The ROM data is
+// expected to be laid out as the banks are, one after the other in the order
+// returned by CopyBanks(). The Offset field of each BankDifference is relative
+// to the start of the bank.
+//
+// Returns an error if the size of the ROM data is not the same as the
+// combined size of the banks.
+func DiffBanks(banks []mapper.BankContent, rom []uint8) ([]BankDifference, error) {
+	var size int
+	for _, b := range banks {
+		size += len(b.Data)
+	}
+	if size != len(rom) {
+		return nil, fmt.Errorf("size of ROM (%d bytes) does not match size of cartridge banks (%d bytes)", len(rom), size)
+	}
+
+	var diff []BankDifference
+
+	var base int
+	for _, b := range banks {
+		for i, v := range b.Data {
+			if v != rom[base+i] {
+				diff = append(diff, BankDifference{
+					Bank:   b.Number,
+					Offset: i,
+					Cart:   v,
+					ROM:    rom[base+i],
+				})
+			}
+		}
+		base += len(b.Data)
+	}
+
+	return diff, nil
+}
+
+// Diff compares the banks of the inserted cartridge with the ROM file. The
+// comparison is with the current contents of the cartridge so it is useful for
+// checking changes made with the POKE or PATCH commands.
+func (cart *Cartridge) Diff(filename string) ([]BankDifference, error) {
+	banks, err := cart.CopyBanks()
+	if err != nil {
+		return nil, fmt.Errorf("cartridge: diff: %w", err)
+	}
+
+	rom, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cartridge: diff: %w", err)
+	}
+
+	diff, err := DiffBanks(banks, rom)
+	if err != nil {
+		return nil, fmt.Errorf("cartridge: diff: %w", err)
+	}
+
+	return diff, nil
+}
@@ -105,7 +105,7 @@ func NewDPCplus(env *environment.Environment, loader cartridgeloader.Loader) (ma
 
 	// size check
 	if bankLen <= 0 || bankLen%cart.bankSize != 0 {
-		return nil, fmt.Errorf("DPC+: wrong number of bytes in cartridge data")
+		return nil, fmt.Errorf("DPC+: %w", cartridgeloader.WrongROMSize)
 	}
 
 	// allocate enough banks
@@ -552,6 +552,12 @@ func (cart *dpcPlus) AccessVolatile(addr uint16, data uint8, poke bool) error {
 				}
 				runArm()
 			}
+
+			// let the yield hook know that the program has ended. the response
+			// is unimportant because there is nothing more to execute
+			if cart.state.yield.Type == coprocessor.YieldProgramEnded {
+				_ = cart.yieldHook.CartYield(cart.state.yield)
+			}
 		}
 
 	// reserved
@@ -991,6 +997,15 @@ func (cart *dpcPlus) GetCoProc() coprocessor.CartCoProc {
 	return cart.arm
 }
 
+// ResetCoProc implements the coprocessor.CartCoProcResetter interface.
+func (cart *dpcPlus) ResetCoProc() error {
+	if cart.state.callfn.IsActive() {
+		return fmt.Errorf("DPC+: coprocessor is running")
+	}
+	cart.arm.ResetRegisters()
+	return nil
+}
+
 // SetYieldHook implements the coprocessor.CartCoProcBus interface.
 func (cart *dpcPlus) SetYieldHook(hook coprocessor.CartYieldHook) {
 	cart.yieldHook = hook
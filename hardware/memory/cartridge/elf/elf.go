@@ -101,7 +101,7 @@ func NewElf(env *environment.Environment, loader cartridgeloader.Loader, inACE b
 	// offset is stored at initial offset 0x28
 	if inACE {
 		if len(r.data) < 0x30 {
-			return nil, fmt.Errorf("ELF: this doesn't look like ELF data embedded in an ACE file")
+			return nil, fmt.Errorf("ELF: %w: this doesn't look like ELF data embedded in an ACE file", cartridgeloader.MalformedELF)
 		}
 		r.offset = int64(r.data[0x28]) | (int64(r.data[0x29]) << 8)
 	}
@@ -109,29 +109,29 @@ func NewElf(env *environment.Environment, loader cartridgeloader.Loader, inACE b
 	// ELF file is read via our elfReaderAt instance
 	ef, err := elf.NewFile(r)
 	if err != nil {
-		return nil, fmt.Errorf("ELF: %w", err)
+		return nil, fmt.Errorf("ELF: %w: %w", cartridgeloader.MalformedELF, err)
 	}
 	defer ef.Close()
 
 	// keeping things simple. only 32bit ELF files supported
 	if ef.Class != elf.ELFCLASS32 {
-		return nil, fmt.Errorf("ELF: only 32bit ELF files are supported")
+		return nil, fmt.Errorf("ELF: %w: only 32bit ELF files are supported", cartridgeloader.MalformedELF)
 	}
 
 	// sanity checks on ELF data
 	if ef.FileHeader.Machine != elf.EM_ARM {
-		return nil, fmt.Errorf("ELF: is not ARM")
+		return nil, fmt.Errorf("ELF: %w: is not ARM", cartridgeloader.MalformedELF)
 	}
 	if ef.FileHeader.Version != elf.EV_CURRENT {
-		return nil, fmt.Errorf("ELF: unknown version")
+		return nil, fmt.Errorf("ELF: %w: unknown version", cartridgeloader.MalformedELF)
 	}
 	if ef.FileHeader.Type != elf.ET_REL {
-		return nil, fmt.Errorf("ELF: is not relocatable")
+		return nil, fmt.Errorf("ELF: %w: is not relocatable", cartridgeloader.MalformedELF)
 	}
 
 	// big endian byte order is probably fine but we've not tested it
 	if ef.FileHeader.ByteOrder != binary.LittleEndian {
-		return nil, fmt.Errorf("ELF: is not little-endian")
+		return nil, fmt.Errorf("ELF: %w: is not little-endian", cartridgeloader.MalformedELF)
 	}
 
 	cart := &Elf{
@@ -1032,6 +1032,16 @@ func (m *elfMemory) Read8bit(addr uint32) (uint8, bool) {
 	return (*mem)[addr], true
 }
 
+// staticByteOrder returns the byte order to use when reading and writing
+// static memory. defaults to little-endian if the byte order has not been set
+// by decoding an ELF file
+func (m *elfMemory) staticByteOrder() binary.ByteOrder {
+	if m.byteOrder == nil {
+		return binary.LittleEndian
+	}
+	return m.byteOrder
+}
+
 // Read16bit implements the mapper.CartStatic interface
 func (m *elfMemory) Read16bit(addr uint32) (uint16, bool) {
 	mem, origin := m.mapAddress(addr, false)
@@ -1039,8 +1049,7 @@ func (m *elfMemory) Read16bit(addr uint32) (uint16, bool) {
 	if mem == nil || len(*mem) < 2 || addr >= uint32(len(*mem)-1) {
 		return 0, false
 	}
-	return uint16((*mem)[addr]) |
-		uint16((*mem)[addr+1])<<8, true
+	return m.staticByteOrder().Uint16((*mem)[addr:]), true
 }
 
 // Read32bit implements the mapper.CartStatic interface
@@ -1050,8 +1059,5 @@ func (m *elfMemory) Read32bit(addr uint32) (uint32, bool) {
 	if mem == nil || len(*mem) < 4 || addr >= uint32(len(*mem)-3) {
 		return 0, false
 	}
-	return uint32((*mem)[addr]) |
-		uint32((*mem)[addr+1])<<8 |
-		uint32((*mem)[addr+2])<<16 |
-		uint32((*mem)[addr+3])<<24, true
+	return m.staticByteOrder().Uint32((*mem)[addr:]), true
 }
@@ -0,0 +1,67 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package elf
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func newTestElfMemory(t *testing.T) *elfMemory {
+	t.Helper()
+
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+
+	env, err := environment.NewEnvironment(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	return newElfMemory(env)
+}
+
+func TestStaticByteOrder(t *testing.T) {
+	mem := newTestElfMemory(t)
+
+	// big-endian fixture in SRAM
+	data := mem.sram.Data()
+	copy(*data, []byte{0x12, 0x34, 0x56, 0x78})
+
+	// byte order defaults to little-endian
+	v, ok := mem.Read32bit(mem.sramOrigin)
+	test.ExpectSuccess(t, ok)
+	test.ExpectEquality(t, v, uint32(0x78563412))
+
+	// static byte order follows the byte order of the ELF
+	mem.byteOrder = binary.BigEndian
+	v, ok = mem.Read32bit(mem.sramOrigin)
+	test.ExpectSuccess(t, ok)
+	test.ExpectEquality(t, v, uint32(0x12345678))
+
+	w, ok := mem.Read16bit(mem.sramOrigin)
+	test.ExpectSuccess(t, ok)
+	test.ExpectEquality(t, w, uint16(0x1234))
+
+	// reading from outside of memory
+	_, ok = mem.Read32bit(0xfffffff0)
+	test.ExpectFailure(t, ok)
+}
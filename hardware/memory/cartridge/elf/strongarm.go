@@ -17,6 +17,7 @@ package elf
 
 import (
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/arm"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 	"github.com/jetsetilly/gopher2600/logger"
 )
@@ -287,6 +288,10 @@ func vcsStartOverblank(mem *elfMemory) {
 	case 3:
 		if mem.yieldDataBus(uint16(0x0080)) {
 			mem.endStrongArmFunction()
+
+			// the start of overblank indicates that the ARM program has
+			// finished with the visible part of the frame
+			mapper.SignalFrameReady(mem.env)
 		}
 	}
 }
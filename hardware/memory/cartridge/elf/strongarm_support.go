@@ -15,15 +15,11 @@
 
 package elf
 
-import (
-	"math/rand"
-)
-
 // these functions should be executed with runStrongArmFunction() and not
 // setStrongArmFunction()
 
 func randint(mem *elfMemory) {
-	_ = mem.arm.RegisterSet(0, rand.Uint32())
+	_ = mem.arm.RegisterSet(0, mem.env.Random.NoRewindUint32())
 }
 
 func memset(mem *elfMemory) {
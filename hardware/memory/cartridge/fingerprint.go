@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
 )
@@ -395,96 +396,184 @@ func fingerprintSB(loader cartridgeloader.Loader) bool {
 	return false
 }
 
-func fingerprint8k(loader cartridgeloader.Loader) string {
+// scores for the candidates in the fingerprint8k(), fingerprint16k(), etc.
+// functions. the score reflects how reliable the fingerprint for the mapper is.
+// more reliable fingerprints are checked first and so the order of the checks
+// in those functions matches the order of the scores
+const (
+	scoreCertain     = 100
+	scoreTigervision = 90
+	scoreParkerBros  = 80
+	scoreMnetwork    = 70
+	scoreJANE        = 60
+	scoreWickstead   = 60
+	scoreSB          = 60
+	scoreSCABS       = 50
+	scoreUA          = 40
+
+	// the score for the mapper that is chosen if no other mapper is recognised
+	scoreFallback = 10
+)
+
+// candidates is used to build the list of possible mappers for the cartridge
+// data. candidates should be added in order of preference, which should be the
+// same as the order of score
+type candidates []cartridgeloader.Candidate
+
+func (c *candidates) add(mapping string, score int) {
+	*c = append(*c, cartridgeloader.Candidate{Mapping: mapping, Score: score})
+}
+
+// detection returns the Detection for the list of candidates. the chosen
+// mapper is the first candidate in the list.
+//
+// the confidence is the score of the chosen mapper, which is a measure of how
+// much evidence there is for it. a mapper that has been recognised with
+// certainty always has a confidence of 100. otherwise, the confidence is
+// reduced by half the score of the next best candidate, if that candidate was
+// also recognised by its fingerprint. a mapper that has been chosen only
+// because nothing else was recognised has the low confidence of the fallback
+// score
+func (c candidates) detection() cartridgeloader.Detection {
+	if len(c) == 0 {
+		return certain(unrecognisedMapper)
+	}
+
+	sorted := slices.Clone(c)
+	slices.SortStableFunc(sorted, func(a, b cartridgeloader.Candidate) int {
+		return b.Score - a.Score
+	})
+
+	confidence := c[0].Score
+	if confidence < scoreCertain && len(c) > 1 && c[1].Score > scoreFallback {
+		confidence -= c[1].Score / 2
+	}
+
+	return cartridgeloader.Detection{
+		Mapping:    c[0].Mapping,
+		Confidence: confidence,
+		Candidates: sorted,
+	}
+}
+
+// certain returns a Detection for a mapper that has been recognised without
+// any doubt
+func certain(mapping string) cartridgeloader.Detection {
+	return cartridgeloader.Detection{
+		Mapping:    mapping,
+		Confidence: scoreCertain,
+		Candidates: []cartridgeloader.Candidate{{Mapping: mapping, Score: scoreCertain}},
+	}
+}
+
+func fingerprint8k(loader cartridgeloader.Loader) cartridgeloader.Detection {
+	var c candidates
+
 	if fingerprintWF8(loader) {
-		return "WF8"
+		c.add("WF8", scoreCertain)
 	}
 
 	if fingerprintTigervision(loader) {
-		return "3F"
+		c.add("3F", scoreTigervision)
 	}
 
 	if fingerprintParkerBros(loader) {
-		return "E0"
+		c.add("E0", scoreParkerBros)
 	}
 
 	// mnetwork has the lowest threshold so place it at the end
 	if fingerprintMnetwork(loader) {
-		return "E7"
+		c.add("E7", scoreMnetwork)
 	}
 
 	if fingerprintWickstead(loader) {
-		return "WD"
+		c.add("WD", scoreWickstead)
 	}
 
 	if fingerprintSCABS(loader) {
-		return "FE"
+		c.add("FE", scoreSCABS)
 	}
 
 	if fingerprintUA(loader) {
-		return "UA"
+		c.add("UA", scoreUA)
 	}
 
-	return "F8"
+	c.add("F8", scoreFallback)
+
+	return c.detection()
 }
 
-func fingerprint16k(loader cartridgeloader.Loader) string {
+func fingerprint16k(loader cartridgeloader.Loader) cartridgeloader.Detection {
+	var c candidates
+
 	if fingerprintTigervision(loader) {
-		return "3F"
+		c.add("3F", scoreTigervision)
 	}
 
 	if fingerprintMnetwork(loader) {
-		return "E7"
+		c.add("E7", scoreMnetwork)
 	}
 
 	if fingerprintJANE(loader) {
-		return "JANE"
+		c.add("JANE", scoreJANE)
 	}
 
-	return "F6"
+	c.add("F6", scoreFallback)
+
+	return c.detection()
 }
 
-func fingerprint32k(loader cartridgeloader.Loader) string {
+func fingerprint32k(loader cartridgeloader.Loader) cartridgeloader.Detection {
+	var c candidates
+
 	if fingerprintFA2(loader) {
-		return "FA2"
+		c.add("FA2", scoreCertain)
 	}
+
 	if fingerprintTigervision(loader) {
-		return "3F"
+		c.add("3F", scoreTigervision)
 	}
-	return "F4"
+
+	c.add("F4", scoreFallback)
+
+	return c.detection()
 }
 
-func fingerprint64k(loader cartridgeloader.Loader) string {
+func fingerprint64k(loader cartridgeloader.Loader) cartridgeloader.Detection {
 	if sc, ok := fingerprintEF(loader); ok {
 		if sc {
-			return "EFSC"
+			return certain("EFSC")
 		}
-		return "EF"
+		return certain("EF")
 	}
-	return unrecognisedMapper
+	return certain(unrecognisedMapper)
 }
 
-func fingerprint128k(loader cartridgeloader.Loader) string {
+func fingerprint128k(loader cartridgeloader.Loader) cartridgeloader.Detection {
+	var c candidates
+
 	if fingerprintDF(loader) {
-		return "DF"
+		c.add("DF", scoreCertain)
 	}
+
 	if fingerprintSB(loader) {
-		return "SB"
+		c.add("SB", scoreSB)
 	}
-	return unrecognisedMapper
+
+	return c.detection()
 }
 
-func fingerprint256k(loader cartridgeloader.Loader) string {
+func fingerprint256k(loader cartridgeloader.Loader) cartridgeloader.Detection {
 	if sc, ok := fingerprintBF(loader); ok {
 		if sc {
-			return "BFSC"
+			return certain("BFSC")
 		}
-		return "BF"
+		return certain("BF")
 	}
 	if fingerprintSB(loader) {
-		return "SB"
+		return certain("SB")
 	}
-	return unrecognisedMapper
+	return certain(unrecognisedMapper)
 }
 
 // returned by fingerprint if the mapper is not recognised. most files will
@@ -493,45 +582,45 @@ func fingerprint256k(loader cartridgeloader.Loader) string {
 // certain whether or nor a file is a valid ROM file
 const unrecognisedMapper = "unrecognised mapper"
 
-func (cart *Cartridge) fingerprint(loader cartridgeloader.Loader) (string, error) {
+func (cart *Cartridge) fingerprint(loader cartridgeloader.Loader) (cartridgeloader.Detection, error) {
 	// moviecart fingerprinting is done in cartridge loader. this is to avoid
 	// loading the entire file into memory, which we definitely don't want to do
 	// with moviecart files due to the large size
 
 	if ok := fingerprintElf(loader, false); ok {
-		return "ELF", nil
+		return certain("ELF"), nil
 	}
 
 	if ok, wrappedElf := fingerprintAce(loader); ok {
 		if wrappedElf {
-			return "ACE_wrapped_ELF", nil
+			return certain("ACE_wrapped_ELF"), nil
 		}
-		return "ACE", nil
+		return certain("ACE"), nil
 	}
 
 	if ok, version := fingerprintCDF(loader); ok {
-		return version, nil
+		return certain(version), nil
 	}
 
 	if fingerprintDPCplus(loader) {
-		return "DPC+", nil
+		return certain("DPC+"), nil
 	}
 
 	if fingerprintSuperchargerFastLoad(loader) {
-		return "AR", nil
+		return certain("AR"), nil
 	}
 
 	if fingerprint3ePlus(loader) {
-		return "3E+", nil
+		return certain("3E+"), nil
 	}
 
 	if fingerprint3e(loader) {
-		return "3E", nil
+		return certain("3E"), nil
 	}
 
 	switch loader.Size() {
 	case 4096:
-		return "4K", nil
+		return certain("4K"), nil
 
 	case 8195:
 		// a widely distributed bad ROM dump of the Pink Panther prototype is
@@ -547,19 +636,19 @@ func (cart *Cartridge) fingerprint(loader cartridgeloader.Loader) (string, error
 		fallthrough
 
 	case 10495:
-		return "DPC", nil
+		return certain("DPC"), nil
 
 	case 12288:
-		return "FA", nil
+		return certain("FA"), nil
 
 	case 16384:
 		return fingerprint16k(loader), nil
 
 	case 24576:
-		return "FA2", nil
+		return certain("FA2"), nil
 
 	case 28672:
-		return "FA2", nil
+		return certain("FA2"), nil
 
 	case 32768:
 		return fingerprint32k(loader), nil
@@ -575,7 +664,7 @@ func (cart *Cartridge) fingerprint(loader cartridgeloader.Loader) (string, error
 	}
 
 	if loader.Size() >= 4096 {
-		return "", fmt.Errorf("unrecognised size (%d bytes)", loader.Size())
+		return cartridgeloader.Detection{}, fmt.Errorf("%w: unrecognised size (%d bytes)", cartridgeloader.UnsupportedMapper, loader.Size())
 	}
-	return "2K", nil
+	return certain("2K"), nil
 }
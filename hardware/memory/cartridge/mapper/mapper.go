@@ -152,11 +152,17 @@ type CartRAMbus interface {
 // addressable, it is also possible to update cartridge RAM through the normal
 // memory buses; although in the context of a debugger it is probably more
 // convience to use PutRAM() in the CartRAMbus interface.
+//
+// Many cartridges have separate read and write ports for RAM. The WriteOrigin
+// field specifies the address of the lowest byte of the write port in those
+// cases. If the field is zero then RAM is written through the same addresses
+// as it is read.
 type CartRAM struct {
-	Label  string
-	Origin uint16
-	Data   []uint8
-	Mapped bool
+	Label       string
+	Origin      uint16
+	WriteOrigin uint16
+	Data        []uint8
+	Mapped      bool
 }
 
 // CartRegistersBus defines the operations required for a debugger to access
@@ -263,6 +269,22 @@ type CartStatic interface {
 	Read32bit(addr uint32) (uint32, bool)
 }
 
+// CartFrameReadyHook is implemented by the television. Cartridge mappers with a
+// coprocessor can use it to signal that the coprocessor has completed the work
+// for a logical frame. See the SignalFrameReady() function.
+type CartFrameReadyHook interface {
+	CoProcFrameReady()
+}
+
+// SignalFrameReady signals to the television in the environment that the
+// coprocessor has completed the work for a logical frame. Does nothing if the
+// television does not implement the CartFrameReadyHook interface.
+func SignalFrameReady(env *environment.Environment) {
+	if hook, ok := env.TV.(CartFrameReadyHook); ok {
+		hook.CoProcFrameReady()
+	}
+}
+
 // CartTapeBus defines additional debugging functions for cartridge types that use tapes.
 type CartTapeBus interface {
 	// Move tape loading to the beginning of the tape
@@ -341,6 +363,20 @@ const (
 	HotspotReserved
 )
 
+func (act CartHotspotAction) String() string {
+	switch act {
+	case HotspotBankSwitch:
+		return "bankswitch"
+	case HotspotRegister:
+		return "register"
+	case HotspotFunction:
+		return "function"
+	case HotspotReserved:
+		return "reserved"
+	}
+	return "unknown"
+}
+
 // HotspotInfo details the name and purpose of hotspot address.
 type CartHotspotInfo struct {
 	Symbol string
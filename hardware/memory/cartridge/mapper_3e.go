@@ -56,7 +56,7 @@ func new3e(env *environment.Environment, loader cartridgeloader.Loader) (mapper.
 	}
 
 	if len(data)%cart.bankSize != 0 {
-		return nil, fmt.Errorf("3E: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("3E: %w", cartridgeloader.WrongROMSize)
 	}
 
 	numBanks := len(data) / cart.bankSize
@@ -281,6 +281,11 @@ func (cart *m3e) GetRAM() []mapper.CartRAM {
 			Data:   make([]uint8, len(cart.state.ram[i])),
 			Mapped: mapped,
 		}
+
+		// the write port follows the read port
+		if mapped {
+			r[i].WriteOrigin = origin + uint16(len(cart.state.ram[i]))
+		}
 		copy(r[i].Data, cart.state.ram[i])
 	}
 
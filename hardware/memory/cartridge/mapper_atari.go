@@ -103,6 +103,13 @@ func hasEmptyArea(d []uint8) bool {
 	//
 	// for example: the Fatal Run (NTSC) ROM uses FF rather than 00 to fill the
 	// empty space.
+	//
+	// data that is too small to contain the superchip RAM is never deemed to
+	// have an empty area. the size of the data is checked by the mapper
+	if len(d) < superchipRAMsize {
+		return false
+	}
+
 	b := d[0]
 	for i := 1; i < superchipRAMsize; i++ {
 		if d[i] != b {
@@ -311,10 +318,11 @@ func (cart *atari) GetRAM() []mapper.CartRAM {
 
 	r := make([]mapper.CartRAM, 1)
 	r[0] = mapper.CartRAM{
-		Label:  "Superchip",
-		Origin: 0x1080,
-		Data:   make([]uint8, len(cart.state.ram)),
-		Mapped: true,
+		Label:       "Superchip",
+		Origin:      0x1080,
+		WriteOrigin: 0x1000,
+		Data:        make([]uint8, len(cart.state.ram)),
+		Mapped:      true,
 	}
 
 	copy(r[0].Data, cart.state.ram)
@@ -377,7 +385,7 @@ func newAtari4k(env *environment.Environment, loader cartridgeloader.Loader) (ma
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("4k: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("4k: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks[0] = make([]uint8, cart.bankSize)
@@ -442,7 +450,7 @@ func newAtari2k(env *environment.Environment, loader cartridgeloader.Loader) (ma
 
 	// support any size less than 4096 bytes that is a power of two
 	if len(data) >= 4096 || bits.OnesCount(uint(len(data))) != 1 {
-		return nil, fmt.Errorf("atari: unsupported cartridge size")
+		return nil, fmt.Errorf("2k: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart := &atari2k{
@@ -533,7 +541,7 @@ func newAtari8k(env *environment.Environment, loader cartridgeloader.Loader) (ma
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("F8: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("F8: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -644,7 +652,7 @@ func newAtari16k(env *environment.Environment, loader cartridgeloader.Loader) (m
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("F6: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("F6: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -761,7 +769,7 @@ func newAtari32k(env *environment.Environment, loader cartridgeloader.Loader) (m
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("F4: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("F4: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
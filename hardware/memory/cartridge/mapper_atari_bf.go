@@ -48,7 +48,7 @@ func newBF(env *environment.Environment, loader cartridgeloader.Loader) (mapper.
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("BF: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("BF: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -47,7 +47,7 @@ func newEF(env *environment.Environment, loader cartridgeloader.Loader) (mapper.
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("EF: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("EF: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
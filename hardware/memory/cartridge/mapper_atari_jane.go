@@ -50,7 +50,7 @@ func newJANE(env *environment.Environment, loader cartridgeloader.Loader) (mappe
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("JANE: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("JANE: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
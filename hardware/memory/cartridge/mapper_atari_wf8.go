@@ -51,7 +51,7 @@ func newWF8(env *environment.Environment, loader cartridgeloader.Loader) (mapper
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("WF8: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("WF8: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -70,7 +70,7 @@ func newCBS(env *environment.Environment, loader cartridgeloader.Loader) (mapper
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("FA: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("FA: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -247,10 +247,11 @@ func (cart *cbs) Step(_ float32) {
 func (cart *cbs) GetRAM() []mapper.CartRAM {
 	r := make([]mapper.CartRAM, 1)
 	r[0] = mapper.CartRAM{
-		Label:  "CBS+RAM",
-		Origin: 0x1100,
-		Data:   make([]uint8, len(cart.state.ram)),
-		Mapped: true,
+		Label:       "CBS+RAM",
+		Origin:      0x1100,
+		WriteOrigin: 0x1000,
+		Data:        make([]uint8, len(cart.state.ram)),
+		Mapped:      true,
 	}
 	copy(r[0].Data, cart.state.ram)
 	return r
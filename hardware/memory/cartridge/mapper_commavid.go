@@ -78,7 +78,7 @@ func newCommaVid(env *environment.Environment, loader cartridgeloader.Loader) (m
 		copy(cart.bankData[2048:], data[:2048])
 		logger.Log(env, "CV", "placing 2k commavid data at end of cartridge memory")
 	} else {
-		return nil, fmt.Errorf("CV: unhandled size for commavid cartridges (%d)", len(data))
+		return nil, fmt.Errorf("CV: %w (%d)", cartridgeloader.WrongROMSize, len(data))
 	}
 
 	return cart, nil
@@ -176,10 +176,11 @@ func (cart *commavid) Step(_ float32) {
 func (cart *commavid) GetRAM() []mapper.CartRAM {
 	r := make([]mapper.CartRAM, 1)
 	r[0] = mapper.CartRAM{
-		Label:  "CommaVid",
-		Origin: 0x1000,
-		Data:   make([]uint8, len(cart.state.ram)),
-		Mapped: true,
+		Label:       "CommaVid",
+		Origin:      0x1000,
+		WriteOrigin: 0x1400,
+		Data:        make([]uint8, len(cart.state.ram)),
+		Mapped:      true,
 	}
 	copy(r[0].Data, cart.state.ram)
 	return r
@@ -53,7 +53,7 @@ func newDF(env *environment.Environment, loader cartridgeloader.Loader) (mapper.
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("DF: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("DF: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -275,10 +275,11 @@ func (cart *df) Step(_ float32) {
 func (cart *df) GetRAM() []mapper.CartRAM {
 	r := make([]mapper.CartRAM, 1)
 	r[0] = mapper.CartRAM{
-		Label:  "DF+RAM",
-		Origin: 0x1080,
-		Data:   make([]uint8, len(cart.state.ram)),
-		Mapped: true,
+		Label:       "DF+RAM",
+		Origin:      0x1080,
+		WriteOrigin: 0x1000,
+		Data:        make([]uint8, len(cart.state.ram)),
+		Mapped:      true,
 	}
 	copy(r[0].Data, cart.state.ram)
 	return r
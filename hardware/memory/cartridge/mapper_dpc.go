@@ -65,7 +65,7 @@ func newDPC(env *environment.Environment, loader cartridgeloader.Loader) (mapper
 	cart.banks = make([][]uint8, cart.NumBanks())
 
 	if len(data) < cart.bankSize*cart.NumBanks()+staticSize {
-		return nil, fmt.Errorf("DPC: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("DPC: %w", cartridgeloader.WrongROMSize)
 	}
 
 	for k := 0; k < cart.NumBanks(); k++ {
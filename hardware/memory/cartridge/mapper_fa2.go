@@ -72,7 +72,7 @@ func newFA2(env *environment.Environment, loader cartridgeloader.Loader) (mapper
 	} else if len(data) == 24576 {
 		cart.banks = make([][]uint8, 6)
 	} else {
-		return nil, fmt.Errorf("FA2: unsupported number of bytes in the cartridge data")
+		return nil, fmt.Errorf("FA2: %w", cartridgeloader.WrongROMSize)
 	}
 
 	for k := 0; k < cart.NumBanks(); k++ {
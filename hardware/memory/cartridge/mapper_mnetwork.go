@@ -107,7 +107,7 @@ func newMnetwork(env *environment.Environment, loader cartridgeloader.Loader) (m
 	cart.banks = make([][]uint8, len(data)/cart.bankSize)
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("E7: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("E7: %w", cartridgeloader.WrongROMSize)
 	}
 
 	for k := 0; k < cart.NumBanks(); k++ {
@@ -380,19 +380,21 @@ func (cart *mnetwork) GetRAM() []mapper.CartRAM {
 	r := make([]mapper.CartRAM, mnetworkNum256byte+1)
 
 	r[0] = mapper.CartRAM{
-		Label:  "1k",
-		Origin: 0x1000,
-		Data:   make([]uint8, len(cart.state.ram1k)),
-		Mapped: cart.state.use1kRAM,
+		Label:       "1k",
+		Origin:      0x1000,
+		WriteOrigin: 0x1000,
+		Data:        make([]uint8, len(cart.state.ram1k)),
+		Mapped:      cart.state.use1kRAM,
 	}
 	copy(r[0].Data, cart.state.ram1k)
 
 	for i := 0; i < mnetworkNum256byte; i++ {
 		r[i+1] = mapper.CartRAM{
-			Label:  fmt.Sprintf("256B [%d]", i),
-			Origin: 0x1900,
-			Data:   make([]uint8, len(cart.state.ram256byte[i])),
-			Mapped: cart.state.ram256byteIdx == i,
+			Label:       fmt.Sprintf("256B [%d]", i),
+			Origin:      0x1900,
+			WriteOrigin: 0x1800,
+			Data:        make([]uint8, len(cart.state.ram256byte[i])),
+			Mapped:      cart.state.ram256byteIdx == i,
 		}
 		copy(r[i+1].Data, cart.state.ram256byte[i])
 	}
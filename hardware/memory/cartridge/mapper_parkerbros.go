@@ -68,7 +68,7 @@ func newParkerBros(env *environment.Environment, loader cartridgeloader.Loader)
 	cart.banks = make([][]uint8, cart.NumBanks())
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("E0: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("E0: %w", cartridgeloader.WrongROMSize)
 	}
 
 	for k := 0; k < cart.NumBanks(); k++ {
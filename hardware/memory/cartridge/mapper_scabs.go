@@ -51,7 +51,7 @@ func newSCABS(env *environment.Environment, loader cartridgeloader.Loader) (mapp
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("FE: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("FE: %w", cartridgeloader.WrongROMSize)
 	}
 
 	for k := 0; k < cart.NumBanks(); k++ {
@@ -59,7 +59,7 @@ func newSuperbank(env *environment.Environment, loader cartridgeloader.Loader) (
 	}
 
 	if len(data)%cart.bankSize != 0 {
-		return nil, fmt.Errorf("SB: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("SB: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, len(data)/cart.bankSize)
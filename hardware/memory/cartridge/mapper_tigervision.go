@@ -74,7 +74,7 @@ func newTigervision(env *environment.Environment, loader cartridgeloader.Loader)
 	}
 
 	if len(data)%cart.bankSize != 0 {
-		return nil, fmt.Errorf("3F: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("3F: %w", cartridgeloader.WrongROMSize)
 	}
 
 	numBanks := len(data) / cart.bankSize
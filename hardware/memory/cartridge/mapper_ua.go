@@ -57,7 +57,7 @@ func newUA(env *environment.Environment, loader cartridgeloader.Loader) (mapper.
 	}
 
 	if len(data) != cart.bankSize*cart.NumBanks() {
-		return nil, fmt.Errorf("UA: wrong number of bytes in the cartridge data")
+		return nil, fmt.Errorf("UA: %w", cartridgeloader.WrongROMSize)
 	}
 
 	cart.banks = make([][]uint8, cart.NumBanks())
@@ -97,7 +97,7 @@ func newWicksteadDesign(env *environment.Environment, loader cartridgeloader.Loa
 		if len(data) == cart.bankSize*cart.NumBanks()+3 {
 			badDump = true
 		} else {
-			return nil, fmt.Errorf("%s: wrong number of bytes in the cartridge data", cart.mappingID)
+			return nil, fmt.Errorf("%s: %w", cart.mappingID, cartridgeloader.WrongROMSize)
 		}
 	}
 
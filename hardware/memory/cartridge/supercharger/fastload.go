@@ -87,7 +87,7 @@ type fastloadBlock struct {
 // newFastLoad is the preferred method of initialisation for the FastLoad type.
 func newFastLoad(env *environment.Environment, state *state, loader cartridgeloader.Loader) (tape, error) {
 	if loader.Size()%fastLoadBlockLen != 0 {
-		return nil, fmt.Errorf("fastload: wrong number of bytes in cartridge data")
+		return nil, fmt.Errorf("fastload: %w", cartridgeloader.WrongROMSize)
 	}
 
 	fl := &FastLoad{
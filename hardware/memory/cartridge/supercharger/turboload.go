@@ -0,0 +1,90 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package supercharger
+
+// TurboLoadTV defines the television functions required by TurboLoad.
+type TurboLoadTV interface {
+	// SetFPSCap sets whether the frame rate should be limited and returns
+	// the previous setting
+	SetFPSCap(limit bool) bool
+}
+
+// TurboLoad speeds up the loading of a tape by the soundload process. The
+// emulation driver should call Start() and End() in response to the
+// NotifySuperchargerSoundloadStarted and NotifySuperchargerSoundloadEnded
+// notifications.
+//
+// During turbo loading the frame rate is not limited and, optionally, audio is
+// disabled. The previous frame rate limit and audio setting are restored when
+// loading ends. For multiload tapes Start() and End() will be called for every
+// load.
+type TurboLoad struct {
+	tv TurboLoadTV
+
+	// function to set whether audio is disabled. returns the previous
+	// setting. can be nil
+	audio func(disabled bool) bool
+
+	// whether turbo loading is enabled
+	Enabled bool
+
+	// whether a load is in progress and the FPS cap and audio settings before
+	// the load started
+	active            bool
+	prevCap           bool
+	prevAudioDisabled bool
+}
+
+// NewTurboLoad is the preferred method of initialisation for the TurboLoad
+// type. The audio function sets whether audio is disabled and returns the
+// previous setting. The audio argument can be nil.
+func NewTurboLoad(tv TurboLoadTV, audio func(disabled bool) bool) *TurboLoad {
+	return &TurboLoad{
+		tv:    tv,
+		audio: audio,
+	}
+}
+
+// Start turbo loading if it is enabled. Should be called when a soundload has
+// started.
+func (t *TurboLoad) Start() {
+	if !t.Enabled || t.active {
+		return
+	}
+	t.active = true
+	t.prevCap = t.tv.SetFPSCap(false)
+	if t.audio != nil {
+		t.prevAudioDisabled = t.audio(true)
+	}
+}
+
+// End turbo loading and return to normal speed. Should be called when a
+// soundload has ended.
+func (t *TurboLoad) End() {
+	if !t.active {
+		return
+	}
+	t.active = false
+	t.tv.SetFPSCap(t.prevCap)
+	if t.audio != nil {
+		t.audio(t.prevAudioDisabled)
+	}
+}
+
+// Active returns true if turbo loading is currently in progress.
+func (t *TurboLoad) Active() bool {
+	return t.active
+}
@@ -0,0 +1,110 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package supercharger_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/supercharger"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// stubTV implements the supercharger.TurboLoadTV interface. it counts the
+// number of frames that would have been limited by the FPS cap
+type stubTV struct {
+	fpsCap bool
+	capped int
+}
+
+func (tv *stubTV) SetFPSCap(limit bool) bool {
+	prev := tv.fpsCap
+	tv.fpsCap = limit
+	return prev
+}
+
+func (tv *stubTV) frame() {
+	if tv.fpsCap {
+		tv.capped++
+	}
+}
+
+// stubAudio records whether audio is disabled
+type stubAudio struct {
+	disabled bool
+}
+
+func (a *stubAudio) setDisabled(disabled bool) bool {
+	prev := a.disabled
+	a.disabled = disabled
+	return prev
+}
+
+// stubLoad runs a stubbed tape load of the specified number of frames and
+// returns the number of frames that were limited by the FPS cap
+func stubLoad(tv *stubTV, turbo *supercharger.TurboLoad, frames int) int {
+	tv.capped = 0
+	turbo.Start()
+	for range frames {
+		tv.frame()
+	}
+	turbo.End()
+	return tv.capped
+}
+
+func TestTurboLoad(t *testing.T) {
+	tv := &stubTV{fpsCap: true}
+	audio := &stubAudio{}
+	turbo := supercharger.NewTurboLoad(tv, audio.setDisabled)
+
+	const loadFrames = 50
+
+	// turbo loading is disabled by default. every frame of the load is limited
+	test.ExpectEquality(t, stubLoad(tv, turbo, loadFrames), loadFrames)
+	test.ExpectSuccess(t, tv.fpsCap)
+	test.ExpectFailure(t, audio.disabled)
+
+	// turbo loading disables the FPS cap and audio during the load
+	turbo.Enabled = true
+	turbo.Start()
+	test.ExpectSuccess(t, turbo.Active())
+	test.ExpectFailure(t, tv.fpsCap)
+	test.ExpectSuccess(t, audio.disabled)
+
+	// and restores them after the load has completed
+	turbo.End()
+	test.ExpectFailure(t, turbo.Active())
+	test.ExpectSuccess(t, tv.fpsCap)
+	test.ExpectFailure(t, audio.disabled)
+
+	// no frame of a turbo load is limited
+	test.ExpectEquality(t, stubLoad(tv, turbo, loadFrames), 0)
+	test.ExpectSuccess(t, tv.fpsCap)
+
+	// the FPS cap setting from before the load is restored. in this case the
+	// FPS cap was not enabled
+	tv.fpsCap = false
+	turbo.Start()
+	turbo.End()
+	test.ExpectFailure(t, tv.fpsCap)
+
+	// the audio setting from before the load is restored. in this case audio
+	// was already disabled
+	audio.disabled = true
+	turbo.Start()
+	test.ExpectSuccess(t, audio.disabled)
+	turbo.End()
+	test.ExpectSuccess(t, audio.disabled)
+}
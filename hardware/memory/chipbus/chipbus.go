@@ -34,6 +34,12 @@ type ChangedRegister struct {
 	Register cpubus.Register
 }
 
+// WriteObserver is implemented by types that want to be notified of every
+// change made to a chip register by the CPU
+type WriteObserver interface {
+	ObserveChipWrite(ChangedRegister)
+}
+
 // Memory defines the operations for the memory system when accessed from the
 // VCS chips (TIA, RIOT)
 type Memory interface {
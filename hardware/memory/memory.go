@@ -22,6 +22,7 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 	"github.com/jetsetilly/gopher2600/hardware/memory/vcs"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 // Memory is the monolithic representation of the memory in 2600.
@@ -58,6 +59,15 @@ type Memory struct {
 	// not all pins of the databus are driven at all times. bits set in
 	// the DataBusDriven field indicate the pins that are being driven
 	DataBusDriven uint8
+
+	// returns the address of the instruction being executed by the CPU. used
+	// when logging writes to ROM. can be nil
+	InstructionAddress func() uint16
+
+	// the television frame number of the most recent read of an input
+	// register (SWCHA or INPT0 to INPT5) by the CPU. used to detect when the
+	// 6507 program is not reading the controllers
+	InputReadFrame int
 }
 
 // NewMemory is the preferred method of initialisation for Memory.
@@ -102,6 +112,35 @@ func (mem *Memory) Reset() {
 	mem.TIA.Reset()
 	mem.RAM.Reset()
 	mem.Cart.Reset()
+	mem.InputReadFrame = mem.currentFrame()
+}
+
+// currentFrame returns the frame number of the television. returns zero if
+// there is no television
+func (mem *Memory) currentFrame() int {
+	if mem.env == nil || mem.env.TV == nil {
+		return 0
+	}
+	return mem.env.TV.GetCoords().Frame
+}
+
+// noteInputRead records the current frame number if the mapped address is one
+// of the input registers. address must be mapped
+//
+// the function is called on every CPU read so the addresses are compared
+// numerically rather than by looking up the register name. the addresses are
+// INPT0 to INPT5 in the TIA and SWCHA in the RIOT
+func (mem *Memory) noteInputRead(address uint16, area memorymap.Area) {
+	switch area {
+	case memorymap.TIA:
+		if address >= 0x08 && address <= 0x0d {
+			mem.InputReadFrame = mem.currentFrame()
+		}
+	case memorymap.RIOT:
+		if address == 0x280 {
+			mem.InputReadFrame = mem.currentFrame()
+		}
+	}
 }
 
 // Area defines the meta-operations for all memory areas
@@ -168,6 +207,9 @@ func (mem *Memory) Read(address uint16) (uint8, error) {
 	var data uint8
 	data, mem.DataBusDriven, err = area.Read(ma)
 
+	// note reads of the input registers
+	mem.noteInputRead(ma, ar)
+
 	// the data bus is not always completely driven. ie. some pins are not powered and are left
 	// floating
 	//
@@ -243,9 +285,47 @@ func (mem *Memory) Write(address uint16, data uint8) error {
 	mem.LastCPUWrite = true
 	mem.LastCPUData = data
 
+	if ar == memorymap.Cartridge && mem.env != nil && mem.env.Prefs.LogROMWrites.Get().(bool) {
+		mem.logROMWrite(ma, data)
+	}
+
 	return area.Write(ma, data)
 }
 
+// logROMWrite logs the write to the cartridge address unless the address is a
+// write hotspot or is in cartridge RAM. address must be mapped
+func (mem *Memory) logROMWrite(address uint16, data uint8) {
+	if bus := mem.Cart.GetCartHotspotsBus(); bus != nil {
+		if _, ok := bus.WriteHotspots()[address]; ok {
+			return
+		}
+	}
+
+	// only writes to the write port of cartridge RAM are ignored. a write to
+	// the read port is a write to ROM as far as the program is concerned
+	if bus := mem.Cart.GetRAMbus(); bus != nil {
+		for _, r := range bus.GetRAM() {
+			if !r.Mapped {
+				continue
+			}
+			origin := r.WriteOrigin
+			if origin == 0 {
+				origin = r.Origin
+			}
+			if address >= origin && address < origin+uint16(len(r.Data)) {
+				return
+			}
+		}
+	}
+
+	if mem.InstructionAddress == nil {
+		logger.Logf(mem.env, "memory", "write to ROM address %#04x (value %#02x)", address, data)
+		return
+	}
+	logger.Logf(mem.env, "memory", "write to ROM address %#04x (value %#02x) by instruction at %#04x",
+		address, data, mem.InstructionAddress())
+}
+
 // Peek implements the DebugBus interface.
 func (mem *Memory) Peek(address uint16) (uint8, error) {
 	ma, ar := memorymap.MapAddress(address, true)
@@ -16,9 +16,17 @@
 package memory_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
 	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
 )
 
 func readData(t *testing.T, mem *memory.Memory, address uint16, expectedData uint8) {
@@ -86,3 +94,61 @@ func TestAddressComplete(t *testing.T) {
 		}
 	}
 }
+
+func TestLogROMWrites(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	cartload, err := cartridgeloader.NewLoaderFromData("romwrites", make([]byte, 8192), "F8", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.Mem.Cart.Attach(cartload))
+
+	// the instruction address is taken from the CPU
+	vcs.CPU.LastResult.Address = 0xf010
+
+	tail := func() string {
+		s := &strings.Builder{}
+		logger.Tail(s, 1)
+		return s.String()
+	}
+
+	// writes to ROM are not logged by default
+	logger.Clear()
+	test.DemandSuccess(t, vcs.Mem.Write(0xf100, 0x55))
+	test.ExpectEquality(t, tail(), "")
+
+	test.DemandSuccess(t, vcs.Env.Prefs.LogROMWrites.Set(true))
+
+	// writes to a hotspot are not logged
+	test.DemandSuccess(t, vcs.Mem.Write(0xfff8, 0x00))
+	test.ExpectEquality(t, tail(), "")
+
+	// writes to a non-hotspot address are logged
+	test.DemandSuccess(t, vcs.Mem.Write(0xf100, 0x55))
+	test.ExpectEquality(t, tail(), "memory: write to ROM address 0x1100 (value 0x55) by instruction at 0xf010\n")
+
+	// writes to RAM are not logged
+	logger.Clear()
+	test.DemandSuccess(t, vcs.Mem.Write(0x0080, 0x55))
+	test.ExpectEquality(t, tail(), "")
+
+	// cartridge with separate read and write ports for RAM
+	cartload, err = cartridgeloader.NewLoaderFromData("romwrites", make([]byte, 8192), "F8SC", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.Mem.Cart.Attach(cartload))
+
+	// writes to the cartridge RAM write port are not logged
+	logger.Clear()
+	test.DemandSuccess(t, vcs.Mem.Write(0xf010, 0x55))
+	test.ExpectEquality(t, tail(), "")
+
+	// writes to the cartridge RAM read port are logged
+	test.DemandSuccess(t, vcs.Mem.Write(0xf090, 0x55))
+	test.ExpectEquality(t, tail(), "memory: write to ROM address 0x1090 (value 0x55) by instruction at 0xf010\n")
+}
@@ -17,6 +17,7 @@ package vcs
 
 import (
 	"encoding/hex"
+	"math/rand"
 
 	"github.com/jetsetilly/gopher2600/environment"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
@@ -46,12 +47,41 @@ func (ram *RAM) Snapshot() *RAM {
 	return &n
 }
 
-// Reset contents of RAM.
+// Reset contents of RAM. If the RandomState preference is false then RAM is
+// filled according to the RAMPattern preference.
 func (ram *RAM) Reset() {
-	for i := range ram.RAM {
-		if ram.env != nil && ram.env.Prefs.RandomState.Get().(bool) {
+	if ram.env != nil && ram.env.Prefs.RandomState.Get().(bool) {
+		for i := range ram.RAM {
 			ram.RAM[i] = uint8(ram.env.Random.NoRewind(0xff))
-		} else {
+		}
+		return
+	}
+
+	pattern := "ZERO"
+	if ram.env != nil {
+		pattern = ram.env.Prefs.RAMPattern.Get().(string)
+	}
+
+	switch pattern {
+	case "FF":
+		for i := range ram.RAM {
+			ram.RAM[i] = 0xff
+		}
+	case "ALTERNATE":
+		for i := range ram.RAM {
+			if i%2 == 0 {
+				ram.RAM[i] = 0x00
+			} else {
+				ram.RAM[i] = 0xff
+			}
+		}
+	case "RANDOM":
+		rnd := rand.New(rand.NewSource(int64(ram.env.Prefs.RAMSeed.Get().(int))))
+		for i := range ram.RAM {
+			ram.RAM[i] = uint8(rnd.Intn(0x100))
+		}
+	default:
+		for i := range ram.RAM {
 			ram.RAM[i] = 0
 		}
 	}
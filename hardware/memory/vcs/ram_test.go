@@ -0,0 +1,82 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package vcs_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestRAMPattern(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	ram := vcs.Mem.RAM
+	p := vcs.Env.Prefs
+
+	// default pattern is zero
+	ram.Reset()
+	for _, v := range ram.RAM {
+		test.ExpectEquality(t, v, uint8(0x00))
+	}
+
+	p.RAMPattern.Set("FF")
+	ram.Reset()
+	for _, v := range ram.RAM {
+		test.ExpectEquality(t, v, uint8(0xff))
+	}
+
+	p.RAMPattern.Set("ALTERNATE")
+	ram.Reset()
+	for i, v := range ram.RAM {
+		if i%2 == 0 {
+			test.ExpectEquality(t, v, uint8(0x00))
+		} else {
+			test.ExpectEquality(t, v, uint8(0xff))
+		}
+	}
+
+	// the same seed produces the same RAM contents
+	p.RAMPattern.Set("RANDOM")
+	p.RAMSeed.Set(100)
+	ram.Reset()
+	a := slices.Clone(ram.RAM)
+	ram.Reset()
+	test.ExpectSuccess(t, slices.Equal(a, ram.RAM))
+
+	// and a different seed produces different RAM contents
+	p.RAMSeed.Set(101)
+	ram.Reset()
+	test.ExpectFailure(t, slices.Equal(a, ram.RAM))
+
+	// the random state preference takes priority over the pattern
+	p.RAMPattern.Set("FF")
+	p.RandomState.Set(true)
+	ram.Reset()
+	test.ExpectFailure(t, slices.IndexFunc(ram.RAM, func(v uint8) bool { return v != 0xff }) == -1)
+}
@@ -64,6 +64,16 @@ type ARMPreferences struct {
 	// true)
 	MisalignedAccessIsFault prefs.Bool
 
+	// log 32bit accesses to addresses that are not word aligned. in addition,
+	// on architectures that don't allow misaligned accesses, single word
+	// reads from a misaligned address are rotated in the same way as real
+	// hardware rather than simply being read from the aligned address
+	StrictAlignment prefs.Bool
+
+	// treat the PC leaving program memory as an error. if this is false then
+	// the program is quietly ended early instead
+	PCOutOfRangeIsError prefs.Bool
+
 	// include disassembly and register details when logging memory faults
 	ExtendedMemoryFaultLogging prefs.Bool
 
@@ -119,6 +129,14 @@ func newARMprefrences() (*ARMPreferences, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = p.dsk.Add("hardware.arm7.strictAlignment", &p.StrictAlignment)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.arm7.pcOutOfRangeIsError", &p.PCOutOfRangeIsError)
+	if err != nil {
+		return nil, err
+	}
 	err = p.dsk.Add("hardware.arm7.extendedMemoryFaultLogging", &p.ExtendedMemoryFaultLogging)
 	if err != nil {
 		return nil, err
@@ -146,6 +164,8 @@ func (p *ARMPreferences) SetDefaults() {
 	p.MAM.Set(-1)
 	p.AbortOnMemoryFault.Set(false)
 	p.MisalignedAccessIsFault.Set(false)
+	p.StrictAlignment.Set(false)
+	p.PCOutOfRangeIsError.Set(true)
 	p.ExtendedMemoryFaultLogging.Set(false)
 	p.UndefinedSymbolWarning.Set(false)
 }
@@ -20,6 +20,9 @@ import (
 	"github.com/jetsetilly/gopher2600/resources"
 )
 
+// RAMPatternList is the list of valid values for the RAMPattern preference.
+var RAMPatternList = []string{"ZERO", "FF", "ALTERNATE", "RANDOM"}
+
 // Preferences defines and collates all the preference values used by the emulation.
 type Preferences struct {
 	dsk *prefs.Disk
@@ -33,6 +36,29 @@ type Preferences struct {
 	// unused pins randomly on a read/peek"
 	RandomPins prefs.Bool
 
+	// the pattern used to fill VCS RAM on reset. one of the values in
+	// RAMPatternList. RAMPattern is ignored if RandomState is true
+	RAMPattern prefs.String
+
+	// seed used when RAMPattern is "RANDOM". the same seed will always produce
+	// the same RAM contents
+	RAMSeed prefs.Int
+
+	// log writes to the cartridge address space that are not to a bankswitch
+	// hotspot or to cartridge RAM. writes to ROM have no effect on real
+	// hardware but they can indicate a bug or an undocumented hotspot
+	LogROMWrites prefs.Bool
+
+	// detect loops in the 6507 program that are waiting for the RIOT timer to
+	// change and replay them without executing the CPU instructions. the TIA
+	// and RIOT are still stepped for every cycle so the saving is small. only
+	// used during fast-forward
+	SpinDetection prefs.Bool
+
+	// the number of frames without a read of the controller registers before
+	// the 6507 program is considered to be in an attract mode
+	AttractFrames prefs.Int
+
 	// preferences used by the television
 	TV *TVPreferences
 
@@ -75,6 +101,26 @@ func NewPreferences() (*Preferences, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = p.dsk.Add("hardware.ramPattern", &p.RAMPattern)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.ramSeed", &p.RAMSeed)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.logROMWrites", &p.LogROMWrites)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.spinDetection", &p.SpinDetection)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.attractFrames", &p.AttractFrames)
+	if err != nil {
+		return nil, err
+	}
 	err = p.dsk.Load(true)
 	if err != nil {
 		return nil, err
@@ -113,6 +159,11 @@ func (p *Preferences) SetDefaults() {
 	// initialise random number generator
 	p.RandomState.Set(false)
 	p.RandomPins.Set(false)
+	p.RAMPattern.Set("ZERO")
+	p.RAMSeed.Set(0)
+	p.LogROMWrites.Set(false)
+	p.SpinDetection.Set(false)
+	p.AttractFrames.Set(300)
 }
 
 // Load current hardware preference from disk.
@@ -17,12 +17,46 @@ package preferences
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"sync/atomic"
 
+	"github.com/jetsetilly/gopher2600/hardware/tia/revision"
 	"github.com/jetsetilly/gopher2600/prefs"
 	"github.com/jetsetilly/gopher2600/resources"
 )
 
+// RevisionPresetList is the list of valid names for ApplyPreset().
+var RevisionPresetList = []string{"STANDARD", "EARLYNTSC", "LATENTSC", "PAL"}
+
+// the bugs associated with each preset. the variations between individual
+// consoles are large and these presets are only an approximation of the
+// behaviour reported for each type of console. bugs that have only been
+// observed on individual consoles, such as LateColor, are not part of any
+// preset and should be enabled individually
+var revisionPresets = map[string][]revision.Bug{
+	// a TIA with none of the known bugs. this is the same as the default
+	// preferences
+	"STANDARD": {},
+
+	// early NTSC consoles (the "heavy sixer" and "light sixer" models) are the
+	// reference for the emulation of the TIA and so none of the bugs are
+	// enabled
+	"EARLYNTSC": {},
+
+	// later NTSC consoles (the 2600 Jr. in particular) do not draw the Cosmic
+	// Ark starfield
+	//
+	// http://www.ataricompendium.com/faq/vcs_tia/vcs_tia.html
+	"LATENTSC": {revision.LostMOTCK},
+
+	// PAL consoles are more likely to show the RESPx and scancounter
+	// differences under HMOVE conditions
+	//
+	// https://atariage.com/forums/topic/311795-576-and-1008-characters/?tab=comments#comment-4748106
+	"PAL": {revision.LateRESPx, revision.EarlyScancounter},
+}
+
 // LiveRevisionPrefrences encapsulates the current (live) revision values.
 //
 // For performance critical situations these values should be preferred to the
@@ -168,6 +202,104 @@ func (p *RevisionPreferences) SetDefaults() {
 	p.RESPxHBLANK.Set(false)
 }
 
+// bug returns the preference for the specified bug.
+func (p *RevisionPreferences) bug(bug revision.Bug) *prefs.Bool {
+	switch bug {
+	case revision.LateVDELGRP0:
+		return &p.LateVDELGRP0
+	case revision.LateVDELGRP1:
+		return &p.LateVDELGRP1
+	case revision.LateRESPx:
+		return &p.LateRESPx
+	case revision.EarlyScancounter:
+		return &p.EarlyScancounter
+	case revision.LatePFx:
+		return &p.LatePFx
+	case revision.LateColor:
+		return &p.LateColor
+	case revision.LostMOTCK:
+		return &p.LostMOTCK
+	case revision.RESPxHBLANK:
+		return &p.RESPxHBLANK
+	}
+	panic(fmt.Sprintf("unknown TIA revision bug (%d)", bug))
+}
+
+// ApplyPreset sets the combination of bugs associated with the named preset.
+// Bugs not associated with the preset are turned off. The name must be one of
+// the values in RevisionPresetList and is not case sensitive.
+//
+// The TIA revision preferences are found in the Revision field of the
+// Preferences type and so the preset for an emulation is applied with
+// Prefs.Revision.ApplyPreset().
+func (p *RevisionPreferences) ApplyPreset(name string) error {
+	bugs, ok := revisionPresets[strings.ToUpper(name)]
+	if !ok {
+		return fmt.Errorf("revision: unknown preset (%s)", name)
+	}
+
+	for bug := revision.LateVDELGRP0; bug <= revision.RESPxHBLANK; bug++ {
+		err := p.bug(bug).Set(slices.Contains(bugs, bug))
+		if err != nil {
+			return fmt.Errorf("revision: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// QuirkInfo describes a single TIA revision bug and whether it is enabled.
+type QuirkInfo struct {
+	Name        string
+	Description string
+	Enabled     bool
+}
+
+// Quirks returns information about every TIA revision bug. Useful for
+// presenting the list of bugs without knowing about each bug individually.
+func (p *RevisionPreferences) Quirks() []QuirkInfo {
+	var quirks []QuirkInfo
+	for bug := revision.LateVDELGRP0; bug <= revision.RESPxHBLANK; bug++ {
+		quirks = append(quirks, QuirkInfo{
+			Name:        bug.String(),
+			Description: bug.Description(),
+			Enabled:     p.bug(bug).Get().(bool),
+		})
+	}
+	return quirks
+}
+
+// SetQuirk enables or disables the TIA revision bug with the specified name.
+// The name is not case sensitive and should be one of the names returned by
+// Quirks().
+func (p *RevisionPreferences) SetQuirk(name string, enabled bool) error {
+	for bug := revision.LateVDELGRP0; bug <= revision.RESPxHBLANK; bug++ {
+		if strings.EqualFold(bug.String(), name) {
+			err := p.bug(bug).Set(enabled)
+			if err != nil {
+				return fmt.Errorf("revision: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("revision: unknown bug (%s)", name)
+}
+
+// String returns a description of every bug that is currently enabled.
+func (p *RevisionPreferences) String() string {
+	var s strings.Builder
+	for bug := revision.LateVDELGRP0; bug <= revision.RESPxHBLANK; bug++ {
+		if p.bug(bug).Get().(bool) {
+			s.WriteString(bug.Description())
+			s.WriteString("\n")
+		}
+	}
+	if s.Len() == 0 {
+		return "no TIA revision bugs enabled"
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
 // Load revision preferences from disk.
 func (p *RevisionPreferences) Load() error {
 	return p.dsk.Load(false)
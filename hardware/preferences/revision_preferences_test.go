@@ -0,0 +1,112 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package preferences_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestRevisionPreset(t *testing.T) {
+	prefs.DisableSaving = true
+
+	p, err := preferences.NewPreferences()
+	test.DemandSuccess(t, err)
+	rev := p.Revision
+
+	// bugs that are not part of a preset are turned off by the preset
+	test.DemandSuccess(t, rev.LatePFx.Set(true))
+
+	err = rev.ApplyPreset("latentsc")
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.LateVDELGRP0.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateVDELGRP1.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateRESPx.Get().(bool), false)
+	test.ExpectEquality(t, rev.EarlyScancounter.Get().(bool), false)
+	test.ExpectEquality(t, rev.LatePFx.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateColor.Get().(bool), false)
+	test.ExpectEquality(t, rev.LostMOTCK.Get().(bool), true)
+	test.ExpectEquality(t, rev.RESPxHBLANK.Get().(bool), false)
+
+	// live values are updated along with the preference values
+	test.ExpectEquality(t, rev.Live.LostMOTCK.Load().(bool), true)
+	test.ExpectEquality(t, rev.Live.LatePFx.Load().(bool), false)
+
+	// applying a second preset turns off bugs not in that preset
+	err = rev.ApplyPreset("PAL")
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.LateVDELGRP0.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateVDELGRP1.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateRESPx.Get().(bool), true)
+	test.ExpectEquality(t, rev.EarlyScancounter.Get().(bool), true)
+	test.ExpectEquality(t, rev.LatePFx.Get().(bool), false)
+	test.ExpectEquality(t, rev.LateColor.Get().(bool), false)
+	test.ExpectEquality(t, rev.LostMOTCK.Get().(bool), false)
+	test.ExpectEquality(t, rev.RESPxHBLANK.Get().(bool), false)
+
+	err = rev.ApplyPreset("earlyntsc")
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.String(), "no TIA revision bugs enabled")
+
+	test.DemandSuccess(t, rev.ApplyPreset("PAL"))
+
+	err = rev.ApplyPreset("STANDARD")
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.String(), "no TIA revision bugs enabled")
+
+	// unknown presets are rejected and leave the flags unchanged
+	err = rev.ApplyPreset("SECAM")
+	test.ExpectFailure(t, err)
+	test.ExpectEquality(t, rev.String(), "no TIA revision bugs enabled")
+}
+
+func TestRevisionQuirks(t *testing.T) {
+	prefs.DisableSaving = true
+
+	p, err := preferences.NewPreferences()
+	test.DemandSuccess(t, err)
+	rev := p.Revision
+	test.DemandSuccess(t, rev.ApplyPreset("STANDARD"))
+
+	quirks := rev.Quirks()
+	test.ExpectEquality(t, len(quirks), 8)
+	test.ExpectEquality(t, quirks[0].Name, "LateVDELGRP0")
+	test.ExpectEquality(t, quirks[7].Name, "RESPxHBLANK")
+	for _, q := range quirks {
+		test.ExpectFailure(t, q.Enabled)
+		test.ExpectInequality(t, q.Description, "")
+	}
+
+	// toggle a quirk by name. the name is not case sensitive
+	err = rev.SetQuirk("latepfx", true)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.LatePFx.Get().(bool), true)
+	test.ExpectEquality(t, rev.Live.LatePFx.Load().(bool), true)
+
+	for _, q := range rev.Quirks() {
+		test.ExpectEquality(t, q.Enabled, q.Name == "LatePFx")
+	}
+
+	err = rev.SetQuirk("LatePFx", false)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, rev.LatePFx.Get().(bool), false)
+
+	err = rev.SetQuirk("EarlyHMOVE", true)
+	test.ExpectFailure(t, err)
+}
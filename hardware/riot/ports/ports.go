@@ -74,8 +74,19 @@ type Ports struct {
 	// state of peripheral audio output. applies to peripherals that implement
 	// ports.mutePeripheral interface
 	peripheralsMuted bool
+
+	// INPTx registers that have been forced to a specific value with the
+	// ForceINPTx() function. indexed by the register offset from INPT0
+	//
+	// while a register is forced, writes from peripherals to that register are
+	// ignored
+	inptxForced      [numINPTx]bool
+	inptxForcedValue [numINPTx]uint8
 }
 
+// the number of INPTx registers in the TIA
+const numINPTx = 6
+
 // NewPorts is the preferred method of initialisation of the Ports type
 func NewPorts(env *environment.Environment, riotMem chipbus.Memory, tiaMem chipbus.Memory) *Ports {
 	p := &Ports{
@@ -249,6 +260,13 @@ func (p *Ports) ResetPeripherals() {
 	if p.Panel != nil {
 		p.Panel.Reset()
 	}
+
+	// reapply any forced INPTx values. the memory reset will have cleared them
+	for i := range p.inptxForced {
+		if p.inptxForced[i] {
+			p.tia.ChipWrite(chipbus.INPT0+chipbus.Register(i), p.inptxForcedValue[i])
+		}
+	}
 }
 
 // Update checks to see if ChipData applies to the Input type and updates the
@@ -385,6 +403,11 @@ func (p *Ports) WriteSWCHx(id plugging.PortID, data uint8) {
 
 // WriteINPTx implements the peripheral.PeripheralBus interface
 func (p *Ports) WriteINPTx(inptx chipbus.Register, data uint8) {
+	// forced registers are not affected by peripherals
+	if p.inptxForced[inptx-chipbus.INPT0] {
+		return
+	}
+
 	// the VBLANK latch bit only applies to INPT4 and INPT5
 	latch := false
 	if inptx == chipbus.INPT4 || inptx == chipbus.INPT5 {
@@ -398,6 +421,64 @@ func (p *Ports) WriteINPTx(inptx chipbus.Register, data uint8) {
 	}
 }
 
+// ForceINPTx sets the INPTx register to the specified value. The value will
+// remain until ReleaseINPTx() is called, regardless of what the attached
+// peripherals do. The VBLANK latch is also ignored.
+//
+// The inptx argument is the register number, in the range 0 to 5.
+//
+// Useful for testing paddle and light-gun code without having to model the
+// controller.
+func (p *Ports) ForceINPTx(inptx int, data uint8) error {
+	if inptx < 0 || inptx >= numINPTx {
+		return fmt.Errorf("ports: INPT%d is not a valid register", inptx)
+	}
+	p.inptxForced[inptx] = true
+	p.inptxForcedValue[inptx] = data
+	p.tia.ChipWrite(chipbus.INPT0+chipbus.Register(inptx), data)
+	return nil
+}
+
+// ReleaseINPTx returns control of the INPTx register to the attached
+// peripherals. The register will retain the forced value until the next
+// time a peripheral writes to it.
+//
+// The inptx argument is the register number, in the range 0 to 5.
+func (p *Ports) ReleaseINPTx(inptx int) error {
+	if inptx < 0 || inptx >= numINPTx {
+		return fmt.Errorf("ports: INPT%d is not a valid register", inptx)
+	}
+	p.inptxForced[inptx] = false
+	return nil
+}
+
+// IsINPTxForced returns true and the forced value if the INPTx register has
+// been forced with the ForceINPTx() function.
+//
+// The inptx argument is the register number, in the range 0 to 5.
+func (p *Ports) IsINPTxForced(inptx int) (bool, uint8) {
+	if inptx < 0 || inptx >= numINPTx {
+		return false, 0
+	}
+	return p.inptxForced[inptx], p.inptxForcedValue[inptx]
+}
+
+// StringINPTx returns the current value of the INPTx registers, noting which
+// are forced.
+func (p *Ports) StringINPTx() string {
+	s := strings.Builder{}
+	for i := 0; i < numINPTx; i++ {
+		s.WriteString(fmt.Sprintf("INPT%d: %#02x", i, p.tia.ChipRefer(chipbus.INPT0+chipbus.Register(i))))
+		if p.inptxForced[i] {
+			s.WriteString(" [forced]")
+		}
+		if i < numINPTx-1 {
+			s.WriteString(" ")
+		}
+	}
+	return s.String()
+}
+
 // HandleInputEvent forwards the InputEvent to the perupheral in the correct
 // port. Returns true if the event was handled and false if not
 func (p *Ports) HandleInputEvent(inp InputEvent) (bool, error) {
@@ -0,0 +1,74 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/plugging"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestForceINPTx(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// the fire button is read in bit 7 of INPT4
+	fire := func() bool {
+		t.Helper()
+		v, err := vcs.Mem.Read(cpubus.ReadAddressByRegister[cpubus.INPT4])
+		test.DemandSuccess(t, err)
+		return v&0x80 == 0x00
+	}
+
+	// fire button is not pressed by default
+	test.ExpectEquality(t, fire(), false)
+
+	// force INPT4 low. the fire button will appear to be pressed
+	test.ExpectSuccess(t, vcs.RIOT.Ports.ForceINPTx(4, 0x00))
+	test.ExpectEquality(t, fire(), true)
+
+	// releasing the fire button on the joystick has no effect while the
+	// register is forced
+	inp := ports.InputEvent{Port: plugging.PortLeft, Ev: ports.Fire, D: false}
+	_, err = vcs.Input.HandleInputEvent(inp)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, fire(), true)
+
+	// forced value survives a reset
+	test.ExpectSuccess(t, vcs.Reset())
+	test.ExpectEquality(t, fire(), true)
+
+	// control returns to the joystick after the register is released
+	test.ExpectSuccess(t, vcs.RIOT.Ports.ReleaseINPTx(4))
+	_, err = vcs.Input.HandleInputEvent(inp)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, fire(), false)
+
+	// only INPT0 to INPT5 can be forced
+	test.ExpectFailure(t, vcs.RIOT.Ports.ForceINPTx(6, 0x00))
+}
@@ -186,6 +186,30 @@ func (tmr *Timer) Update(data chipbus.ChangedRegister) bool {
 
 }
 
+// SetInterval sets the timer divider and count as though the CPU had written
+// the count to the TIM1T, TIM8T, TIM64T or T1024T register. The divider must be
+// one of 1, 8, 64 or 1024.
+func (tmr *Timer) SetInterval(divider Divider, count uint8) error {
+	var reg cpubus.Register
+
+	switch divider {
+	case TIM1T:
+		reg = cpubus.TIM1T
+	case TIM8T:
+		reg = cpubus.TIM8T
+	case TIM64T:
+		reg = cpubus.TIM64T
+	case T1024T:
+		reg = cpubus.T1024T
+	default:
+		return fmt.Errorf("timer: interval must be 1, 8, 64 or 1024 (%d)", divider)
+	}
+
+	tmr.Update(chipbus.ChangedRegister{Register: reg, Value: count})
+
+	return nil
+}
+
 func (tmr *Timer) updateTIMINT() {
 	v := uint8(0)
 	if tmr.expired {
@@ -261,6 +285,29 @@ func (tmr *Timer) Step() {
 	}
 }
 
+// StableCycles returns the number of CPU cycles for which the value of the
+// INTIM or TIMINT register is guaranteed to remain unchanged. The number
+// assumes that the timer is not written to in that time.
+//
+// A value of zero means that the register may change on the next cycle. This
+// is always the case once the timer has expired.
+func (tmr *Timer) StableCycles(reg cpubus.Register) int {
+	if tmr.expired || tmr.ticksRemaining <= 0 {
+		return 0
+	}
+
+	switch reg {
+	case cpubus.INTIM:
+		return tmr.ticksRemaining - 1
+	case cpubus.TIMINT:
+		// the expired bit is set when INTIM decreases from zero
+		intim := int(tmr.mem.ChipRefer(chipbus.INTIM))
+		return tmr.ticksRemaining - 1 + intim*int(tmr.divider)
+	}
+
+	return 0
+}
+
 // PeekINTIM pokes a new value into the INTIM register. Same as peeking the
 // INTIM register on the cpubus - provided here for convenience
 //
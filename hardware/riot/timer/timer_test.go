@@ -0,0 +1,65 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package timer_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestSetInterval(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	tmr := vcs.RIOT.Timer
+
+	// only the four intervals supported by the RIOT are allowed
+	test.ExpectFailure(t, tmr.SetInterval(2, 10))
+	test.ExpectFailure(t, tmr.SetInterval(0, 10))
+
+	test.ExpectSuccess(t, tmr.SetInterval(timer.TIM64T, 10))
+	test.ExpectEquality(t, tmr.PeekField("divider").(timer.Divider), timer.TIM64T)
+	test.ExpectEquality(t, tmr.PeekField("intim").(uint8), 10)
+
+	// INTIM is decreased on the first cycle after the timer is set
+	tmr.Step()
+	test.ExpectEquality(t, tmr.PeekField("intim").(uint8), 9)
+
+	// the timer expires after the predicted number of cycles
+	stable := tmr.StableCycles(cpubus.TIMINT)
+	test.ExpectEquality(t, stable, 63+9*64)
+
+	for range stable {
+		tmr.Step()
+	}
+	test.ExpectEquality(t, tmr.PeekField("timint").(uint8)&0x80, 0)
+
+	tmr.Step()
+	test.ExpectEquality(t, tmr.PeekField("timint").(uint8)&0x80, 0x80)
+	test.ExpectEquality(t, tmr.PeekField("intim").(uint8), 0xff)
+}
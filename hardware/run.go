@@ -45,6 +45,10 @@ func (vcs *VCS) Run(continueCheck func() (govern.State, error)) error {
 		continueCheck = func() (govern.State, error) { return govern.Running, nil }
 	}
 
+	// spin detection is only used during fast-forward
+	var spin spinDetector
+	var spinning bool
+
 	// see the equivalient colorClock() in the VCS.Step() function for an
 	// explanation for what's going on here:
 	colorClock := func() error {
@@ -53,6 +57,7 @@ func (vcs *VCS) Run(continueCheck func() (govern.State, error)) error {
 		}
 
 		if reg, ok := vcs.Mem.TIA.ChipHasChanged(); ok {
+			vcs.observeChipWrite(reg)
 			vcs.TIA.QuickStep(1)
 			vcs.TIA.QuickStep(2)
 			vcs.TIA.Step(reg, 3)
@@ -63,6 +68,7 @@ func (vcs *VCS) Run(continueCheck func() (govern.State, error)) error {
 			vcs.TIA.QuickStep(3)
 
 			if reg, ok := vcs.Mem.RIOT.ChipHasChanged(); ok {
+				vcs.observeChipWrite(reg)
 				vcs.RIOT.Step(reg)
 			} else {
 				vcs.RIOT.QuickStep()
@@ -71,6 +77,10 @@ func (vcs *VCS) Run(continueCheck func() (govern.State, error)) error {
 
 		vcs.Mem.Cart.Step(vcs.Clock)
 
+		if spinning {
+			spin.cycle(vcs)
+		}
+
 		return nil
 	}
 
@@ -81,10 +91,23 @@ func (vcs *VCS) Run(continueCheck func() (govern.State, error)) error {
 	for state != govern.Ending && state != govern.Initialising {
 		switch state {
 		case govern.Running:
+			detect := vcs.TV.IsFastForward() && vcs.Env.Prefs.SpinDetection.Get().(bool)
+			if detect != spinning {
+				spinning = detect
+				spin.reset()
+			}
+
 			err := vcs.CPU.ExecuteInstruction(colorClock)
 			if err != nil {
 				return err
 			}
+
+			if spinning && spin.instruction(vcs) {
+				err = vcs.skipSpin(&spin, colorClock)
+				if err != nil {
+					return err
+				}
+			}
 		case govern.Paused:
 		default:
 			return fmt.Errorf("vcs: unsupported emulation state (%s) in Run() function", state)
@@ -65,10 +65,17 @@ func (vcs *VCS) Plumb(state *State, fromDifferentEmulation bool) {
 	// take another snapshot of the state before plumbing. we don't want the
 	// machine to change what we have stored in our state array (we learned
 	// that lesson the hard way :-)
+	//
+	// the instruction observer and the disabling of audio belong to the
+	// emulation and not to the state
+	obs := vcs.CPU.InstructionObserver()
 	vcs.CPU = state.CPU.Snapshot()
+	vcs.CPU.SetInstructionObserver(obs)
 	vcs.Mem = state.Mem.Snapshot()
 	vcs.RIOT = state.RIOT.Snapshot()
+	audioDisabled := vcs.TIA.AudioDisabled()
 	vcs.TIA = state.TIA.Snapshot()
+	vcs.TIA.SetAudioDisabled(audioDisabled)
 
 	vcs.CPU.Plumb(vcs.Mem)
 	vcs.Mem.Plumb(vcs.Env, fromDifferentEmulation)
@@ -82,3 +89,19 @@ func (vcs *VCS) Plumb(state *State, fromDifferentEmulation bool) {
 
 	vcs.Input.Plumb(vcs.TV, vcs.RIOT.Ports)
 }
+
+// PlumbTIA replaces the TIA with a copy of a previously snapshotted TIA. The
+// other sub-systems are not affected.
+//
+// Note that the TIA registers that are stored in memory, such as the
+// collision registers, are not part of the TIA snapshot.
+func (vcs *VCS) PlumbTIA(state *tia.TIA) {
+	if state == nil {
+		panic("vcs: cannot plumb in a nil TIA state")
+	}
+
+	audioDisabled := vcs.TIA.AudioDisabled()
+	vcs.TIA = state.Snapshot()
+	vcs.TIA.SetAudioDisabled(audioDisabled)
+	vcs.TIA.Plumb(vcs.Env, vcs.TV, vcs.Mem.TIA, vcs.RIOT.Ports, vcs.CPU)
+}
@@ -0,0 +1,269 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware
+
+import (
+	"slices"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the maximum number of bytes in a spin loop. anything larger than this is
+// unlikely to be a simple wait for the timer
+const spinMaxLoopBytes = 8
+
+// the maximum number of CPU cycles in a single iteration of a spin loop
+const spinMaxLoopCycles = 16
+
+// the maximum number of CPU cycles that will be skipped at once. this is
+// approximately one NTSC frame and means that the continueCheck() function in
+// Run() is still called regularly
+const spinMaxSkip = 76 * 262
+
+// spinCycle is the bus activity of a single CPU cycle
+type spinCycle struct {
+	addr uint16
+	data uint8
+}
+
+// spinRegisters are the CPU registers at the end of an iteration of a loop
+type spinRegisters struct {
+	a, x, y, sp, status uint8
+}
+
+// spinDetector looks for tight loops in the 6507 program that are polling the
+// RIOT timer. for example:
+//
+//	wait:
+//		BIT TIMINT
+//		BPL wait
+//
+// a spin is detected when two consecutive iterations of a loop produce exactly
+// the same bus activity and leave the CPU in exactly the same state. the loop
+// can only contain read instructions and a branch back to the start of the
+// loop.
+//
+// because the value of the RIOT timer is predictable, the iterations of the
+// loop can be replayed without executing the CPU instructions until the value
+// of the polled register is about to change. replaying the iteration means
+// that the same reads are made on the same cycles and so the observable state
+// of the emulation is unchanged.
+//
+// polling loops of TIA registers are not skipped because there is no way of
+// knowing when the value of the register will change
+type spinDetector struct {
+	// the address of the first instruction in the loop. a value of zero means
+	// that there is no candidate loop
+	head uint16
+
+	// the bus activity of the current and previous iterations of the loop
+	current  []spinCycle
+	previous []spinCycle
+
+	// the instructions executed in the current and previous iterations of the
+	// loop. the instructions of a replayed iteration are reported to the CPU's
+	// instruction observer
+	currentInstructions  []execution.Result
+	previousInstructions []execution.Result
+
+	// the state of the CPU at the end of the previous iteration
+	registers spinRegisters
+
+	// the current iteration of the loop contains an instruction or bus
+	// activity that is not allowed in a spin loop
+	invalid bool
+
+	// the previous iteration was the same as the one before it
+	matched bool
+}
+
+// reset the detector so that there is no candidate loop
+func (spin *spinDetector) reset() {
+	spin.head = 0
+	spin.current = spin.current[:0]
+	spin.previous = spin.previous[:0]
+	spin.currentInstructions = spin.currentInstructions[:0]
+	spin.previousInstructions = spin.previousInstructions[:0]
+	spin.invalid = false
+	spin.matched = false
+}
+
+// cycle should be called after every CPU cycle
+func (spin *spinDetector) cycle(vcs *VCS) {
+	if spin.head == 0 {
+		return
+	}
+	if len(spin.current) >= spinMaxLoopCycles {
+		spin.invalid = true
+		return
+	}
+	spin.current = append(spin.current, spinCycle{
+		addr: vcs.Mem.AddressBus,
+		data: vcs.Mem.DataBus,
+	})
+}
+
+// spinAllowed returns true if the instruction is allowed in a spin loop. every
+// cycle of an allowed instruction is a read of the bus
+func spinAllowed(defn *instructions.Definition) bool {
+	if defn == nil {
+		return false
+	}
+	if defn.IsBranch() {
+		return true
+	}
+	if defn.Effect != instructions.Read {
+		return false
+	}
+	switch defn.AddressingMode {
+	case instructions.Immediate, instructions.ZeroPage, instructions.Absolute:
+		return true
+	}
+	return false
+}
+
+// instruction should be called after every CPU instruction. returns true if
+// the most recent iteration of the loop is a spin
+func (spin *spinDetector) instruction(vcs *VCS) bool {
+	res := vcs.CPU.LastResult
+	if !res.Final || !spinAllowed(res.Defn) {
+		spin.reset()
+		return false
+	}
+
+	// any instruction other than a backwards branch to the head of the loop
+	// continues the current iteration
+	pc := vcs.CPU.PC.Address()
+	if !res.Defn.IsBranch() || !res.BranchSuccess || pc >= res.Address || res.Address-pc > spinMaxLoopBytes {
+		if spin.head != 0 && (res.Address < spin.head || res.Address-spin.head > spinMaxLoopBytes) {
+			spin.reset()
+			return false
+		}
+		if spin.head != 0 {
+			spin.currentInstructions = append(spin.currentInstructions, res)
+		}
+		return false
+	}
+
+	// branch to the start of a new candidate loop
+	if pc != spin.head {
+		spin.reset()
+		spin.head = pc
+		return false
+	}
+
+	spin.currentInstructions = append(spin.currentInstructions, res)
+
+	registers := spinRegisters{
+		a:      vcs.CPU.A.Value(),
+		x:      vcs.CPU.X.Value(),
+		y:      vcs.CPU.Y.Value(),
+		sp:     uint8(vcs.CPU.SP.Address()),
+		status: vcs.CPU.Status.Value(),
+	}
+
+	spin.matched = !spin.invalid && len(spin.previous) > 0 &&
+		registers == spin.registers && slices.Equal(spin.current, spin.previous)
+
+	spin.registers = registers
+	spin.previous, spin.current = spin.current, spin.previous[:0]
+	spin.previousInstructions, spin.currentInstructions = spin.currentInstructions, spin.previousInstructions[:0]
+	spin.invalid = false
+
+	return spin.matched
+}
+
+// polled returns the RIOT timer register that is being polled by the spin
+// loop. returns false if the loop reads from any other register or if more
+// than one timer register is being read
+func (spin *spinDetector) polled() (cpubus.Register, bool) {
+	var reg cpubus.Register
+
+	for _, c := range spin.previous {
+		ma, area := memorymap.MapAddress(c.addr, true)
+		switch area {
+		case memorymap.Cartridge, memorymap.RAM:
+		case memorymap.RIOT:
+			r := cpubus.ReadAddress[ma]
+			if r != cpubus.INTIM && r != cpubus.TIMINT {
+				return reg, false
+			}
+			if reg != "" && reg != r {
+				return reg, false
+			}
+			reg = r
+		default:
+			return reg, false
+		}
+	}
+
+	return reg, reg != ""
+}
+
+// skipSpin replays the most recent iteration of the spin loop until the value
+// of the polled timer register is about to change. the colorClock function
+// should be the same function that is used with CPU.ExecuteInstruction()
+//
+// the CPU cycle count is advanced for every replayed cycle and the
+// instructions in every replayed iteration are reported to the CPU's
+// instruction observer, as though the CPU had executed them
+func (vcs *VCS) skipSpin(spin *spinDetector, colorClock func() error) error {
+	// the data read from a cartridge with a coprocessor may change at any time
+	if vcs.Mem.Cart.GetCoProcBus() != nil {
+		return nil
+	}
+
+	reg, ok := spin.polled()
+	if !ok {
+		return nil
+	}
+
+	// the iteration in progress when the value changes, and the one after
+	// that, are left for the CPU to execute
+	stable := min(vcs.RIOT.Timer.StableCycles(reg), spinMaxSkip)
+	iterations := stable/len(spin.previous) - 1
+
+	obs := vcs.CPU.InstructionObserver()
+
+	for range iterations {
+		for _, c := range spin.previous {
+			_, err := vcs.Mem.Read(c.addr)
+			if err != nil {
+				return err
+			}
+			err = colorClock()
+			if err != nil {
+				return err
+			}
+		}
+
+		vcs.CPU.CycleCount += len(spin.previous)
+
+		if obs != nil {
+			for _, res := range spin.previousInstructions {
+				obs.ObserveInstruction(res)
+			}
+		}
+	}
+
+	spin.current = spin.current[:0]
+	spin.currentInstructions = spin.currentInstructions[:0]
+
+	return nil
+}
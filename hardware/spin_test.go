@@ -0,0 +1,176 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a 4k ROM that sets the RIOT timer and then waits for it to expire
+func spinROM() []byte {
+	return hardwaretest.ROM([]byte{
+		0xa9, 0xff, // f000 lda #$ff
+		0x8d, 0x96, 0x02, // f002 sta TIM64T
+		0x2c, 0x85, 0x02, // f005 bit TIMINT
+		0x10, 0xfb, // f008 bpl $f005
+		0x4c, 0x0a, 0xf0, // f00a jmp $f00a
+	})
+}
+
+// the address of the instruction after the spin loop
+const spinExit = 0xf00a
+
+// newSpinVCS creates a VCS with the spin ROM attached and running in
+// fast-forward mode if requested
+func newSpinVCS(t testing.TB, detection bool, fastForward bool) *hardware.VCS {
+	t.Helper()
+
+	vcs := hardwaretest.NewVCS(t, spinROM())
+
+	err := vcs.Env.Prefs.SpinDetection.Set(detection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = vcs.TV.SetFastForward(fastForward, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return vcs
+}
+
+// runSpinLoop runs the VCS until the spin loop exits. returns the number of
+// CPU instructions that were executed
+func runSpinLoop(vcs *hardware.VCS) (int, error) {
+	var instructions int
+	err := vcs.Run(func() (govern.State, error) {
+		instructions++
+		if vcs.CPU.PC.Address() == spinExit {
+			return govern.Ending, nil
+		}
+		return govern.Running, nil
+	})
+	return instructions, err
+}
+
+// instructionCounter implements the cpu.InstructionObserver interface
+type instructionCounter struct {
+	count int
+}
+
+func (obs *instructionCounter) ObserveInstruction(_ execution.Result) {
+	obs.count++
+}
+
+// runSpin runs the spin ROM until the loop exits. returns the VCS in that state
+// and the number of CPU instructions that were executed
+func runSpin(t *testing.T, detection bool, fastForward bool) (*hardware.VCS, int) {
+	t.Helper()
+
+	vcs := newSpinVCS(t, detection, fastForward)
+	instructions, err := runSpinLoop(vcs)
+	test.DemandSuccess(t, err)
+
+	return vcs, instructions
+}
+
+func TestSpinDetection(t *testing.T) {
+	prefs.DisableSaving = true
+
+	normal, normalCount := runSpin(t, false, true)
+	spin, spinCount := runSpin(t, true, true)
+
+	// the timer is set for 255*64 cycles and each iteration of the loop is 7
+	// cycles. without spin detection every iteration is executed
+	test.ExpectSuccess(t, normalCount > 2000)
+	test.ExpectSuccess(t, spinCount < 20)
+
+	// the state of the emulation is the same in both cases
+	test.ExpectEquality(t, spin.TV.GetCoords(), normal.TV.GetCoords())
+	test.ExpectEquality(t, spin.CPU.String(), normal.CPU.String())
+	test.ExpectEquality(t, spin.RIOT.String(), normal.RIOT.String())
+	test.ExpectEquality(t, spin.TIA.String(), normal.TIA.String())
+	test.ExpectEquality(t, spin.Mem.AddressBus, normal.Mem.AddressBus)
+	test.ExpectEquality(t, spin.Mem.DataBus, normal.Mem.DataBus)
+
+	// skipped cycles are counted as though the CPU had executed them
+	test.ExpectEquality(t, spin.CPU.CycleCount, normal.CPU.CycleCount)
+
+	// spin detection has no effect when not in fast-forward mode
+	_, count := runSpin(t, true, false)
+	test.ExpectEquality(t, count, normalCount)
+}
+
+func TestSpinDetectionObserver(t *testing.T) {
+	prefs.DisableSaving = true
+
+	run := func(detection bool) (*hardware.VCS, int) {
+		vcs := newSpinVCS(t, detection, true)
+		obs := &instructionCounter{}
+		vcs.CPU.SetInstructionObserver(obs)
+		_, err := runSpinLoop(vcs)
+		test.DemandSuccess(t, err)
+		return vcs, obs.count
+	}
+
+	normal, normalCount := run(false)
+	spin, spinCount := run(true)
+
+	// the instructions in the skipped iterations are reported to the
+	// observer
+	test.ExpectSuccess(t, normalCount > 2000)
+	test.ExpectEquality(t, spinCount, normalCount)
+	test.ExpectEquality(t, spin.CPU.CycleCount, normal.CPU.CycleCount)
+}
+
+// the benchmarks measure the time taken to run the spin loop. skipping the
+// spin loop still steps the TIA and RIOT for every cycle so any improvement
+// comes only from not decoding and executing the CPU instructions
+func benchmarkSpin(b *testing.B, detection bool) {
+	prefs.DisableSaving = true
+
+	vcs := newSpinVCS(b, detection, true)
+
+	b.ResetTimer()
+	for range b.N {
+		b.StopTimer()
+		err := vcs.Reset()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		_, err = runSpinLoop(vcs)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSpinDetectionOff(b *testing.B) {
+	benchmarkSpin(b, false)
+}
+
+func BenchmarkSpinDetectionOn(b *testing.B) {
+	benchmarkSpin(b, true)
+}
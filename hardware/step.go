@@ -73,11 +73,13 @@ func (vcs *VCS) Step(colorClockCallback func(isCycle bool) error) error {
 		}
 
 		if reg, ok := vcs.Mem.TIA.ChipHasChanged(); ok {
+			vcs.observeChipWrite(reg)
 			vcs.TIA.Step(reg, 3)
 		} else {
 			vcs.TIA.QuickStep(3)
 		}
 		if reg, ok := vcs.Mem.RIOT.ChipHasChanged(); ok {
+			vcs.observeChipWrite(reg)
 			vcs.RIOT.Step(reg)
 		} else {
 			vcs.RIOT.QuickStep()
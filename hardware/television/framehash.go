@@ -0,0 +1,59 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// the 64bit FNV-1a offset basis and prime. we're implementing the hash
+// ourselves rather than using the hash/fnv package because we only want to
+// hash a single byte from each signal and calling Write() for every signal
+// would be needlessly slow
+const (
+	frameHashOffset = 14695981039346656037
+	frameHashPrime  = 1099511628211
+)
+
+// hashSignals returns a 64bit FNV-1a hash of the color signals in the signal
+// array. signals that are invalid or in the VBLANK are treated as black.
+//
+// the hash only depends on the value of the color signals and the order in
+// which they appear. it is therefore stable across runs and across platforms
+func hashSignals(sig []signal.SignalAttributes) uint64 {
+	var h uint64 = frameHashOffset
+	for _, s := range sig {
+		c := s.Color
+		if s.Index == signal.NoSignal || s.VBlank {
+			c = signal.VideoBlack
+		}
+		h ^= uint64(c)
+		h *= frameHashPrime
+	}
+	return h
+}
+
+// FrameHash returns a hash of the pixels in the most recently completed frame.
+// Suitable for comparing frames in regression tests without having to store
+// entire images.
+//
+// The hash is of the color signals sent to the television and not of any
+// rendered image. It is therefore unaffected by palette, resizing or any other
+// presentation preferences. A value of zero indicates that no frame has been
+// completed since the television was created or reset.
+func (tv *Television) FrameHash() uint64 {
+	return tv.frameHash
+}
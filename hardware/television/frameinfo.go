@@ -16,6 +16,7 @@
 package television
 
 import (
+	"encoding/json"
 	"fmt"
 	"image"
 
@@ -104,6 +105,16 @@ type FrameInfo struct {
 	// if the profile of the VBLANK bounds has changed after the Stable flag has
 	// been set then VBLANKunstable will be true
 	VBLANKunstable bool
+
+	// ForcedFrame is true if there has been no valid VSYNC signal for some
+	// time and the television is forcing frames to be the number of scanlines
+	// suggested by the specification
+	ForcedFrame bool
+
+	// CoProcFrameReady is true if the cartridge coprocessor signalled that it
+	// had completed the work for a logical frame during this frame. see the
+	// CoProcFrameReady() function of the Television type
+	CoProcFrameReady bool
 }
 
 // NewFrameInfo returns an initialised FrameInfo for the specification.
@@ -119,6 +130,99 @@ func (info FrameInfo) String() string {
 	return fmt.Sprintf("top: %d, bottom: %d, total: %d", info.VisibleTop, info.VisibleBottom, info.TotalScanlines)
 }
 
+// frameInfoJSON is the representation of FrameInfo used by MarshalJSON() and
+// UnmarshalJSON(). the field names should not be changed because the output is
+// intended to be stable
+type frameInfoJSON struct {
+	Spec           string  `json:"spec"`
+	FrameNum       int     `json:"frameNum"`
+	TotalScanlines int     `json:"totalScanlines"`
+	RefreshRate    float32 `json:"refreshRate"`
+	VisibleTop     int     `json:"visibleTop"`
+	VisibleBottom  int     `json:"visibleBottom"`
+	VBLANKtop      int     `json:"vblankTop"`
+	VBLANKbottom   int     `json:"vblankBottom"`
+	VBLANKatari    bool    `json:"vblankAtari"`
+	VBLANKunstable bool    `json:"vblankUnstable"`
+	FromVSYNC      bool    `json:"fromVSYNC"`
+	VSYNCscanline  int     `json:"vsyncScanline"`
+	VSYNCcount     int     `json:"vsyncCount"`
+	VSYNCunstable  bool    `json:"vsyncUnstable"`
+	IsSynced       bool    `json:"synced"`
+	Stable         bool    `json:"stable"`
+	Jitter         bool    `json:"jitter"`
+	ForcedFrame    bool    `json:"forcedFrame"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. Only the ID of the
+// specification is included in the output. The output is suitable for logging
+// and for comparing frame information between runs.
+func (info FrameInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(frameInfoJSON{
+		Spec:           info.Spec.ID,
+		FrameNum:       info.FrameNum,
+		TotalScanlines: info.TotalScanlines,
+		RefreshRate:    info.RefreshRate,
+		VisibleTop:     info.VisibleTop,
+		VisibleBottom:  info.VisibleBottom,
+		VBLANKtop:      info.VBLANKtop,
+		VBLANKbottom:   info.VBLANKbottom,
+		VBLANKatari:    info.VBLANKatari,
+		VBLANKunstable: info.VBLANKunstable,
+		FromVSYNC:      info.FromVSYNC,
+		VSYNCscanline:  info.VSYNCscanline,
+		VSYNCcount:     info.VSYNCcount,
+		VSYNCunstable:  info.VSYNCunstable,
+		IsSynced:       info.IsSynced,
+		Stable:         info.Stable,
+		Jitter:         info.Jitter,
+		ForcedFrame:    info.ForcedFrame,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The Spec field is
+// set to the specification named in the data.
+func (info *FrameInfo) UnmarshalJSON(data []byte) error {
+	var j frameInfoJSON
+	err := json.Unmarshal(data, &j)
+	if err != nil {
+		return fmt.Errorf("frameinfo: %w", err)
+	}
+
+	switch j.Spec {
+	case "NTSC":
+		info.Spec = specification.SpecNTSC
+	case "PAL":
+		info.Spec = specification.SpecPAL
+	case "PAL-M":
+		info.Spec = specification.SpecPAL_M
+	case "SECAM":
+		info.Spec = specification.SpecSECAM
+	default:
+		return fmt.Errorf("frameinfo: unknown specification (%s)", j.Spec)
+	}
+
+	info.FrameNum = j.FrameNum
+	info.TotalScanlines = j.TotalScanlines
+	info.RefreshRate = j.RefreshRate
+	info.VisibleTop = j.VisibleTop
+	info.VisibleBottom = j.VisibleBottom
+	info.VBLANKtop = j.VBLANKtop
+	info.VBLANKbottom = j.VBLANKbottom
+	info.VBLANKatari = j.VBLANKatari
+	info.VBLANKunstable = j.VBLANKunstable
+	info.FromVSYNC = j.FromVSYNC
+	info.VSYNCscanline = j.VSYNCscanline
+	info.VSYNCcount = j.VSYNCcount
+	info.VSYNCunstable = j.VSYNCunstable
+	info.IsSynced = j.IsSynced
+	info.Stable = j.Stable
+	info.Jitter = j.Jitter
+	info.ForcedFrame = j.ForcedFrame
+
+	return nil
+}
+
 // Crop returns an image.Rectangle for the cropped region of the screen. Using
 // this is preferrable than using the VisibleTop/Bottom fields to construct the rectangle
 //
@@ -20,7 +20,31 @@ import (
 	"time"
 )
 
+// Clock is the source of time for the limiter. The real time clock is used
+// unless a different clock is specified with SetClock().
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// realClock implements the Clock interface using the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// the amount of time between each measurement of the actual frame rate
+const measuringInterval = time.Second
+
 type Limiter struct {
+	// source of time for limiting and measurement
+	clock Clock
+
 	// whether to wait for fps limited each frame
 	Active bool
 
@@ -36,18 +60,16 @@ type Limiter struct {
 	// whether the requested frame rate is equal to the refresh rate
 	MatchRefreshRate atomic.Value // bool
 
-	// pulse that performs the limiting. the duration of the ticker will be set
-	// when the frame rate changes.
-	pulse *time.Ticker
+	// the limiting is performed by waiting for a pulse. the duration of the
+	// pulse will be set when the frame rate changes
+	pulseDuration time.Duration
+	nextPulse     time.Time
 
 	// we don't want to measure the frame rate too often because it's
 	// relatively expensive. a simple counter is an effective limiter
 	pulseCt      int
 	pulseCtLimit int
 
-	// pulse that performs the FPS measurement
-	measuringPulse *time.Ticker
-
 	// the measured FPS is the number of frames divided by the amount of
 	// elapsed time since the previous measurement
 	measureTime time.Time
@@ -80,19 +102,32 @@ type Limiter struct {
 // the refresh rate.
 func NewLimiter() *Limiter {
 	lmtr := Limiter{}
+	lmtr.clock = realClock{}
 	lmtr.Active = true
 	lmtr.MatchRefreshRate.Store(false)
 	lmtr.Measured.Store(float32(0.0))
 
-	lmtr.pulse = time.NewTicker(time.Millisecond * 16)
-	lmtr.measuringPulse = time.NewTicker(time.Millisecond * 1000)
-
 	lmtr.SetRefreshRate(60)
 	lmtr.SetLimit(-1)
 
 	return &lmtr
 }
 
+// SetClock changes the source of time used by the limiter. A value of nil
+// will restore the real time clock. Measurement of the actual frame rate is
+// restarted.
+func (lmtr *Limiter) SetClock(clk Clock) {
+	if clk == nil {
+		clk = realClock{}
+	}
+	lmtr.clock = clk
+
+	lmtr.pulseCt = 0
+	lmtr.nextPulse = lmtr.clock.Now().Add(lmtr.pulseDuration)
+	lmtr.measureCt = 0
+	lmtr.measureTime = lmtr.clock.Now()
+}
+
 // Set the refresh rate for the limiter. This is equivalent to the refresh rate
 // of the television. It is distinict from the limit value but is related and
 // the limit value (see SetLimit() function) will usually equal the refresh rate
@@ -127,12 +162,25 @@ func (lmtr *Limiter) SetLimit(fps float32) {
 	// set scale and duration to wait according to requested FPS rate
 	lmtr.pulseCt = 0
 	lmtr.pulseCtLimit = 1 + int(fps/20)
-	lmtr.pulse.Stop()
-	lmtr.pulse.Reset(time.Duration(1000000000 / fps * float32(lmtr.pulseCtLimit)))
+	lmtr.pulseDuration = time.Duration(1000000000 / fps * float32(lmtr.pulseCtLimit))
+	lmtr.nextPulse = lmtr.clock.Now().Add(lmtr.pulseDuration)
 
 	// restart acutal FPS rate measurement values
 	lmtr.measureCt = 0
-	lmtr.measureTime = time.Now()
+	lmtr.measureTime = lmtr.clock.Now()
+}
+
+// wait for the next pulse. if the time of the next pulse has already passed
+// then there is no wait and the pulse after that is measured from the current
+// time
+func (lmtr *Limiter) wait() {
+	now := lmtr.clock.Now()
+	if now.Before(lmtr.nextPulse) {
+		lmtr.clock.Sleep(lmtr.nextPulse.Sub(now))
+		lmtr.nextPulse = lmtr.nextPulse.Add(lmtr.pulseDuration)
+	} else {
+		lmtr.nextPulse = now.Add(lmtr.pulseDuration)
+	}
 }
 
 // CheckFrame should be called every frame.
@@ -147,7 +195,7 @@ func (lmtr *Limiter) CheckFrame() {
 			lmtr.pulseCt++
 			if lmtr.pulseCt >= lmtr.pulseCtLimit {
 				lmtr.pulseCt = 0
-				<-lmtr.pulse.C
+				lmtr.wait()
 			}
 		}
 	}
@@ -165,20 +213,21 @@ func (lmtr *Limiter) CheckFrame() {
 func (lmtr *Limiter) CheckScanline() {
 }
 
-// MeasureActual measures frame rate on every tick of the measuringPulse ticker.
-// callers of MeasureActual() should be mindful of how ofter the function is
-// called, regardless of the throttle provided by the measuring pulse - checking
-// the pulse channel is itself expensive.
+// MeasureActual measures frame rate once every measuring interval. callers of
+// MeasureActual() should be mindful of how ofter the function is called,
+// regardless of the throttle provided by the measuring interval - checking the
+// clock is itself expensive.
 func (lmtr *Limiter) MeasureActual() {
-	select {
-	case <-lmtr.measuringPulse.C:
-		t := time.Now()
-		m := float32(lmtr.measureCt) / float32(t.Sub(lmtr.measureTime).Seconds())
-		lmtr.Measured.Store(m)
-
-		// reset time and count ready for next measurement
-		lmtr.measureTime = t
-		lmtr.measureCt = 0
-	default:
+	t := lmtr.clock.Now()
+	elapsed := t.Sub(lmtr.measureTime)
+	if elapsed < measuringInterval {
+		return
 	}
+
+	m := float32(lmtr.measureCt) / float32(elapsed.Seconds())
+	lmtr.Measured.Store(m)
+
+	// reset time and count ready for next measurement
+	lmtr.measureTime = t
+	lmtr.measureCt = 0
 }
@@ -17,6 +17,7 @@ package limiter_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jetsetilly/gopher2600/hardware/television/limiter"
 	"github.com/jetsetilly/gopher2600/test"
@@ -59,3 +60,62 @@ func TestTicker(t *testing.T) {
 	rate = lmtr.Measured.Load().(float32)
 	test.ExpectSuccess(t, rate >= hz*(1.0-measurementTolerance) && rate <= hz*(1.0+measurementTolerance))
 }
+
+// fakeClock implements the limiter.Clock interface. time only advances when
+// Sleep() or advance() is called
+type fakeClock struct {
+	now time.Time
+}
+
+func (clk *fakeClock) Now() time.Time {
+	return clk.now
+}
+
+func (clk *fakeClock) Sleep(d time.Duration) {
+	clk.now = clk.now.Add(d)
+}
+
+func (clk *fakeClock) advance(d time.Duration) {
+	clk.now = clk.now.Add(d)
+}
+
+func TestFakeClock(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+
+	lmtr := limiter.NewLimiter()
+	lmtr.SetClock(clk)
+
+	// frames take no time to generate so the limiter sleeps for the entire
+	// frame. the measured rate will match the requested rate
+	for _, hz := range []float32{60.0, 50.0, 30.0} {
+		lmtr.SetLimit(hz)
+		for range int(hz * numFramesPerTest) {
+			lmtr.CheckFrame()
+			lmtr.MeasureActual()
+		}
+		rate := lmtr.Measured.Load().(float32)
+		test.ExpectSuccess(t, rate >= hz*(1.0-measurementTolerance) && rate <= hz*(1.0+measurementTolerance))
+	}
+
+	// with the limiter inactive the measured rate depends only on how long it
+	// takes to generate each frame
+	lmtr.Active = false
+	lmtr.SetLimit(60.0)
+	for range 4 * numFramesPerTest {
+		clk.advance(time.Second / 4)
+		lmtr.CheckFrame()
+		lmtr.MeasureActual()
+	}
+	test.ExpectEquality(t, lmtr.Measured.Load().(float32), float32(4.0))
+
+	// frames that take longer to generate than the limit allows are not slowed
+	// down any further by the limiter
+	lmtr.Active = true
+	lmtr.SetLimit(60.0)
+	for range 10 * numFramesPerTest {
+		clk.advance(time.Second / 10)
+		lmtr.CheckFrame()
+		lmtr.MeasureActual()
+	}
+	test.ExpectEquality(t, lmtr.Measured.Load().(float32), float32(10.0))
+}
@@ -0,0 +1,83 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// SetPalette sets the colors to use for the specification. The palette should
+// be created with specification.ReadPalette(). A nil palette restores the
+// built-in palette of the specification.
+//
+// The palette belongs to the television instance and does not affect any other
+// television. Nor is it part of the television state and so is unaffected by
+// the rewind system.
+func (tv *Television) SetPalette(id string, palette []color.RGBA) error {
+	spec, ok := builtinSpec(id)
+	if !ok {
+		return fmt.Errorf("palette: no specification (%s)", id)
+	}
+
+	if palette == nil {
+		delete(tv.palettes, id)
+	} else {
+		if len(palette) != len(spec.Colors) {
+			return fmt.Errorf("palette: palette for %s should have %d colors", id, len(spec.Colors))
+		}
+		if tv.palettes == nil {
+			tv.palettes = make(map[string][]color.RGBA)
+		}
+		tv.palettes[id] = palette
+	}
+
+	tv.applyPalette()
+
+	return nil
+}
+
+// applyPalette makes sure the specification in the television state is using
+// the correct palette. must be called whenever the specification in the state
+// is replaced
+func (tv *Television) applyPalette() {
+	id := tv.state.frameInfo.Spec.ID
+	if p, ok := tv.palettes[id]; ok {
+		tv.state.frameInfo.Spec.Colors = p
+		return
+	}
+	if spec, ok := builtinSpec(id); ok {
+		tv.state.frameInfo.Spec.Colors = spec.Colors
+	}
+}
+
+// builtinSpec returns the specification for the ID. the PAL60 request ID is
+// not a specification in its own right and is not recognised
+func builtinSpec(id string) (specification.Spec, bool) {
+	switch id {
+	case "NTSC":
+		return specification.SpecNTSC, true
+	case "PAL":
+		return specification.SpecPAL, true
+	case "PAL-M":
+		return specification.SpecPAL_M, true
+	case "SECAM":
+		return specification.SpecSECAM, true
+	}
+	return specification.Spec{}, false
+}
@@ -108,6 +108,17 @@ type PixelRendererFPSCap interface {
 	SetFPSCap(limit bool)
 }
 
+// SignalPostProcessor implementations can modify the signals before they are
+// forwarded to the PixelRenderers. For example, to blend the colour of
+// adjacent pixels and so approximate the artifacts of a composite signal.
+//
+// The signals are a copy and have the same layout as the signals sent to
+// SetPixels(). Changes to the signals do not affect audio mixing or the frame
+// hash.
+type SignalPostProcessor interface {
+	PostProcess(sig []signal.SignalAttributes, current int) error
+}
+
 // FrameTrigger implementations listen for Pause events
 type PauseTrigger interface {
 	Pause(pause bool) error
@@ -0,0 +1,121 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// FrameLayout describes the number of scanlines in each part of a frame
+// produced by the SignalGenerator.
+type FrameLayout struct {
+	VSync        int
+	VBlankTop    int
+	Visible      int
+	VBlankBottom int
+}
+
+// Scanlines returns the total number of scanlines in the layout.
+func (l FrameLayout) Scanlines() int {
+	return l.VSync + l.VBlankTop + l.Visible + l.VBlankBottom
+}
+
+// Frame layouts that match the ideal frame for the NTSC and PAL
+// specifications.
+var (
+	LayoutNTSC = FrameLayout{VSync: 3, VBlankTop: 37, Visible: 192, VBlankBottom: 30}
+	LayoutPAL  = FrameLayout{VSync: 3, VBlankTop: 45, Visible: 228, VBlankBottom: 36}
+)
+
+// SignalGenerator produces sequences of signal.SignalAttributes and sends them
+// to the Television. It allows the television to be tested without a TIA.
+//
+// The generator does not produce an HSYNC signal. The television will rely on
+// its own clock count for the end of each scanline.
+type SignalGenerator struct {
+	tv *Television
+
+	// the color of pixels in the visible part of the scanline
+	Color signal.ColorSignal
+}
+
+// NewSignalGenerator is the preferred method of initialisation for the
+// SignalGenerator type. If the television is not yet attached to a VCS then it
+// will be given an environment with default preferences and will be reset.
+func NewSignalGenerator(tv *Television) (*SignalGenerator, error) {
+	if tv.env == nil {
+		env, err := environment.NewEnvironment(environment.MainEmulation, tv, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("television: signal generator: %w", err)
+		}
+		tv.AttachVCS(env, nil)
+
+		err = tv.Reset(false)
+		if err != nil {
+			return nil, fmt.Errorf("television: signal generator: %w", err)
+		}
+	}
+
+	return &SignalGenerator{
+		tv:    tv,
+		Color: signal.VideoBlack,
+	}, nil
+}
+
+// Scanline sends a single scanline to the television with the VSYNC and
+// VBLANK signals set as specified. Pixels in the visible part of the scanline
+// are set to the generator's Color unless VBLANK is set.
+func (gen *SignalGenerator) Scanline(vsync bool, vblank bool) {
+	for clk := range specification.ClksScanline {
+		sig := signal.SignalAttributes{
+			VSync:  vsync,
+			VBlank: vblank,
+			Color:  signal.VideoBlack,
+		}
+		if !vblank && clk >= specification.ClksHBlank {
+			sig.Color = gen.Color
+		}
+		gen.tv.Signal(sig)
+	}
+}
+
+// Scanlines sends n scanlines to the television. See Scanline() for details.
+func (gen *SignalGenerator) Scanlines(n int, vsync bool, vblank bool) {
+	for range n {
+		gen.Scanline(vsync, vblank)
+	}
+}
+
+// Frame sends a complete frame to the television with the specified layout.
+// The frame ends with the VSYNC scanlines because the television considers
+// the first scanline after VSYNC to be the start of a new frame.
+func (gen *SignalGenerator) Frame(layout FrameLayout) {
+	gen.Scanlines(layout.VBlankTop, false, true)
+	gen.Scanlines(layout.Visible, false, false)
+	gen.Scanlines(layout.VBlankBottom, false, true)
+	gen.Scanlines(layout.VSync, true, true)
+}
+
+// Frames sends n frames to the television with the specified layout.
+func (gen *SignalGenerator) Frames(n int, layout FrameLayout) {
+	for range n {
+		gen.Frame(layout)
+	}
+}
@@ -17,13 +17,13 @@ package specification
 
 import "image/color"
 
-// PaletteNTSC is the collection of NTSC colours.
+// PaletteNTSC is the collection of built-in NTSC colours.
 var PaletteNTSC = []color.RGBA{}
 
-// PalettePAL is the collection of PAL colours.
+// PalettePAL is the collection of built-in PAL colours.
 var PalettePAL = []color.RGBA{}
 
-// PaletteSECAM is the collection of SECAM colours.
+// PaletteSECAM is the collection of built-in SECAM colours.
 var PaletteSECAM = []color.RGBA{}
 
 // VideoBlack is the color produced by a television in the absence of a color
@@ -0,0 +1,56 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package specification
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// PaletteEntries is the number of distinct colors in a palette. The
+// ColorSignal values sent by the TIA use only seven bits, meaning that each
+// palette entry is used for two consecutive ColorSignal values.
+const PaletteEntries = 128
+
+// the number of bytes in a palette file
+const paletteFileSize = PaletteEntries * 3
+
+// ReadPalette reads a palette from the io.Reader. The palette data must be
+// 128 entries of three bytes, the red, green and blue components of each color
+// in turn.
+//
+// The returned colors are arranged in the same way as the Colors field of the
+// Spec type. The palette is applied to a television with the SetPalette()
+// function in the television package. The colors of the Spec are never
+// changed.
+func ReadPalette(r io.Reader) ([]color.RGBA, error) {
+	data := make([]byte, paletteFileSize)
+	_, err := io.ReadFull(r, data)
+	if err != nil {
+		return nil, fmt.Errorf("palette: palette data should be %d bytes: %w", paletteFileSize, err)
+	}
+
+	palette := make([]color.RGBA, 0, PaletteEntries*2)
+	for i := range PaletteEntries {
+		col := color.RGBA{data[i*3], data[i*3+1], data[i*3+2], 255}
+
+		// repeat color twice in palette
+		palette = append(palette, col, col)
+	}
+
+	return palette, nil
+}
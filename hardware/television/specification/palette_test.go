@@ -0,0 +1,55 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package specification_test
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestReadPalette(t *testing.T) {
+	// custom palette with a single known color at entry 5
+	data := make([]byte, specification.PaletteEntries*3)
+	data[15] = 0x12
+	data[16] = 0x34
+	data[17] = 0x56
+	custom := color.RGBA{0x12, 0x34, 0x56, 255}
+
+	palette, err := specification.ReadPalette(bytes.NewReader(data))
+	test.DemandSuccess(t, err)
+	test.ExpectEquality(t, len(palette), len(specification.SpecNTSC.Colors))
+
+	// both color signals for the palette entry use the custom color
+	spec := specification.SpecNTSC
+	spec.Colors = palette
+	test.ExpectEquality(t, spec.GetColor(signal.ColorSignal(10)), custom)
+	test.ExpectEquality(t, spec.GetColor(signal.ColorSignal(11)), custom)
+
+	// video black is unaffected by the palette
+	test.ExpectEquality(t, spec.GetColor(signal.VideoBlack), specification.VideoBlack)
+
+	// the specification itself is unchanged
+	test.ExpectEquality(t, specification.SpecNTSC.GetColor(signal.ColorSignal(10)), specification.PaletteNTSC[10])
+
+	// incomplete palette data
+	_, err = specification.ReadPalette(bytes.NewReader(data[:100]))
+	test.ExpectFailure(t, err)
+}
@@ -17,6 +17,10 @@ package television
 
 import (
 	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"time"
 
 	"github.com/jetsetilly/gopher2600/debugger/govern"
 	"github.com/jetsetilly/gopher2600/environment"
@@ -35,6 +39,10 @@ const leadingFrames = 1
 // stable. once the tv is stable then specification switching cannot happen.
 const stabilityThreshold = 6
 
+// the number of consecutive frames without a valid VSYNC signal before the
+// television forces frames to be of a fixed number of scanlines
+const forcedFrameThreshold = 10
+
 // State encapsulates the television values that can change from moment to
 // moment. Used by the rewind system when recording the current television
 // state.
@@ -74,6 +82,10 @@ type State struct {
 	// latch to say if next flyback was a result of VSYNC or not
 	fromVSYNC bool
 
+	// the number of consecutive frames that have not been started as a result
+	// of a valid VSYNC signal
+	framesWithoutVSYNC int
+
 	// frame resizer
 	resizer Resizer
 
@@ -139,6 +151,46 @@ func (s *State) GetReqSpecID() string {
 	return s.reqSpecID
 }
 
+// GetVisiblePixels returns the color signals for the visible portion of the
+// screen, as defined by the current FrameInfo, along with the width and height
+// of the visible area. The first entry in the returned slice is the top-left
+// visible pixel.
+//
+// Signals are taken from the television's signal buffer. If the current frame
+// is incomplete then signals not yet reached in the current frame will be
+// those from the previous frame.
+func (tv *Television) GetVisiblePixels() ([]signal.ColorSignal, int, int) {
+	crop := tv.state.frameInfo.Crop()
+	width := crop.Dx()
+	height := crop.Dy()
+
+	pixels := make([]signal.ColorSignal, 0, width*height)
+	for y := crop.Min.Y; y < crop.Max.Y; y++ {
+		i := y*specification.ClksScanline + crop.Min.X
+		for _, sig := range tv.signals[i : i+width] {
+			pixels = append(pixels, sig.Color)
+		}
+	}
+
+	return pixels, width, height
+}
+
+// GetScanlineSignals returns a copy of the signals for the specified scanline.
+// The returned slice will have specification.ClksScanline entries, indexed by
+// the clock. Returns nil if the scanline is out of range.
+//
+// As with GetVisiblePixels(), if the scanline has not yet been reached in the
+// current frame then the signals will be those from the previous frame.
+func (tv *Television) GetScanlineSignals(scanline int) []signal.SignalAttributes {
+	if scanline < 0 || scanline >= specification.AbsoluteMaxScanlines {
+		return nil
+	}
+	i := scanline * specification.ClksScanline
+	sigs := make([]signal.SignalAttributes, specification.ClksScanline)
+	copy(sigs, tv.signals[i:i+specification.ClksScanline])
+	return sigs
+}
+
 // GetLastSignal returns a copy of the most SignalAttributes sent to the TV
 // (via the Signal() function).
 func (s *State) GetLastSignal() signal.SignalAttributes {
@@ -195,6 +247,12 @@ type Television struct {
 	// realtime mixer. only one allowed
 	realtimeMixer RealtimeAudioMixer
 
+	// post-processing of signals before they are forwarded to the pixel
+	// renderers. only one allowed. the processed signals are kept separate
+	// from the signals array so that audio and the frame hash are unaffected
+	postProcessor    SignalPostProcessor
+	processedSignals []signal.SignalAttributes
+
 	// instance of current state (as supported by the rewind system)
 	state *State
 
@@ -229,8 +287,60 @@ type Television struct {
 	prevSignalLastIdx int
 	prevSignalFirst   int
 
+	// hash of the signals in the most recently completed frame. see
+	// FrameHash() function
+	frameHash uint64
+
+	// whether the cartridge coprocessor has signalled that it has completed
+	// the work for the current frame. see CoProcFrameReady() function
+	coprocFrameReady bool
+
 	// state of emulation
 	emulationState govern.State
+
+	// fast-forward state. see SetFastForward() function
+	fastForward fastForward
+
+	// recent changes to the emulation state. see StateTransitions() function
+	transitions []StateTransition
+
+	// the number of frames that have ended with a VSYNC driven flyback and the
+	// number that have ended with a natural flyback. these counts are not part
+	// of the television state because they should not be affected by the
+	// rewind system. see FlybackCounts() function
+	flybackVSYNC   int
+	flybackNatural int
+
+	// custom palettes keyed by specification ID. the palettes are not part of
+	// the television state for the same reason as the flyback counts. see
+	// SetPalette() function
+	palettes map[string][]color.RGBA
+}
+
+// the maximum number of entries in the state transitions log
+const maxStateTransitions = 32
+
+// StateTransition records a change of emulation state, as notified by the
+// SetEmulationState() function.
+type StateTransition struct {
+	From govern.State
+	To   govern.State
+	Time time.Time
+}
+
+func (t StateTransition) String() string {
+	return fmt.Sprintf("%s %s -> %s", t.Time.Format("15:04:05.000"), t.From, t.To)
+}
+
+// fastForward records the fast-forward settings of the television
+type fastForward struct {
+	active bool
+
+	// the number of frames between calls to the pixel renderers
+	renderInterval int
+
+	// the FPS cap setting before fast-forward began
+	prevCap bool
 }
 
 // NewTelevision creates a new instance of the television type, satisfying the
@@ -248,6 +358,8 @@ func NewTelevision(spec string) (*Television, error) {
 		},
 		signals:     make([]signal.SignalAttributes, specification.AbsoluteMaxClks),
 		prevSignals: make([]signal.SignalAttributes, specification.AbsoluteMaxClks),
+
+		processedSignals: make([]signal.SignalAttributes, specification.AbsoluteMaxClks),
 	}
 
 	// initialise frame rate limiter
@@ -285,6 +397,7 @@ func (tv *Television) Reset(keepFrameNum bool) error {
 	tv.state.stableFrames = 0
 	tv.state.vsync.reset()
 	tv.state.fromVSYNC = false
+	tv.state.framesWithoutVSYNC = 0
 	tv.state.lastSignal = signal.SignalAttributes{
 		Index: signal.NoSignal,
 	}
@@ -296,6 +409,8 @@ func (tv *Television) Reset(keepFrameNum bool) error {
 	}
 	tv.currentSignalIdx = 0
 	tv.firstSignalIdx = 0
+	tv.frameHash = 0
+	tv.coprocFrameReady = false
 
 	tv.setRefreshRate(tv.state.frameInfo.Spec.RefreshRate)
 	tv.state.resizer.reset(tv.state.frameInfo.Spec)
@@ -328,6 +443,7 @@ func (tv *Television) Plumb(vcs VCS, state *State) {
 	}
 
 	tv.state = state.Snapshot()
+	tv.applyPalette()
 
 	// make sure vcs knows about current spec
 	tv.vcs = vcs
@@ -378,6 +494,13 @@ func (tv *Television) RemovePixelRenderer(r PixelRenderer) {
 	}
 }
 
+// CoProcFrameReady implements the mapper.CartFrameReadyHook interface. The
+// signal is latched until the end of the frame and forwarded to FrameTrigger
+// implementations in the CoProcFrameReady field of FrameInfo.
+func (tv *Television) CoProcFrameReady() {
+	tv.coprocFrameReady = true
+}
+
 // AddFrameTrigger adds an implementation of FrameTrigger.
 func (tv *Television) AddFrameTrigger(f FrameTrigger) {
 	for i := range tv.frameTriggers {
@@ -456,6 +579,18 @@ func (tv *Television) RemoveRealtimeAudioMixer() {
 	tv.realtimeMixer = nil
 }
 
+// AddSignalPostProcessor adds a SignalPostProcessor. Any previous assignment
+// is lost.
+func (tv *Television) AddSignalPostProcessor(p SignalPostProcessor) {
+	tv.postProcessor = p
+}
+
+// RemoveSignalPostProcessor removes any SignalPostProcessor implementation
+// from the Television.
+func (tv *Television) RemoveSignalPostProcessor() {
+	tv.postProcessor = nil
+}
+
 // some televisions may need to conclude and/or dispose of resources
 // gently. implementations of End() should call EndRendering() and
 // EndMixing() on each PixelRenderer and AudioMixer that has been added.
@@ -706,7 +841,7 @@ func (tv *Television) newScanline() error {
 
 	// check for realtime mixing requirements. if it is required then
 	// immediately push the audio data from the previous frame to the mixer
-	if tv.realtimeMixer != nil && tv.emulationState == govern.Running && tv.state.frameInfo.Stable {
+	if tv.realtimeMixer != nil && tv.emulationState == govern.Running && tv.state.frameInfo.Stable && !tv.fastForward.active {
 		if tv.realtimeMixer.MoreAudio() {
 			err := tv.realtimeMixer.SetAudio(tv.prevSignals[:tv.prevSignalLastIdx])
 			if err != nil {
@@ -757,6 +892,11 @@ func (tv *Television) newFrame() error {
 			if tv.state.reqSpecID == "AUTO" && tv.state.scanline <= specification.PALTrigger {
 				tv.setSpec("NTSC")
 			}
+		case specification.SpecSECAM.ID:
+			// SECAM is never selected automatically and so is never changed
+			// automatically either. the colour encoding of SECAM is very
+			// different to PAL and NTSC and it would be wrong to switch
+			// specification based on the number of scanlines alone
 		}
 	}
 
@@ -817,6 +957,16 @@ func (tv *Television) newFrame() error {
 		}
 	}
 
+	// count flyback type. frames that are being replayed by the rewind system
+	// are not counted
+	if tv.emulationState != govern.Rewinding {
+		if tv.state.fromVSYNC {
+			tv.flybackVSYNC++
+		} else {
+			tv.flybackNatural++
+		}
+	}
+
 	// note VSYNC information and update VSYNC history
 	tv.state.frameInfo.FromVSYNC = tv.state.fromVSYNC
 	tv.state.frameInfo.IsSynced = tv.state.vsync.isSynced()
@@ -826,13 +976,29 @@ func (tv *Television) newFrame() error {
 
 	// desynchronise if we've not seen a valid VSYNC signal immediately before
 	// this call to newFrame()
-	if !tv.state.fromVSYNC {
-		tv.state.vsync.desync(specification.AbsoluteMaxScanlines)
+	//
+	// if there has been no VSYNC signal for some time then the frame is forced
+	// to be the number of scanlines suggested by the specification. this means
+	// that the frame will at least be stable for ROMs that never send a VSYNC
+	if tv.state.fromVSYNC {
+		tv.state.framesWithoutVSYNC = 0
+	} else {
+		tv.state.framesWithoutVSYNC++
+		if tv.state.framesWithoutVSYNC >= forcedFrameThreshold {
+			tv.state.vsync.flybackScanline = tv.state.frameInfo.Spec.ScanlinesTotal
+		} else {
+			tv.state.vsync.desync(specification.AbsoluteMaxScanlines)
+		}
 	}
+	tv.state.frameInfo.ForcedFrame = tv.state.framesWithoutVSYNC >= forcedFrameThreshold
 
 	// reset fromVSYNC latch
 	tv.state.fromVSYNC = false
 
+	// note whether the coprocessor signalled frame completion and reset latch
+	tv.state.frameInfo.CoProcFrameReady = tv.coprocFrameReady
+	tv.coprocFrameReady = false
+
 	// prepare for next frame
 	tv.state.frameNum++
 	tv.state.scanline = 0
@@ -850,8 +1016,16 @@ func (tv *Television) newFrame() error {
 		}
 	}
 
-	// set pending pixels
-	err = tv.renderSignals()
+	// the signals array now contains the completed frame
+	tv.frameHash = hashSignals(tv.signals)
+
+	// set pending pixels. when fast-forwarding the pixel renderers are only
+	// called every renderInterval frames but audio is always mixed
+	if tv.fastForward.active && tv.state.frameNum%tv.fastForward.renderInterval != 0 {
+		err = tv.mixAudio()
+	} else {
+		err = tv.renderSignals()
+	}
 	if err != nil {
 		return err
 	}
@@ -889,8 +1063,20 @@ func (tv *Television) newFrame() error {
 func (tv *Television) renderSignals() error {
 	// do not render pixels if emulation is in the rewinding state
 	if tv.emulationState != govern.Rewinding {
+		sig := tv.signals
+
+		// post-process a copy of the signals if a processor has been added
+		if tv.postProcessor != nil && len(tv.renderers) > 0 {
+			copy(tv.processedSignals, tv.signals)
+			err := tv.postProcessor.PostProcess(tv.processedSignals, tv.currentSignalIdx)
+			if err != nil {
+				return fmt.Errorf("television: %w", err)
+			}
+			sig = tv.processedSignals
+		}
+
 		for _, r := range tv.renderers {
-			err := r.SetPixels(tv.signals, tv.currentSignalIdx)
+			err := r.SetPixels(sig, tv.currentSignalIdx)
 			if err != nil {
 				return fmt.Errorf("television: %w", err)
 			}
@@ -898,7 +1084,12 @@ func (tv *Television) renderSignals() error {
 	}
 
 	// ... but we do mix audio even if the emulation is rewinding
+	return tv.mixAudio()
+}
 
+// mixAudio forwards audio in the signalHistory buffer to all audio mixers.
+// the realtime mixer is not used when the television is fast-forwarding
+func (tv *Television) mixAudio() error {
 	// update realtime mixers
 	//
 	// an additional condition saying the realtimeMixer is used only once the
@@ -907,7 +1098,7 @@ func (tv *Television) renderSignals() error {
 	// never output. in particular, the tunabit demo ROM.
 	//
 	// https://atariage.com/forums/topic/274172-tiatune-tia-music-player-with-correct-tuning/
-	if tv.realtimeMixer != nil {
+	if tv.realtimeMixer != nil && !tv.fastForward.active {
 		err := tv.realtimeMixer.SetAudio(tv.signals[tv.firstSignalIdx:tv.currentSignalIdx])
 		if err != nil {
 			return fmt.Errorf("television: %w", err)
@@ -951,8 +1142,26 @@ func (tv *Television) SetSpec(spec string, forced bool) error {
 	return nil
 }
 
+// Stabilise sets the television as being stable without waiting for the
+// stabilityThreshold number of synchronised frames. The next frame will report
+// as stable if the television is synchronised at that point.
+//
+// An error is returned if the television is in the period where the
+// specification might be changed as a result of the AUTO specification
+// request. Note that stabilising the television before that period means that
+// the specification will not be changed automatically.
+func (tv *Television) Stabilise() error {
+	if tv.state.reqSpecID == "AUTO" && tv.state.stableFrames > leadingFrames && tv.state.stableFrames < stabilityThreshold {
+		return fmt.Errorf("television: cannot stabilise during automatic specification detection")
+	}
+	tv.state.stableFrames = stabilityThreshold
+	tv.state.frameInfo.Stable = true
+	return nil
+}
+
 func (tv *Television) setSpec(spec string) {
 	tv.state.setSpec(spec)
+	tv.applyPalette()
 	tv.setRefreshRate(tv.state.frameInfo.Spec.RefreshRate)
 }
 
@@ -971,6 +1180,16 @@ func (tv *Television) SetEmulationState(state govern.State) error {
 	prev := tv.emulationState
 	tv.emulationState = state
 
+	// log transition. the oldest entry is dropped if the log is full
+	if len(tv.transitions) >= maxStateTransitions {
+		tv.transitions = tv.transitions[1:]
+	}
+	tv.transitions = append(tv.transitions, StateTransition{
+		From: prev,
+		To:   state,
+		Time: time.Now(),
+	})
+
 	switch prev {
 	case govern.Paused:
 		// start off the unpaused state by measuring the current framerate.
@@ -993,6 +1212,22 @@ func (tv *Television) SetEmulationState(state govern.State) error {
 	return nil
 }
 
+// StateTransitions returns a copy of the most recent emulation state
+// transitions, oldest first. The log is bounded and only the most recent
+// transitions are returned.
+func (tv *Television) StateTransitions() []StateTransition {
+	return slices.Clone(tv.transitions)
+}
+
+// FlybackCounts returns the number of frames that have ended because of a
+// VSYNC signal and the number of frames that have ended with a natural flyback
+// (ie. without a VSYNC signal). The counts are for the lifetime of the
+// television and a high number of natural flybacks can indicate a ROM that is
+// prone to rolling.
+func (tv *Television) FlybackCounts() (vsync int, natural int) {
+	return tv.flybackVSYNC, tv.flybackNatural
+}
+
 // NudgeFPSCap stops the FPS limiter for the specified number of frames. A value
 // of zero (or less) will stop any existing nudge
 func (tv *Television) NudgeFPSCap(frames int) {
@@ -1018,6 +1253,42 @@ func (tv *Television) SetFPSCap(limit bool) bool {
 	return prev
 }
 
+// SetFastForward starts or stops fast-forwarding. While fast-forwarding the FPS
+// cap is disabled and the realtime audio mixer is not used. Regular audio
+// mixers are unaffected.
+//
+// The renderInterval argument specifies how often the pixel renderers are
+// called. For example, a value of 10 means that the pixel renderers are called
+// once every ten frames. A value of one or less means that every frame is
+// rendered. The argument is ignored when stopping fast-forward.
+//
+// The FPS cap setting from before fast-forward began is restored when
+// fast-forward stops.
+func (tv *Television) SetFastForward(enable bool, renderInterval int) error {
+	if enable {
+		if !tv.fastForward.active {
+			tv.fastForward.prevCap = tv.SetFPSCap(false)
+		}
+		tv.fastForward.active = true
+		tv.fastForward.renderInterval = max(renderInterval, 1)
+		return nil
+	}
+
+	if !tv.fastForward.active {
+		return nil
+	}
+	tv.fastForward.active = false
+	tv.SetFPSCap(tv.fastForward.prevCap)
+
+	// make sure the pixel renderers are up to date
+	return tv.renderSignals()
+}
+
+// IsFastForward returns true if the television is currently fast-forwarding.
+func (tv *Television) IsFastForward() bool {
+	return tv.fastForward.active
+}
+
 // SetFPS requests the number frames per second. This overrides the frame rate of
 // the specification. A negative value restores frame rate to the ideal value
 // (the frequency of the incoming signal).
@@ -1077,6 +1348,45 @@ func (tv *Television) GetCoords() coords.TelevisionCoords {
 	return tv.state.GetCoords()
 }
 
+// BeamPosition returns the position of the TV beam in visible pixel
+// coordinates. The visible area of the screen is as described by the Crop()
+// function of FrameInfo. If the beam is currently outside of the visible area,
+// either because it is in the horizontal blank or it is above or below the
+// visible scanlines, then the visible return value is false. The x and y
+// values are still meaningful in that case but will be negative or larger
+// than the visible area.
+//
+// Like all Television functions this function is not safe to call from
+// goroutines other than the one that created the Television.
+func (tv *Television) BeamPosition() (x, y int, visible bool) {
+	crop := tv.state.frameInfo.Crop()
+	x = tv.state.clock - crop.Min.X
+	y = tv.state.scanline - crop.Min.Y
+	visible = image.Pt(tv.state.clock, tv.state.scanline).In(crop)
+	return x, y, visible
+}
+
+// ScanlineForVisibleY returns the television scanline for the Y coordinate of
+// the visible area of the screen. The visible area is as described by the
+// Crop() function of FrameInfo and so the result will change as the visible
+// area is resized.
+//
+// Like all Television functions this function is not safe to call from
+// goroutines other than the one that created the Television.
+func (tv *Television) ScanlineForVisibleY(y int) int {
+	return y + tv.state.frameInfo.Crop().Min.Y
+}
+
+// VisibleYForScanline is the inverse of ScanlineForVisibleY(). The result will
+// be negative or larger than the visible area if the scanline is outside of
+// the visible area of the screen.
+//
+// Like all Television functions this function is not safe to call from
+// goroutines other than the one that created the Television.
+func (tv *Television) VisibleYForScanline(scanline int) int {
+	return scanline - tv.state.frameInfo.Crop().Min.Y
+}
+
 func (tv *Television) IsFrameNum(frame int) bool {
 	return tv.state.frameNum == frame
 }
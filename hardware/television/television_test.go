@@ -16,9 +16,22 @@
 package television_test
 
 import (
+	"encoding/json"
+	"image/color"
+	"strings"
 	"testing"
 
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/debugger/govern"
+	"github.com/jetsetilly/gopher2600/environment"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
 	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
 )
 
 func TestNewTelevision(t *testing.T) {
@@ -42,3 +55,791 @@ func TestNewTelevision(t *testing.T) {
 		t.Errorf("'FOO' spec creation unexpectedly succeeded")
 	}
 }
+
+// a minimal 4k ROM that produces a 259 scanline frame with a different
+// background color on every scanline
+func frameHashROM() []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta VSYNC
+		0x85, 0x02, // f004 sta WSYNC
+		0x85, 0x02, // f006 sta WSYNC
+		0x85, 0x02, // f008 sta WSYNC
+		0xa9, 0x00, // f00a lda #$00
+		0x85, 0x00, // f00c sta VSYNC
+		0xa2, 0x00, // f00e ldx #$00
+		0x86, 0x09, // f010 stx COLUBK
+		0x85, 0x02, // f012 sta WSYNC
+		0xe8,       // f014 inx
+		0xe0, 0x00, // f015 cpx #$00
+		0xd0, 0xf7, // f017 bne $f010
+		0x4c, 0x00, 0xf0, // f019 jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestFrameHash(t *testing.T) {
+	prefs.DisableSaving = true
+
+	run := func() uint64 {
+		t.Helper()
+
+		vcs := hardwaretest.NewVCS(t, nil)
+		tv := vcs.TV
+
+		// random state would make the test non-deterministic
+		test.DemandSuccess(t, vcs.Env.Prefs.RandomState.Set(false))
+
+		// no frame has been completed yet
+		test.ExpectEquality(t, tv.FrameHash(), 0)
+
+		cartload, err := cartridgeloader.NewLoaderFromData("framehash", frameHashROM(), "4K", "", nil)
+		test.DemandSuccess(t, err)
+		test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+		test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+		return tv.FrameHash()
+	}
+
+	// hash is the same every time the ROM is run
+	h := run()
+	test.ExpectEquality(t, run(), h)
+
+	// the hash should never change unless the emulation of the TIA or the
+	// television changes
+	test.ExpectEquality(t, h, 2346748291833303022)
+}
+
+// fakeCoProcMapper stands in for a cartridge mapper with a coprocessor
+type fakeCoProcMapper struct {
+	env *environment.Environment
+}
+
+func (m fakeCoProcMapper) coprocFinished() {
+	mapper.SignalFrameReady(m.env)
+}
+
+// frameReadyObserver implements the television.FrameTrigger interface
+type frameReadyObserver struct {
+	ready []bool
+}
+
+func (o *frameReadyObserver) NewFrame(info television.FrameInfo) error {
+	o.ready = append(o.ready, info.CoProcFrameReady)
+	return nil
+}
+
+func TestCoProcFrameReady(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, frameHashROM())
+	tv := vcs.TV
+
+	obs := &frameReadyObserver{}
+	tv.AddFrameTrigger(obs)
+
+	// no signal from the coprocessor
+	test.DemandSuccess(t, vcs.RunForFrameCount(2, nil))
+	for _, r := range obs.ready {
+		test.ExpectFailure(t, r)
+	}
+
+	// signal from the coprocessor is seen by the observer on the next frame
+	// only
+	n := len(obs.ready)
+	fake := fakeCoProcMapper{env: vcs.Env}
+	fake.coprocFinished()
+	test.DemandSuccess(t, vcs.RunForFrameCount(4, nil))
+	test.DemandSuccess(t, len(obs.ready) > n+1)
+	test.ExpectSuccess(t, obs.ready[n])
+	for _, r := range obs.ready[n+1:] {
+		test.ExpectFailure(t, r)
+	}
+}
+
+// pixelCapture implements the television.PixelRenderer interface and keeps a
+// copy of the most recent signals
+type pixelCapture struct {
+	sig []signal.SignalAttributes
+}
+
+func (c *pixelCapture) NewFrame(_ television.FrameInfo) error { return nil }
+func (c *pixelCapture) NewScanline(_ int) error               { return nil }
+func (c *pixelCapture) Reset()                                {}
+func (c *pixelCapture) EndRendering() error                   { return nil }
+
+func (c *pixelCapture) SetPixels(sig []signal.SignalAttributes, _ int) error {
+	c.sig = append(c.sig[:0], sig...)
+	return nil
+}
+
+func TestVisiblePixels(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, frameHashROM())
+	tv := vcs.TV
+
+	capture := &pixelCapture{}
+	tv.AddPixelRenderer(capture)
+
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+
+	pixels, width, height := tv.GetVisiblePixels()
+
+	// dimensions should match the resize state of the television
+	info := tv.GetFrameInfo()
+	test.ExpectEquality(t, width, specification.ClksVisible)
+	test.ExpectEquality(t, height, info.VisibleBottom-info.VisibleTop+1)
+	test.ExpectEquality(t, len(pixels), width*height)
+
+	// the first pixel is the first visible pixel of the frame
+	idx := info.VisibleTop*specification.ClksScanline + specification.ClksHBlank
+	test.DemandSuccess(t, len(capture.sig) > idx)
+	test.ExpectEquality(t, pixels[0], capture.sig[idx].Color)
+
+	// and the last pixel is the last visible pixel of the frame
+	idx = info.VisibleBottom*specification.ClksScanline + specification.ClksHBlank + specification.ClksVisible - 1
+	test.ExpectEquality(t, pixels[len(pixels)-1], capture.sig[idx].Color)
+}
+
+// frameObserver implements the television.FrameTrigger interface
+type frameObserver struct {
+	frames []television.FrameInfo
+}
+
+func (o *frameObserver) NewFrame(info television.FrameInfo) error {
+	o.frames = append(o.frames, info)
+	return nil
+}
+
+func TestForcedFrame(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv := hardwaretest.NewVCS(t, nil).TV
+
+	obs := &frameObserver{}
+	tv.AddFrameTrigger(obs)
+
+	// signals with no VSYNC at all
+	for len(obs.frames) < 20 {
+		tv.Signal(signal.SignalAttributes{Color: signal.VideoBlack})
+	}
+
+	// fallback is not active for the first frames
+	test.ExpectFailure(t, obs.frames[0].ForcedFrame)
+
+	// but does engage eventually
+	first := -1
+	for i, f := range obs.frames {
+		if f.ForcedFrame {
+			first = i
+			break
+		}
+	}
+	test.DemandSuccess(t, first > 0)
+	test.DemandSuccess(t, first < len(obs.frames)-2)
+
+	// once engaged, the fallback remains active and the number of scanlines
+	// in each frame is the number suggested by the specification
+	for _, f := range obs.frames[first+1:] {
+		test.ExpectSuccess(t, f.ForcedFrame)
+		test.ExpectEquality(t, f.TotalScanlines, specification.SpecNTSC.ScanlinesTotal)
+	}
+}
+
+func TestScanlineSignals(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, frameHashROM())
+	tv := vcs.TV
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+
+	sigs := tv.GetScanlineSignals(100)
+	test.ExpectEquality(t, len(sigs), specification.ClksScanline)
+
+	// the HSYNC signal begins on clock 16 and lasts for 20 clocks
+	const hsyncStart = 16
+	const hsyncEnd = hsyncStart + 20
+	for i, s := range sigs {
+		test.ExpectEquality(t, s.HSync, i >= hsyncStart && i < hsyncEnd)
+		test.ExpectEquality(t, s.Index, 100*specification.ClksScanline+i)
+	}
+
+	// out of range scanlines
+	test.ExpectEquality(t, len(tv.GetScanlineSignals(-1)), 0)
+	test.ExpectEquality(t, len(tv.GetScanlineSignals(specification.AbsoluteMaxScanlines)), 0)
+}
+
+func TestSECAM(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("AUTO")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	vcs, err := hardware.NewVCS(environment.MainEmulation, tv, nil, nil)
+	test.DemandSuccess(t, err)
+
+	// SECAM as requested by, for example, the ROM filename
+	test.DemandSuccess(t, tv.SetSpec("SECAM", false))
+
+	info := tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.ID, "SECAM")
+
+	// SECAM palette is different to the PAL palette. the SECAM palette
+	// ignores the luminance bits of the color signal
+	pal := specification.SpecPAL
+	test.ExpectInequality(t, info.Spec.GetColor(0x1e), pal.GetColor(0x1e))
+	test.ExpectEquality(t, info.Spec.GetColor(0x1e), info.Spec.GetColor(0xfe))
+
+	// SECAM and PAL are both 50Hz (625 line) systems and are very different
+	// to NTSC in that regard
+	test.ExpectEquality(t, info.Spec.RefreshRate, pal.RefreshRate)
+	test.ExpectInequality(t, info.Spec.RefreshRate, specification.SpecNTSC.RefreshRate)
+
+	// the frameHash ROM produces a frame with fewer scanlines than the PAL
+	// trigger but the specification does not change to NTSC
+	cartload, err := cartridgeloader.NewLoaderFromData("secam", frameHashROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.DemandSuccess(t, tv.SetSpec("SECAM", false))
+	test.DemandSuccess(t, vcs.RunForFrameCount(20, nil))
+	test.ExpectEquality(t, tv.GetFrameInfo().Spec.ID, "SECAM")
+}
+
+func TestFrameInfoJSON(t *testing.T) {
+	info := television.NewFrameInfo(specification.SpecPAL)
+	info.FrameNum = 100
+	info.TotalScanlines = 313
+	info.RefreshRate = 49.86
+	info.VSYNCscanline = 2
+	info.VSYNCcount = 3
+	info.FromVSYNC = true
+	info.IsSynced = true
+	info.Stable = true
+
+	data, err := json.Marshal(info)
+	test.ExpectSuccess(t, err)
+
+	// output contains only the ID of the specification
+	test.ExpectSuccess(t, strings.Contains(string(data), `"spec":"PAL"`))
+	test.ExpectFailure(t, strings.Contains(string(data), "Colors"))
+
+	var cmp television.FrameInfo
+	err = json.Unmarshal(data, &cmp)
+	test.ExpectSuccess(t, err)
+
+	test.ExpectEquality(t, cmp.Spec.ID, "PAL")
+	test.ExpectEquality(t, cmp.FrameNum, 100)
+	test.ExpectEquality(t, cmp.TotalScanlines, 313)
+	test.ExpectEquality(t, cmp.RefreshRate, float32(49.86))
+	test.ExpectEquality(t, cmp.VisibleTop, info.VisibleTop)
+	test.ExpectEquality(t, cmp.VisibleBottom, info.VisibleBottom)
+	test.ExpectEquality(t, cmp.VSYNCscanline, 2)
+	test.ExpectEquality(t, cmp.VSYNCcount, 3)
+	test.ExpectSuccess(t, cmp.FromVSYNC)
+	test.ExpectSuccess(t, cmp.IsSynced)
+	test.ExpectSuccess(t, cmp.Stable)
+	test.ExpectFailure(t, cmp.ForcedFrame)
+
+	// marshalling the unmarshalled frame info produces identical output
+	data2, err := json.Marshal(cmp)
+	test.ExpectSuccess(t, err)
+	test.ExpectEquality(t, string(data2), string(data))
+
+	// unknown specification
+	err = json.Unmarshal([]byte(`{"spec":"FOO"}`), &cmp)
+	test.ExpectFailure(t, err)
+}
+
+func TestForcedSpec(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv := hardwaretest.NewVCS(t, nil).TV
+
+	info := tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.ID, "NTSC")
+	test.ExpectEquality(t, info.RefreshRate, specification.SpecNTSC.RefreshRate)
+
+	// an unforced change has no effect because the television was not created
+	// with the AUTO specification
+	test.DemandSuccess(t, tv.SetSpec("PAL", false))
+	test.ExpectEquality(t, tv.GetFrameInfo().Spec.ID, "NTSC")
+
+	// forcing the change works regardless of the creation specification
+	test.DemandSuccess(t, tv.SetSpec("PAL", true))
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.ID, "PAL")
+	test.ExpectEquality(t, tv.GetReqSpecID(), "PAL")
+	test.ExpectEquality(t, info.RefreshRate, specification.SpecPAL.RefreshRate)
+	test.ExpectEquality(t, info.TotalScanlines, specification.SpecPAL.ScanlinesTotal)
+
+	// unknown specification
+	test.ExpectFailure(t, tv.SetSpec("FOO", true))
+}
+
+// renderCounter implements the television.PixelRenderer and
+// television.RealtimeAudioMixer interfaces and counts the number of calls to
+// SetPixels() and SetAudio()
+type renderCounter struct {
+	pixels int
+	audio  int
+}
+
+func (c *renderCounter) NewFrame(_ television.FrameInfo) error { return nil }
+func (c *renderCounter) NewScanline(_ int) error               { return nil }
+func (c *renderCounter) Reset()                                {}
+func (c *renderCounter) EndRendering() error                   { return nil }
+func (c *renderCounter) EndMixing() error                      { return nil }
+func (c *renderCounter) MoreAudio() bool                       { return false }
+
+func (c *renderCounter) SetPixels(_ []signal.SignalAttributes, _ int) error {
+	c.pixels++
+	return nil
+}
+
+func (c *renderCounter) SetAudio(_ []signal.SignalAttributes) error {
+	c.audio++
+	return nil
+}
+
+func TestFastForward(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, frameHashROM())
+	tv := vcs.TV
+
+	counter := &renderCounter{}
+	tv.AddPixelRenderer(counter)
+	tv.AddRealtimeAudioMixer(counter)
+
+	tv.SetFPSCap(true)
+
+	// every frame is rendered normally
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+	test.ExpectEquality(t, counter.pixels, 10)
+	test.ExpectEquality(t, counter.audio, 10)
+
+	// fast-forward disables the FPS cap and the realtime audio mixer. only
+	// every fifth frame is rendered
+	test.DemandSuccess(t, tv.SetFastForward(true, 5))
+	test.ExpectSuccess(t, tv.IsFastForward())
+	test.ExpectFailure(t, tv.SetFPSCap(false))
+
+	*counter = renderCounter{}
+	test.DemandSuccess(t, vcs.RunForFrameCount(20, nil))
+	test.ExpectEquality(t, counter.pixels, 4)
+	test.ExpectEquality(t, counter.audio, 0)
+
+	// ending fast-forward restores the FPS cap and renders the current frame
+	*counter = renderCounter{}
+	test.DemandSuccess(t, tv.SetFastForward(false, 0))
+	test.ExpectFailure(t, tv.IsFastForward())
+	test.ExpectSuccess(t, tv.SetFPSCap(true))
+	test.ExpectEquality(t, counter.pixels, 1)
+
+	// rendering returns to normal
+	*counter = renderCounter{}
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+	test.ExpectEquality(t, counter.pixels, 10)
+	test.ExpectEquality(t, counter.audio, 10)
+}
+
+func TestStateTransitions(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	test.ExpectEquality(t, len(tv.StateTransitions()), 0)
+
+	states := []govern.State{govern.Initialising, govern.Paused, govern.Running, govern.Rewinding, govern.Paused}
+	for _, s := range states {
+		test.DemandSuccess(t, tv.SetEmulationState(s))
+	}
+
+	// transitions are logged in order
+	log := tv.StateTransitions()
+	test.ExpectEquality(t, len(log), len(states))
+	test.ExpectEquality(t, log[0].From, govern.EmulatorStart)
+	for i, s := range states {
+		test.ExpectEquality(t, log[i].To, s)
+		if i > 0 {
+			test.ExpectEquality(t, log[i].From, states[i-1])
+			test.ExpectSuccess(t, !log[i].Time.Before(log[i-1].Time))
+		}
+	}
+
+	// the log is bounded and the oldest entries are dropped
+	for range 100 {
+		test.DemandSuccess(t, tv.SetEmulationState(govern.Running))
+		test.DemandSuccess(t, tv.SetEmulationState(govern.Paused))
+	}
+	log = tv.StateTransitions()
+	test.ExpectSuccess(t, len(log) < 100)
+	test.ExpectEquality(t, log[len(log)-1].From, govern.Running)
+	test.ExpectEquality(t, log[len(log)-1].To, govern.Paused)
+}
+
+// invertProcessor implements the television.SignalPostProcessor interface and
+// inverts the color of every pixel. a copy of the unprocessed signals is kept
+type invertProcessor struct {
+	original []signal.SignalAttributes
+}
+
+func (p *invertProcessor) PostProcess(sig []signal.SignalAttributes, _ int) error {
+	p.original = append(p.original[:0], sig...)
+	for i := range sig {
+		sig[i].Color = ^sig[i].Color
+	}
+	return nil
+}
+
+// pixelCopier implements the television.PixelRenderer interface and keeps a
+// copy of the most recent signals sent by SetPixels()
+type pixelCopier struct {
+	sig     []signal.SignalAttributes
+	current int
+}
+
+func (c *pixelCopier) NewFrame(_ television.FrameInfo) error { return nil }
+func (c *pixelCopier) NewScanline(_ int) error               { return nil }
+func (c *pixelCopier) Reset()                                {}
+func (c *pixelCopier) EndRendering() error                   { return nil }
+
+func (c *pixelCopier) SetPixels(sig []signal.SignalAttributes, current int) error {
+	c.sig = append(c.sig[:0], sig...)
+	c.current = current
+	return nil
+}
+
+func TestSignalPostProcessor(t *testing.T) {
+	prefs.DisableSaving = true
+
+	run := func(proc *invertProcessor) (*pixelCopier, uint64) {
+		t.Helper()
+
+		vcs := hardwaretest.NewVCS(t, nil)
+		tv := vcs.TV
+		test.DemandSuccess(t, vcs.Env.Prefs.RandomState.Set(false))
+
+		cartload, err := cartridgeloader.NewLoaderFromData("postprocess", frameHashROM(), "4K", "", nil)
+		test.DemandSuccess(t, err)
+		test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+		copier := &pixelCopier{}
+		tv.AddPixelRenderer(copier)
+		if proc != nil {
+			tv.AddSignalPostProcessor(proc)
+		}
+
+		test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+		return copier, tv.FrameHash()
+	}
+
+	// without a post processor the renderer receives the signals unchanged
+	plain, hash := run(nil)
+
+	proc := &invertProcessor{}
+	processed, processedHash := run(proc)
+
+	// the renderer receives the processed signals
+	test.ExpectEquality(t, len(processed.sig), len(proc.original))
+	test.ExpectEquality(t, processed.current, plain.current)
+	for i := range processed.sig {
+		if processed.sig[i].Color != ^proc.original[i].Color {
+			t.Fatalf("pixel %d has not been processed", i)
+		}
+		if proc.original[i] != plain.sig[i] {
+			t.Fatalf("pixel %d has changed in the original signals", i)
+		}
+	}
+
+	// the frame hash is not affected by the post processor
+	test.ExpectEquality(t, processedHash, hash)
+}
+
+func TestFlybackCounts(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, nil)
+	tv := vcs.TV
+
+	vsync, natural := tv.FlybackCounts()
+	test.ExpectEquality(t, vsync, 0)
+	test.ExpectEquality(t, natural, 0)
+
+	// every frame of the frame hash ROM is ended by VSYNC
+	cartload, err := cartridgeloader.NewLoaderFromData("flyback", frameHashROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+
+	vsync, natural = tv.FlybackCounts()
+	test.ExpectEquality(t, vsync, 10)
+	test.ExpectEquality(t, natural, 0)
+
+	// a ROM that never sends a VSYNC signal. every frame is a natural flyback
+	rom := hardwaretest.ROM([]byte{0x4c, 0x00, 0xf0}) // f000 jmp $f000
+
+	cartload, err = cartridgeloader.NewLoaderFromData("flyback", rom, "4K", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+	test.DemandSuccess(t, vcs.RunForFrameCount(5, nil))
+
+	// counts accumulate over the lifetime of the television
+	vsync, natural = tv.FlybackCounts()
+	test.ExpectEquality(t, vsync, 10)
+	test.ExpectEquality(t, natural, 5)
+}
+
+func TestBeamPosition(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, frameHashROM())
+	tv := vcs.TV
+	test.DemandSuccess(t, vcs.RunForFrameCount(10, nil))
+
+	info := tv.GetFrameInfo()
+
+	type position struct {
+		x, y    int
+		visible bool
+	}
+
+	// television coordinates (as returned by GetCoords()) and the expected
+	// beam position for those coordinates
+	expected := map[[2]int]position{
+		{info.VisibleTop - 1, 10}:                             {10, -1, false},
+		{info.VisibleTop, -1}:                                 {-1, 0, false},
+		{info.VisibleTop, 0}:                                  {0, 0, true},
+		{info.VisibleTop + 10, specification.ClksVisible - 1}: {specification.ClksVisible - 1, 10, true},
+		{info.VisibleBottom, 50}:                              {50, info.VisibleBottom - info.VisibleTop, true},
+		{info.VisibleBottom + 1, 50}:                          {50, info.VisibleBottom - info.VisibleTop + 1, false},
+	}
+
+	// step through a complete frame and sample the beam position on every
+	// color clock
+	actual := make(map[[2]int]position)
+	frame := tv.GetCoords().Frame
+	for tv.GetCoords().Frame <= frame+1 {
+		err := vcs.Step(func(_ bool) error {
+			c := tv.GetCoords()
+			if _, ok := expected[[2]int{c.Scanline, c.Clock}]; ok {
+				var p position
+				p.x, p.y, p.visible = tv.BeamPosition()
+				actual[[2]int{c.Scanline, c.Clock}] = p
+			}
+			return nil
+		})
+		test.DemandSuccess(t, err)
+	}
+
+	test.ExpectEquality(t, len(actual), len(expected))
+	for c, p := range expected {
+		test.ExpectEquality(t, actual[c], p)
+	}
+}
+
+func TestSignalGenerator(t *testing.T) {
+	prefs.DisableSaving = true
+
+	// generate frames with the layout until the television is synchronised.
+	// returns the number of frames generated
+	sync := func(tv *television.Television, gen *television.SignalGenerator, layout television.FrameLayout) int {
+		t.Helper()
+		var n int
+		for !tv.GetFrameInfo().IsSynced {
+			gen.Frame(layout)
+			n++
+			if n > 100 {
+				t.Fatalf("television did not synchronise")
+			}
+		}
+		return n
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	gen, err := television.NewSignalGenerator(tv)
+	test.DemandSuccess(t, err)
+	gen.Color = 0x0e
+
+	sync(tv, gen, television.LayoutNTSC)
+	test.ExpectFailure(t, tv.GetFrameInfo().Stable)
+
+	// the frame becomes stable once the television has been synchronised for
+	// stabilityThreshold frames
+	synced := 1
+	for !tv.GetFrameInfo().Stable {
+		gen.Frame(television.LayoutNTSC)
+		test.DemandSuccess(t, tv.GetFrameInfo().IsSynced)
+		synced++
+	}
+	test.ExpectEquality(t, synced, 6)
+
+	info := tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.ID, specification.SpecNTSC.ID)
+	test.ExpectEquality(t, info.TotalScanlines, television.LayoutNTSC.Scanlines())
+
+	// the specification is detected automatically from the number of scanlines
+	tv, err = television.NewTelevision("AUTO")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	gen, err = television.NewSignalGenerator(tv)
+	test.DemandSuccess(t, err)
+
+	sync(tv, gen, television.LayoutPAL)
+	gen.Frames(6, television.LayoutPAL)
+
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.ID, specification.SpecPAL.ID)
+	test.ExpectEquality(t, info.TotalScanlines, television.LayoutPAL.Scanlines())
+}
+
+func TestStabilise(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	gen, err := television.NewSignalGenerator(tv)
+	test.DemandSuccess(t, err)
+
+	for n := 0; !tv.GetFrameInfo().IsSynced; n++ {
+		test.DemandSuccess(t, n < 100)
+		gen.Frame(television.LayoutNTSC)
+	}
+	test.ExpectFailure(t, tv.GetFrameInfo().Stable)
+
+	// the next frame is stable without waiting for the stability threshold
+	test.ExpectSuccess(t, tv.Stabilise())
+	gen.Frame(television.LayoutNTSC)
+	test.ExpectSuccess(t, tv.GetFrameInfo().Stable)
+
+	// stabilising is not allowed while the AUTO specification might change
+	tv, err = television.NewTelevision("AUTO")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	gen, err = television.NewSignalGenerator(tv)
+	test.DemandSuccess(t, err)
+
+	for n := 0; !tv.GetFrameInfo().IsSynced; n++ {
+		test.DemandSuccess(t, n < 100)
+		gen.Frame(television.LayoutNTSC)
+	}
+	gen.Frame(television.LayoutNTSC)
+	test.ExpectFailure(t, tv.Stabilise())
+}
+
+func TestScanlineForVisibleY(t *testing.T) {
+	prefs.DisableSaving = true
+
+	// check that the mapping between visible Y and scanline round-trips and
+	// that it agrees with the current frame info
+	check := func(tv *television.Television) {
+		t.Helper()
+		info := tv.GetFrameInfo()
+		test.ExpectEquality(t, tv.ScanlineForVisibleY(0), info.VisibleTop)
+		test.ExpectEquality(t, tv.VisibleYForScanline(info.VisibleTop), 0)
+		test.ExpectEquality(t, tv.VisibleYForScanline(info.VisibleBottom), info.VisibleBottom-info.VisibleTop)
+		for y := -10; y < info.TotalScanlines; y++ {
+			test.ExpectEquality(t, tv.VisibleYForScanline(tv.ScanlineForVisibleY(y)), y)
+		}
+	}
+
+	ntsc, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer ntsc.End()
+	check(ntsc)
+
+	pal, err := television.NewTelevision("PAL")
+	test.DemandSuccess(t, err)
+	defer pal.End()
+	check(pal)
+
+	// the ideal visible area is different for each specification
+	test.ExpectInequality(t, ntsc.GetFrameInfo().VisibleTop, pal.GetFrameInfo().VisibleTop)
+
+	// the resizer will change the visible area of the television while the
+	// ROM is running
+	vcs, err := hardware.NewVCS(environment.MainEmulation, ntsc, nil, nil)
+	test.DemandSuccess(t, err)
+
+	cartload, err := cartridgeloader.NewLoaderFromData("scanlineruler", frameHashROM(), "4K", "", nil)
+	test.DemandSuccess(t, err)
+	test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+
+	top := ntsc.GetFrameInfo().VisibleTop
+	test.DemandSuccess(t, vcs.RunForFrameCount(60, nil))
+	test.ExpectInequality(t, ntsc.GetFrameInfo().VisibleTop, top)
+	check(ntsc)
+
+	// moving the resizer state to another television changes the mapping for
+	// that television
+	pal.SetResizer(ntsc.GetResizer())
+	test.ExpectEquality(t, pal.GetFrameInfo().VisibleTop, ntsc.GetFrameInfo().VisibleTop)
+	check(pal)
+}
+
+func TestSetPalette(t *testing.T) {
+	prefs.DisableSaving = true
+
+	tv, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer tv.End()
+
+	other, err := television.NewTelevision("NTSC")
+	test.DemandSuccess(t, err)
+	defer other.End()
+
+	// palette where every color is the same
+	palette := make([]color.RGBA, len(specification.SpecNTSC.Colors))
+	custom := color.RGBA{0x12, 0x34, 0x56, 255}
+	for i := range palette {
+		palette[i] = custom
+	}
+
+	// a snapshot taken before the palette is changed
+	state := tv.Snapshot()
+
+	test.DemandSuccess(t, tv.SetPalette("NTSC", palette))
+	info := tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), custom)
+
+	// the specification and other televisions are unaffected
+	test.ExpectEquality(t, specification.SpecNTSC.GetColor(signal.ColorSignal(10)), specification.PaletteNTSC[10])
+	info = other.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), specification.PaletteNTSC[10])
+
+	// the palette survives the plumbing of an earlier state
+	tv.Plumb(nil, state)
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), custom)
+
+	// the palette is only used for the specification it was set for
+	test.DemandSuccess(t, tv.SetSpec("PAL", true))
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), specification.PalettePAL[10])
+	test.DemandSuccess(t, tv.SetSpec("NTSC", true))
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), custom)
+
+	// built-in palette is restored
+	test.DemandSuccess(t, tv.SetPalette("NTSC", nil))
+	info = tv.GetFrameInfo()
+	test.ExpectEquality(t, info.Spec.GetColor(signal.ColorSignal(10)), specification.PaletteNTSC[10])
+
+	// palette of the wrong size
+	test.ExpectFailure(t, tv.SetPalette("NTSC", palette[:10]))
+
+	// unknown specification
+	test.ExpectFailure(t, tv.SetPalette("FOO", palette))
+}
@@ -51,6 +51,41 @@ func Stereo(channel0 uint8, channel1 uint8) (int16, int16) {
 	return Mono(channel0, 0), Mono(0, channel1)
 }
 
+// Mute is a mask of the channels that are silenced when the two channels are
+// mixed. Muting a channel does not affect the audio registers.
+type Mute uint8
+
+// List of valid Mute values.
+const (
+	MuteNone     Mute = 0x00
+	MuteChannel0 Mute = 0x01
+	MuteChannel1 Mute = 0x02
+	MuteBoth     Mute = MuteChannel0 | MuteChannel1
+)
+
+func (m Mute) String() string {
+	switch m & MuteBoth {
+	case MuteChannel0:
+		return "channel 0 muted"
+	case MuteChannel1:
+		return "channel 1 muted"
+	case MuteBoth:
+		return "both channels muted"
+	}
+	return "no channels muted"
+}
+
+// Apply the mute mask to the volume of the two channels.
+func (m Mute) Apply(channel0 uint8, channel1 uint8) (uint8, uint8) {
+	if m&MuteChannel0 == MuteChannel0 {
+		channel0 = 0
+	}
+	if m&MuteChannel1 == MuteChannel1 {
+		channel1 = 0
+	}
+	return channel0, channel1
+}
+
 func init() {
 	for vol := 0; vol < len(mono); vol++ {
 		mono[vol] = int16(0x7fff * float32(vol) / float32(maxVolume) * (30 + 1*float32(maxVolume)) / (30 + 1*float32(vol)))
@@ -0,0 +1,47 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package mix_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio/mix"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestMute(t *testing.T) {
+	const v0 = 15
+	const v1 = 8
+
+	ch0, ch1 := mix.MuteNone.Apply(v0, v1)
+	test.ExpectEquality(t, ch0, v0)
+	test.ExpectEquality(t, ch1, v1)
+
+	ch0, ch1 = mix.MuteChannel0.Apply(v0, v1)
+	test.ExpectEquality(t, ch0, 0)
+	test.ExpectEquality(t, ch1, v1)
+	test.ExpectEquality(t, mix.Mono(ch0, ch1), mix.Mono(0, v1))
+
+	ch0, ch1 = mix.MuteChannel1.Apply(v0, v1)
+	test.ExpectEquality(t, ch0, v0)
+	test.ExpectEquality(t, ch1, 0)
+	test.ExpectEquality(t, mix.Mono(ch0, ch1), mix.Mono(v0, 0))
+
+	ch0, ch1 = mix.MuteBoth.Apply(v0, v1)
+	test.ExpectEquality(t, ch0, 0)
+	test.ExpectEquality(t, ch1, 0)
+	test.ExpectEquality(t, mix.Mono(ch0, ch1), mix.Mono(0, 0))
+}
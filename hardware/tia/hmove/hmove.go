@@ -20,9 +20,30 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
 	"github.com/jetsetilly/gopher2600/hardware/tia/delay"
 )
 
+// Strobe records the circumstances of an HMOVE strobe.
+type Strobe struct {
+	// the television coordinates at the moment of the strobe
+	Coords coords.TelevisionCoords
+
+	// the phase clock value at the moment of the strobe
+	PClk int
+
+	// the number of color clocks before the HMOVE signal is decoded. this is
+	// decided by the phase clock and will be a value between 3 and 6
+	Delay int
+}
+
+func (st Strobe) String() string {
+	if st.Delay == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%s  PClk: %d  Delay: %d", st.Coords, st.PClk, st.Delay)
+}
+
 type Hmove struct {
 	// the delay between HMOVE being triggered and the latch flag being set
 	// to true
@@ -48,6 +69,10 @@ type Hmove struct {
 
 	// Clk is true when the TIA PhaseClock.Phi2() is true
 	Clk bool
+
+	// the most recent HMOVE strobe. the zero value means HMOVE has not been
+	// strobed since the last reset
+	LastStrobe Strobe
 }
 
 // ResetRipple begins the ripple count.
@@ -65,6 +90,15 @@ func (hm *Hmove) Tick() {
 	}
 }
 
+// RippleCount returns the value of the ripple counter. The boolean return
+// value is false if the ripple counter is not currently counting.
+func (hm *Hmove) RippleCount() (int, bool) {
+	if hm.Ripple > 15 {
+		return 0, false
+	}
+	return int(hm.Ripple), true
+}
+
 // Reset Hmove values.
 func (hm *Hmove) Reset() {
 	hm.Latch = false
@@ -72,6 +106,7 @@ func (hm *Hmove) Reset() {
 	hm.Clk = false
 	hm.FutureLatch.Drop()
 	hm.Future.Drop()
+	hm.LastStrobe = Strobe{}
 }
 
 func (hm *Hmove) String() string {
@@ -89,8 +124,8 @@ func (hm *Hmove) String() string {
 		s.WriteString(" HMOVE not latched")
 	}
 
-	if hm.Ripple <= 15 {
-		s.WriteString(fmt.Sprintf(" (ripple count %d)", hm.Ripple))
+	if ct, ok := hm.RippleCount(); ok {
+		s.WriteString(fmt.Sprintf(" (ripple count %d)", ct))
 	} else if hm.RippleJustEnded {
 		s.WriteString(" (ripple just ended)")
 	}
@@ -95,6 +95,30 @@ const (
 	RESPxHBLANK
 )
 
+// String returns the name of the bug. The name is the same as the name of
+// the Bug constant.
+func (bug Bug) String() string {
+	switch bug {
+	case LateVDELGRP0:
+		return "LateVDELGRP0"
+	case LateVDELGRP1:
+		return "LateVDELGRP1"
+	case LateRESPx:
+		return "LateRESPx"
+	case EarlyScancounter:
+		return "EarlyScancounter"
+	case LatePFx:
+		return "LatePFx"
+	case LateColor:
+		return "LateColor"
+	case LostMOTCK:
+		return "LostMOTCK"
+	case RESPxHBLANK:
+		return "RESPxHBLANK"
+	}
+	return "unknown bug"
+}
+
 func (bug Bug) Description() string {
 	switch bug {
 	case LateVDELGRP0:
@@ -73,6 +73,11 @@ type TIA struct {
 	Video *video.Video
 	Audio *audio.Audio
 
+	// if audioDisabled is true then the TIA will not step the audio
+	// sub-system and no audio updates will be sent to the television. see
+	// SetAudioDisabled()
+	audioDisabled bool
+
 	// horizontal blank controls whether to send colour information to the
 	// television. it is turned on at the end of the visible screen and turned
 	// on depending on the HMOVE latch. it is also used to control when sprite
@@ -133,6 +138,29 @@ func (tia *TIA) String() string {
 	return s.String()
 }
 
+// Position returns the current value of the hsync counter, the phase of the
+// phase clock and the number of video cycles (color clocks) since the start of
+// the scanline.
+//
+// The hsync counter ticks on the rising edge of Phi2 so the number of video
+// cycles is counted from that point in the phase clock.
+func (tia *TIA) Position() (hsyncCount int, pclkPhase int, videoCycles int) {
+	hsyncCount = int(tia.hsync)
+	pclkPhase = int(tia.PClk)
+	phase := (pclkPhase - int(phaseclock.RisingPhi2) + phaseclock.NumStates) % phaseclock.NumStates
+	videoCycles = hsyncCount*phaseclock.NumStates + phase
+	return hsyncCount, pclkPhase, videoCycles
+}
+
+// BlankState returns the current state of the HBLANK and VBLANK signals.
+//
+// The VBLANK state is taken from the most recent signal sent to the
+// television. This means the state will not reflect a write to the VBLANK
+// register until the register change has been resolved by the TIA.
+func (tia *TIA) BlankState() (hblank bool, vblank bool) {
+	return tia.Hblank, tia.sig.VBlank
+}
+
 // NewTIA creates a TIA, to be used in a VCS emulation.
 func NewTIA(env *environment.Environment, tv TV, mem chipbus.Memory, riot RIOTports, cpu CPU) (*TIA, error) {
 	tia := &TIA{
@@ -162,6 +190,24 @@ func (tia *TIA) Snapshot() *TIA {
 	return &n
 }
 
+// SetAudioDisabled stops the TIA from stepping the audio sub-system. No audio
+// updates will be sent to the television while audio is disabled. This is
+// useful for situations where performance is more important than sound, for
+// example when fast-forwarding a headless emulation.
+//
+// The setting belongs to the emulation and not to the state of the TIA. It
+// will be copied by Snapshot() but it should be reapplied when a snapshot is
+// plumbed into the emulation.
+func (tia *TIA) SetAudioDisabled(disabled bool) {
+	tia.audioDisabled = disabled
+}
+
+// AudioDisabled returns true if the audio sub-system has been disabled with
+// SetAudioDisabled().
+func (tia *TIA) AudioDisabled() bool {
+	return tia.audioDisabled
+}
+
 // Plumb the a new ChipBus into the TIA.
 func (tia *TIA) Plumb(env *environment.Environment, tv TV, mem chipbus.Memory, riot RIOTports, cpu CPU) {
 	tia.env = env
@@ -257,6 +303,14 @@ func (tia *TIA) Update(data chipbus.ChangedRegister) bool {
 			delayDuration = 2
 		}
 
+		// the delay recorded with the strobe is the number of CLK as described
+		// in TIA_HW_Notes
+		tia.Hmove.LastStrobe = hmove.Strobe{
+			Coords: tia.tv.GetCoords(),
+			PClk:   int(tia.PClk),
+			Delay:  delayDuration + 1,
+		}
+
 		tia.Hmove.FutureLatch.Schedule(delayDuration, 0)
 		tia.Hmove.Future.Schedule(delayDuration+3, 0)
 		tia.pendingEvents += 2
@@ -588,7 +642,7 @@ func (tia *TIA) Step(reg chipbus.ChangedRegister, ct int) {
 	}
 
 	// mix audio and copy values to television signal
-	if ct == 3 && tia.Audio.Step() {
+	if ct == 3 && !tia.audioDisabled && tia.Audio.Step() {
 		tia.sig.AudioUpdate = true
 		tia.sig.AudioChannel0 = tia.Audio.Vol0
 		tia.sig.AudioChannel1 = tia.Audio.Vol1
@@ -758,7 +812,7 @@ func (tia *TIA) QuickStep(ct int) {
 	}
 
 	// mix audio and copy values to television signal
-	if ct == 3 && tia.Audio.Step() {
+	if ct == 3 && !tia.audioDisabled && tia.Audio.Step() {
 		tia.sig.AudioUpdate = true
 		tia.sig.AudioChannel0 = tia.Audio.Vol0
 		tia.sig.AudioChannel1 = tia.Audio.Vol1
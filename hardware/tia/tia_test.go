@@ -0,0 +1,340 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tia_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cpubus"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/hardware/tia/phaseclock"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestPosition(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, nil)
+
+	expect := func(hsync int, pclk phaseclock.PhaseClock, cycles int) {
+		t.Helper()
+		h, p, c := vcs.TIA.Position()
+		test.ExpectEquality(t, h, hsync)
+		test.ExpectEquality(t, p, int(pclk))
+		test.ExpectEquality(t, c, cycles)
+	}
+
+	step := func(n int) {
+		for range n {
+			vcs.TIA.QuickStep(1)
+		}
+	}
+
+	// newly created TIA is at the start of the scanline
+	expect(0, phaseclock.RisingPhi2, 0)
+
+	step(1)
+	expect(0, phaseclock.FallingPhi2, 1)
+
+	step(2)
+	expect(0, phaseclock.FallingPhi1, 3)
+
+	// hsync counter ticks on the next rising edge of Phi2
+	step(1)
+	expect(1, phaseclock.RisingPhi2, 4)
+
+	step(100)
+	expect(26, phaseclock.RisingPhi2, 104)
+
+	// a full scanline is 228 video cycles
+	step(124)
+	expect(0, phaseclock.RisingPhi2, 0)
+}
+
+func TestBlankState(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, nil)
+
+	expect := func(hblank bool, vblank bool) {
+		t.Helper()
+		h, v := vcs.TIA.BlankState()
+		test.ExpectEquality(t, h, hblank)
+		test.ExpectEquality(t, v, vblank)
+	}
+
+	step := func(n int) {
+		for range n {
+			vcs.TIA.QuickStep(1)
+		}
+	}
+
+	writeVBLANK := func(v uint8) {
+		vcs.TIA.Step(chipbus.ChangedRegister{
+			Address:  cpubus.WriteAddressByRegister[cpubus.VBLANK],
+			Register: cpubus.VBLANK,
+			Value:    v,
+		}, 1)
+	}
+
+	// newly created TIA is at the start of the scanline and in HBLANK
+	expect(true, false)
+
+	// HBLANK ends after 68 color clocks
+	step(67)
+	expect(true, false)
+	step(1)
+	expect(false, false)
+
+	// and begins again at the start of the next scanline
+	step(159)
+	expect(false, false)
+	step(1)
+	expect(true, false)
+
+	// VBLANK is reported once the write to the register has been resolved
+	writeVBLANK(0x02)
+	step(4)
+	expect(true, true)
+
+	// VBLANK does not affect HBLANK
+	step(100)
+	expect(false, true)
+
+	writeVBLANK(0x00)
+	step(4)
+	expect(false, false)
+}
+
+// audioCounter implements the television.AudioMixer interface and counts the
+// number of audio updates
+type audioCounter struct {
+	updates int
+}
+
+func (a *audioCounter) SetAudio(sig []signal.SignalAttributes) error {
+	for _, s := range sig {
+		if s.AudioUpdate {
+			a.updates++
+		}
+	}
+	return nil
+}
+
+func (a *audioCounter) EndMixing() error { return nil }
+func (a *audioCounter) Reset()           {}
+
+// a minimal 4k ROM that produces a tone on audio channel 0
+func audioROM() []byte {
+	prg := []byte{
+		0xa9, 0x04, // f000 lda #$04
+		0x85, 0x15, // f002 sta AUDC0
+		0x85, 0x17, // f004 sta AUDF0
+		0xa9, 0x0f, // f006 lda #$0f
+		0x85, 0x19, // f008 sta AUDV0
+		0x85, 0x02, // f00a sta WSYNC
+		0x4c, 0x0a, 0xf0, // f00c jmp $f00a
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func newAudioVCS(t testing.TB) (*hardware.VCS, *audioCounter) {
+	t.Helper()
+
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, audioROM())
+	counter := &audioCounter{}
+	vcs.TV.AddAudioMixer(counter)
+
+	return vcs, counter
+}
+
+func TestAudioDisabled(t *testing.T) {
+	vcs, counter := newAudioVCS(t)
+
+	// audio updates are sent to the television by default
+	test.DemandSuccess(t, vcs.RunForFrameCount(2, nil))
+	test.ExpectSuccess(t, counter.updates > 0)
+
+	// no audio updates are sent when audio is disabled
+	vcs.TIA.SetAudioDisabled(true)
+	test.DemandSuccess(t, vcs.RunForFrameCount(4, nil))
+	counter.updates = 0
+	test.DemandSuccess(t, vcs.RunForFrameCount(6, nil))
+	test.ExpectEquality(t, counter.updates, 0)
+
+	// disabled flag survives a reset of the VCS
+	test.DemandSuccess(t, vcs.Reset())
+	test.ExpectSuccess(t, vcs.TIA.AudioDisabled())
+
+	// the flag is not part of the rewindable state. plumbing in a state that
+	// was taken when audio was enabled does not enable audio
+	vcs.TIA.SetAudioDisabled(false)
+	state := vcs.Snapshot()
+	vcs.TIA.SetAudioDisabled(true)
+	vcs.Plumb(state, false)
+	test.ExpectSuccess(t, vcs.TIA.AudioDisabled())
+	vcs.PlumbTIA(state.TIA)
+	test.ExpectSuccess(t, vcs.TIA.AudioDisabled())
+
+	counter.updates = 0
+	test.DemandSuccess(t, vcs.RunForFrameCount(2, nil))
+	test.ExpectEquality(t, counter.updates, 0)
+
+	// audio updates resume when audio is enabled again
+	vcs.TIA.SetAudioDisabled(false)
+	test.DemandSuccess(t, vcs.RunForFrameCount(2, nil))
+	test.ExpectSuccess(t, counter.updates > 0)
+}
+
+func benchmarkAudio(b *testing.B, disabled bool) {
+	vcs, _ := newAudioVCS(b)
+
+	vcs.TIA.SetAudioDisabled(disabled)
+
+	b.ResetTimer()
+	for range b.N {
+		vcs.TIA.Step(chipbus.ChangedRegister{}, 3)
+	}
+}
+
+func BenchmarkAudioEnabled(b *testing.B) {
+	benchmarkAudio(b, false)
+}
+
+func BenchmarkAudioDisabled(b *testing.B) {
+	benchmarkAudio(b, true)
+}
+
+// a minimal 4k ROM that draws a sprite on every scanline. the sprite is
+// enabled by writing the value to the register. the register should be one of
+// GRP0, ENAM0 or ENABL
+func spriteROM(register uint8, value uint8) []byte {
+	prg := []byte{
+		0xa9, 0x0e, // f000 lda #$0e
+		0x85, 0x06, // f002 sta COLUP0
+		0x85, 0x08, // f004 sta COLUPF
+		0xa9, value, // f006 lda #value
+		0x85, register, // f008 sta register
+		0xa9, 0x02, // f00a lda #$02
+		0x85, 0x00, // f00c sta VSYNC
+		0x85, 0x02, // f00e sta WSYNC
+		0x85, 0x02, // f010 sta WSYNC
+		0x85, 0x02, // f012 sta WSYNC
+		0xa9, 0x00, // f014 lda #$00
+		0x85, 0x00, // f016 sta VSYNC
+		0xa2, 0x00, // f018 ldx #$00
+		0x85, 0x02, // f01a sta WSYNC
+		0xe8,       // f01c inx
+		0xd0, 0xfb, // f01d bne $f01a
+		0x4c, 0x0a, 0xf0, // f01f jmp $f00a
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestSpritePosition(t *testing.T) {
+	prefs.DisableSaving = true
+
+	t.Run("player", func(t *testing.T) {
+		testSpritePosition(t, spriteROM(0x1b, 0x80), func(vcs *hardware.VCS) spritePositioner {
+			return vcs.TIA.Video.Player0
+		})
+	})
+	t.Run("missile", func(t *testing.T) {
+		testSpritePosition(t, spriteROM(0x1d, 0x02), func(vcs *hardware.VCS) spritePositioner {
+			return vcs.TIA.Video.Missile0
+		})
+	})
+	t.Run("ball", func(t *testing.T) {
+		testSpritePosition(t, spriteROM(0x1f, 0x02), func(vcs *hardware.VCS) spritePositioner {
+			return vcs.TIA.Video.Ball
+		})
+	})
+}
+
+type spritePositioner interface {
+	SetPosition(pixel int)
+}
+
+func testSpritePosition(t *testing.T, rom []byte, sprite func(*hardware.VCS) spritePositioner) {
+	t.Helper()
+
+	vcs := hardwaretest.NewVCS(t, rom)
+	tv := vcs.TV
+	test.DemandSuccess(t, vcs.RunForFrameCount(5, nil))
+
+	// the first visible pixel on the scanline that is not the background color
+	firstPixel := func() int {
+		for i, s := range tv.GetScanlineSignals(100)[specification.ClksHBlank:] {
+			if s.Color != 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// the position can be set at any point in the scanline
+	setPosition := func(pixel int, clock int) {
+		t.Helper()
+		var done bool
+		for !done {
+			err := vcs.Step(func(_ bool) error {
+				if !done && tv.GetCoords().Scanline == 50 && tv.GetCoords().Clock == clock {
+					sprite(vcs).SetPosition(pixel)
+					done = true
+				}
+				return nil
+			})
+			test.DemandSuccess(t, err)
+		}
+	}
+
+	for _, clock := range []int{-20, 0, 10, 80, 159} {
+		for _, pixel := range []int{30, 31, 100, 0, 159} {
+			setPosition(pixel, clock)
+			test.DemandSuccess(t, vcs.RunForFrameCount(1, nil))
+			test.ExpectEquality(t, firstPixel(), pixel)
+		}
+	}
+}
+
+func TestPlayfieldASCII(t *testing.T) {
+	prefs.DisableSaving = true
+
+	vcs := hardwaretest.NewVCS(t, nil)
+
+	pf := vcs.TIA.Video.Playfield
+	test.ExpectEquality(t, pf.ASCII(), "........................................")
+
+	// PF0 is read from bit 4 to bit 7, PF1 from bit 7 to bit 0 and PF2 from
+	// bit 0 to bit 7
+	pf.SetPF0(0x10)
+	pf.SetPF1(0x81)
+	pf.SetPF2(0xc0)
+	test.ExpectEquality(t, pf.ASCII(), "#...#......#......###...#......#......##")
+
+	// the right half of the playfield is a mirror image of the left half
+	pf.SetCTRLPF(0x01)
+	test.ExpectEquality(t, pf.ASCII(), "#...#......#......####......#......#...#")
+}
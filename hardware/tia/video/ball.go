@@ -399,6 +399,26 @@ func (bs *BallSprite) pixel() {
 	bs.lastTickFromHmove = false
 }
 
+// SetPosition changes the position counters of the sprite so that it is drawn
+// at the specified pixel, counting from the left edge of the visible screen.
+// The sprite will be drawn in the new position from the next scanline.
+//
+// Intended for use by debuggers. The effect is the same as a well timed write
+// to the RESBL register.
+func (bs *BallSprite) SetPosition(pixel int) {
+	pixel %= specification.ClksVisible
+	if pixel < 0 {
+		pixel += specification.ClksVisible
+	}
+
+	// the reset clock is adjusted to account for the delay between the
+	// position counter reaching the start signal and the first pixel being
+	// drawn
+	bs.position, bs.pclk = positionCounters(bs.tia.tv.GetCoords().Clock, pixel+1)
+	bs.ResetPixel = pixel
+	bs.HmovedPixel = pixel
+}
+
 // the delayed enable bit is set when the gfx register for player 1 is updated.
 func (bs *BallSprite) setEnableDelay() {
 	bs.EnabledDelay = bs.Enabled
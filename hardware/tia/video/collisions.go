@@ -28,6 +28,11 @@ type Collisions struct {
 	// LastColorClock records the combination of collision bits for the most recent
 	// video cycle. Facilitates production of string information.
 	LastColorClock CollisionEvent
+
+	// Latched records the collision events in the most recent video cycle
+	// that caused a bit in a collision register to be set for the first time
+	// since the register was cleared. It is a subset of LastColorClock.
+	Latched CollisionEvent
 }
 
 // CollisionEvent is an emulator specific value that records the collision
@@ -145,6 +150,27 @@ func (col CollisionEvent) String() string {
 	return strings.TrimSuffix(s.String(), "\n")
 }
 
+// collisionRegisters maps the name of each collision register to the
+// collision events that set bits in that register.
+var collisionRegisters = map[string]CollisionEvent{
+	"CXM0P":  m0p1 | m0p0,
+	"CXM1P":  m1p0 | m1p1,
+	"CXP0FB": p0pf | p0bl,
+	"CXP1FB": p1pf | p1bl,
+	"CXM0FB": m0pf | m0bl,
+	"CXM1FB": m1pf | m1bl,
+	"CXBLPF": blpf,
+	"CXPPMM": p0p1 | m0m1,
+}
+
+// CollisionRegisterEvents returns the collision events that set bits in the
+// named collision register. Returns false if the name is not a collision
+// register.
+func CollisionRegisterEvents(register string) (CollisionEvent, bool) {
+	ev, ok := collisionRegisters[register]
+	return ev, ok
+}
+
 func newCollisions(mem chipbus.Memory) *Collisions {
 	col := &Collisions{mem: mem}
 	col.Clear()
@@ -173,6 +199,7 @@ func (col *Collisions) Clear() {
 	col.mem.ChipWrite(chipbus.CXBLPF, 0x00)
 	col.mem.ChipWrite(chipbus.CXPPMM, 0x00)
 	col.LastColorClock = cxclr
+	col.Latched.reset()
 }
 
 // optimised tick of collision registers. memory is only written to when necessary.
@@ -182,16 +209,23 @@ func (col *Collisions) Clear() {
 // instead.
 func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 	col.LastColorClock.reset()
+	col.Latched.reset()
 
 	if m0 {
 		if p1 {
 			v := col.mem.ChipRefer(chipbus.CXM0P)
+			if v&0x80 == 0 {
+				col.Latched |= m0p1
+			}
 			v |= 0x80
 			col.LastColorClock |= m0p1
 			col.mem.ChipWrite(chipbus.CXM0P, v)
 		}
 		if p0 {
 			v := col.mem.ChipRefer(chipbus.CXM0P)
+			if v&0x40 == 0 {
+				col.Latched |= m0p0
+			}
 			v |= 0x40
 			col.LastColorClock |= m0p0
 			col.mem.ChipWrite(chipbus.CXM0P, v)
@@ -199,12 +233,18 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 
 		if pf {
 			v := col.mem.ChipRefer(chipbus.CXM0FB)
+			if v&0x80 == 0 {
+				col.Latched |= m0pf
+			}
 			v |= 0x80
 			col.LastColorClock |= m0pf
 			col.mem.ChipWrite(chipbus.CXM0FB, v)
 		}
 		if bl {
 			v := col.mem.ChipRefer(chipbus.CXM0FB)
+			if v&0x40 == 0 {
+				col.Latched |= m0bl
+			}
 			v |= 0x40
 			col.LastColorClock |= m0bl
 			col.mem.ChipWrite(chipbus.CXM0FB, v)
@@ -214,12 +254,18 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 	if m1 {
 		if p0 {
 			v := col.mem.ChipRefer(chipbus.CXM1P)
+			if v&0x80 == 0 {
+				col.Latched |= m1p0
+			}
 			v |= 0x80
 			col.LastColorClock |= m1p0
 			col.mem.ChipWrite(chipbus.CXM1P, v)
 		}
 		if p1 {
 			v := col.mem.ChipRefer(chipbus.CXM1P)
+			if v&0x40 == 0 {
+				col.Latched |= m1p1
+			}
 			v |= 0x40
 			col.LastColorClock |= m1p1
 			col.mem.ChipWrite(chipbus.CXM1P, v)
@@ -227,12 +273,18 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 
 		if pf {
 			v := col.mem.ChipRefer(chipbus.CXM1FB)
+			if v&0x80 == 0 {
+				col.Latched |= m1pf
+			}
 			v |= 0x80
 			col.LastColorClock |= m1pf
 			col.mem.ChipWrite(chipbus.CXM1FB, v)
 		}
 		if bl {
 			v := col.mem.ChipRefer(chipbus.CXM1FB)
+			if v&0x40 == 0 {
+				col.Latched |= m1bl
+			}
 			v |= 0x40
 			col.LastColorClock |= m1bl
 			col.mem.ChipWrite(chipbus.CXM1FB, v)
@@ -242,12 +294,18 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 	if p0 {
 		if pf {
 			v := col.mem.ChipRefer(chipbus.CXP0FB)
+			if v&0x80 == 0 {
+				col.Latched |= p0pf
+			}
 			v |= 0x80
 			col.LastColorClock |= p0pf
 			col.mem.ChipWrite(chipbus.CXP0FB, v)
 		}
 		if bl {
 			v := col.mem.ChipRefer(chipbus.CXP0FB)
+			if v&0x40 == 0 {
+				col.Latched |= p0bl
+			}
 			v |= 0x40
 			col.LastColorClock |= p0bl
 			col.mem.ChipWrite(chipbus.CXP0FB, v)
@@ -257,12 +315,18 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 	if p1 {
 		if pf {
 			v := col.mem.ChipRefer(chipbus.CXP1FB)
+			if v&0x80 == 0 {
+				col.Latched |= p1pf
+			}
 			v |= 0x80
 			col.LastColorClock |= p1pf
 			col.mem.ChipWrite(chipbus.CXP1FB, v)
 		}
 		if bl {
 			v := col.mem.ChipRefer(chipbus.CXP1FB)
+			if v&0x40 == 0 {
+				col.Latched |= p1bl
+			}
 			v |= 0x40
 			col.LastColorClock |= p1bl
 			col.mem.ChipWrite(chipbus.CXP1FB, v)
@@ -271,6 +335,9 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 
 	if bl && pf {
 		v := col.mem.ChipRefer(chipbus.CXBLPF)
+		if v&0x80 == 0 {
+			col.Latched |= blpf
+		}
 		v |= 0x80
 		col.LastColorClock |= blpf
 		col.mem.ChipWrite(chipbus.CXBLPF, v)
@@ -279,6 +346,9 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 
 	if p0 && p1 {
 		v := col.mem.ChipRefer(chipbus.CXPPMM)
+		if v&0x80 == 0 {
+			col.Latched |= p0p1
+		}
 		v |= 0x80
 		col.LastColorClock |= p0p1
 		col.mem.ChipWrite(chipbus.CXPPMM, v)
@@ -286,6 +356,9 @@ func (col *Collisions) tick(p0, p1, m0, m1, bl, pf bool) {
 
 	if m0 && m1 {
 		v := col.mem.ChipRefer(chipbus.CXPPMM)
+		if v&0x40 == 0 {
+			col.Latched |= m0m1
+		}
 		v |= 0x40
 		col.LastColorClock |= m0m1
 		col.mem.ChipWrite(chipbus.CXPPMM, v)
@@ -410,6 +410,26 @@ func (ms *MissileSprite) _futureResetPosition(_ uint8) {
 	}
 }
 
+// SetPosition changes the position counters of the sprite so that it is drawn
+// at the specified pixel, counting from the left edge of the visible screen.
+// The sprite will be drawn in the new position from the next scanline.
+//
+// Intended for use by debuggers. The effect is the same as a well timed write
+// to the RESM0/RESM1 register.
+func (ms *MissileSprite) SetPosition(pixel int) {
+	pixel %= specification.ClksVisible
+	if pixel < 0 {
+		pixel += specification.ClksVisible
+	}
+
+	// the reset clock is adjusted to account for the delay between the
+	// position counter reaching the start signal and the first pixel being
+	// drawn
+	ms.position, ms.pclk = positionCounters(ms.tia.tv.GetCoords().Clock, pixel+1)
+	ms.ResetPixel = pixel
+	ms.HmovedPixel = pixel
+}
+
 func (ms *MissileSprite) setResetToPlayer(on bool) {
 	ms.ResetToPlayer = on
 }
@@ -646,6 +646,30 @@ func (ps *PlayerSprite) SetVerticalDelay(vdelay bool) {
 	}
 }
 
+// SetPosition changes the position counters of the sprite so that it is drawn
+// at the specified pixel, counting from the left edge of the visible screen.
+// The sprite will be drawn in the new position from the next scanline.
+//
+// Intended for use by debuggers. The effect is the same as a well timed write
+// to the RESP0/RESP1 register.
+func (ps *PlayerSprite) SetPosition(pixel int) {
+	pixel %= specification.ClksVisible
+	if pixel < 0 {
+		pixel += specification.ClksVisible
+	}
+
+	// see _futureResetPosition() for the reason behind the adjustments to the
+	// reset clock
+	reset := pixel
+	if ps.SizeAndCopies == 0x05 || ps.SizeAndCopies == 0x07 {
+		reset--
+	}
+
+	ps.position, ps.pclk = positionCounters(ps.tia.tv.GetCoords().Clock, reset)
+	ps.ResetPixel = pixel
+	ps.HmovedPixel = pixel
+}
+
 func (ps *PlayerSprite) setReflection(value bool) {
 	// from TIA_HW_Notes.txt:
 	//
@@ -177,6 +177,34 @@ func (pf *Playfield) String() string {
 	return s.String()
 }
 
+// ASCII returns the playfield as it would be drawn across the entire scanline
+// with the current PF0, PF1, PF2 and CTRLPF values. Each of the 40 playfield
+// bits is represented by a single character: a '#' for a set bit and a '.' for
+// an unset bit.
+func (pf *Playfield) ASCII() string {
+	right := pf.RegularData
+	if pf.Reflected {
+		right = pf.ReflectedData
+	}
+
+	s := strings.Builder{}
+	for _, d := range pf.RegularData {
+		s.WriteByte(playfieldASCII(d))
+	}
+	for _, d := range right {
+		s.WriteByte(playfieldASCII(d))
+	}
+
+	return s.String()
+}
+
+func playfieldASCII(bit bool) byte {
+	if bit {
+		return '#'
+	}
+	return '.'
+}
+
 func (pf *Playfield) tick() bool {
 	pf.prevColorLatch = pf.colorLatch
 
@@ -0,0 +1,60 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package video
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/hardware/tia/phaseclock"
+	"github.com/jetsetilly/gopher2600/hardware/tia/polycounter"
+)
+
+// the number of position counter values before the counter wraps around. the
+// counter is reset when it reaches this value (see the sprite tick() functions)
+const positionCounterWrap = 40
+
+// positionCounters returns the position counter and phase clock values of a
+// sprite that was reset at the reset clock, as they would be at the current
+// clock. both clock values count from the left edge of the visible screen.
+//
+// the sprite is assumed to have been ticked already for the current clock.
+// sprites are not ticked during the horizontal blank so a current clock value
+// in the horizontal blank is treated as being immediately before the left
+// edge of the screen
+func positionCounters(current int, reset int) (polycounter.Polycounter, phaseclock.PhaseClock) {
+	ticks := max(current+1, 0)
+	elapsed := (ticks - reset) % specification.ClksVisible
+	if elapsed < 0 {
+		elapsed += specification.ClksVisible
+	}
+
+	// tick the counters in the same way as the sprite tick() functions
+	position := polycounter.Polycounter(polycounter.ResetValue)
+	pclk := phaseclock.PhaseClock(phaseclock.ResetValue)
+	for range elapsed {
+		pclk++
+		if pclk >= phaseclock.NumStates {
+			pclk = 0
+		}
+		if pclk == phaseclock.RisingPhi2 {
+			position++
+			if position >= positionCounterWrap {
+				position = polycounter.ResetValue
+			}
+		}
+	}
+
+	return position, pclk
+}
@@ -308,6 +308,7 @@ func (vd *Video) Pixel() {
 			vd.Ball.pixelCollision, vd.Playfield.colorLatch)
 	} else {
 		vd.Collisions.LastColorClock.reset()
+		vd.Collisions.Latched.reset()
 	}
 
 	// prioritisation of pixels:
@@ -24,6 +24,7 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/input"
 	"github.com/jetsetilly/gopher2600/hardware/memory"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/hardware/memory/chipbus"
 	"github.com/jetsetilly/gopher2600/hardware/peripherals"
 	"github.com/jetsetilly/gopher2600/hardware/peripherals/controllers"
 	"github.com/jetsetilly/gopher2600/hardware/preferences"
@@ -72,6 +73,9 @@ type VCS struct {
 	// television detects a change in the TV signal it will notify the emulated
 	// console, allowing it to note the new implied clock speed.
 	Clock float32
+
+	// notified of every change to a chip register. see SetChipWriteObserver()
+	chipWriteObserver chipbus.WriteObserver
 }
 
 // NewVCS creates a new VCS and everything associated with the hardware. It is
@@ -98,6 +102,9 @@ func NewVCS(label environment.Label, tv *television.Television, notify notificat
 
 	vcs.Mem = memory.NewMemory(vcs.Env)
 	vcs.CPU = cpu.NewCPU(vcs.Mem)
+	vcs.Mem.InstructionAddress = func() uint16 {
+		return vcs.CPU.LastResult.Address
+	}
 	vcs.RIOT = riot.NewRIOT(vcs.Env, vcs.Mem.RIOT, vcs.Mem.TIA)
 
 	vcs.Input = input.NewInput(vcs.TV, vcs.RIOT.Ports)
@@ -200,11 +207,13 @@ func (vcs *VCS) Reset() error {
 	//
 	// TODO: proper Reset() function for the TIA
 	audio := vcs.TIA.Audio
+	audioDisabled := vcs.TIA.AudioDisabled()
 	vcs.TIA, err = tia.NewTIA(vcs.Env, vcs.TV, vcs.Mem.TIA, vcs.RIOT.Ports, vcs.CPU)
 	if err != nil {
 		return err
 	}
 	vcs.TIA.Audio = audio
+	vcs.TIA.SetAudioDisabled(audioDisabled)
 
 	// other areas of the VCS are simply reset because the emulation may have
 	// altered the part of the state that we do *not* want to reset. notably,
@@ -282,12 +291,35 @@ func (vcs *VCS) SetClockSpeed(spec specification.Spec) {
 	}
 }
 
+// IsInAttractMode returns true if the 6507 program has not read the controller
+// registers (SWCHA and INPT0 to INPT5) for the number of frames given by the
+// AttractFrames preference. This is a heuristic that suggests the program is
+// running an attract mode or demo loop and is not waiting for the player.
+func (vcs *VCS) IsInAttractMode() bool {
+	frames := vcs.Env.Prefs.AttractFrames.Get().(int)
+	return vcs.TV.GetCoords().Frame-vcs.Mem.InputReadFrame >= frames
+}
+
 // DetatchEmulationExtras removes all possible monitors, recorders, etc. from
 // the emulation.  Currently this mean: the TIA audio tracker, the RIOT event
-// recorders and playback, and RIOT plug monitor.
+// recorders and playback, the RIOT plug monitor and the chip write observer.
 func (vcs *VCS) DetatchEmulationExtras() {
 	vcs.TIA.Audio.SetTracker(nil)
 	vcs.Input.ClearRecorders()
 	vcs.Input.AttachPlayback(nil)
 	vcs.RIOT.Ports.AttachPlugMonitor(nil)
+	vcs.SetChipWriteObserver(nil)
+}
+
+// SetChipWriteObserver sets the observer that will be notified of every change
+// made to a TIA or RIOT register by the CPU. A value of nil removes any
+// existing observer.
+func (vcs *VCS) SetChipWriteObserver(obs chipbus.WriteObserver) {
+	vcs.chipWriteObserver = obs
+}
+
+func (vcs *VCS) observeChipWrite(reg chipbus.ChangedRegister) {
+	if vcs.chipWriteObserver != nil {
+		vcs.chipWriteObserver.ObserveChipWrite(reg)
+	}
 }
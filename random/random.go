@@ -45,6 +45,10 @@ type Random struct {
 	// useful for normalised instances where random numbers must be predictable
 	ZeroSeed bool
 
+	// the seed for random numbers. initialised with the base seed but can be
+	// changed with SetSeed()
+	seed int64
+
 	// standard Go random number generator for NoRewind()
 	nonRewindable rand.Source
 }
@@ -53,10 +57,27 @@ type Random struct {
 func NewRandom(tv TV) *Random {
 	return &Random{
 		tv:            tv,
+		seed:          baseSeed,
 		nonRewindable: rand.NewSource(baseSeed),
 	}
 }
 
+// SetSeed sets the seed for all random numbers generated by the instance.
+// Emulations using the same seed will produce the same sequence of random
+// numbers, making runs reproducible.
+//
+// The ZeroSeed field is set to false by this function.
+func (rnd *Random) SetSeed(seed int64) {
+	rnd.ZeroSeed = false
+	rnd.seed = seed
+	rnd.nonRewindable = rand.NewSource(seed)
+}
+
+// Seed returns the current seed.
+func (rnd *Random) Seed() int64 {
+	return rnd.seed
+}
+
 // translate television coordinates into a single value
 func coordsSum(c coords.TelevisionCoords) int64 {
 	return int64(c.Frame*specification.AbsoluteMaxClks + c.Scanline*specification.ClksScanline + c.Clock)
@@ -77,7 +98,7 @@ func (rnd *Random) Rewindable(n int) int {
 
 	seed := coordsSum(rnd.tv.GetCoords())
 	if !rnd.ZeroSeed {
-		seed += baseSeed
+		seed += rnd.seed
 	}
 	seed *= seed
 	b := seed >> 32
@@ -102,3 +123,9 @@ func (rnd *Random) NoRewind(n int) int {
 
 	return int(rnd.nonRewindable.Int63() % int64(n))
 }
+
+// NoRewindUint32 is the same as NoRewind() except that the returned value is
+// a random 32bit number.
+func (rnd *Random) NoRewindUint32() uint32 {
+	return uint32(rnd.nonRewindable.Int63())
+}
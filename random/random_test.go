@@ -18,7 +18,10 @@ package random_test
 import (
 	"testing"
 
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware/hardwaretest"
 	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+	"github.com/jetsetilly/gopher2600/prefs"
 	"github.com/jetsetilly/gopher2600/random"
 	"github.com/jetsetilly/gopher2600/test"
 )
@@ -42,3 +45,66 @@ func TestRandom(t *testing.T) {
 		test.ExpectEquality(t, a.Rewindable(i), b.Rewindable(i))
 	}
 }
+
+func TestSeed(t *testing.T) {
+	a := random.NewRandom(&mockTV{})
+	b := random.NewRandom(&mockTV{})
+	a.SetSeed(1000)
+	b.SetSeed(1000)
+	test.ExpectEquality(t, a.Seed(), int64(1000))
+
+	for i := 1; i < 256; i++ {
+		test.ExpectEquality(t, a.Rewindable(i), b.Rewindable(i))
+		test.ExpectEquality(t, a.NoRewind(i), b.NoRewind(i))
+		test.ExpectEquality(t, a.NoRewindUint32(), b.NoRewindUint32())
+	}
+}
+
+// a 4k ROM that sets the background color of every scanline from the contents
+// of zero page memory
+func seedROM() []byte {
+	prg := []byte{
+		0xa9, 0x02, // f000 lda #$02
+		0x85, 0x00, // f002 sta VSYNC
+		0x85, 0x02, // f004 sta WSYNC
+		0x85, 0x02, // f006 sta WSYNC
+		0x85, 0x02, // f008 sta WSYNC
+		0xa9, 0x00, // f00a lda #$00
+		0x85, 0x00, // f00c sta VSYNC
+		0xb5, 0x80, // f00e lda $80,X
+		0x85, 0x09, // f010 sta COLUBK
+		0x85, 0x02, // f012 sta WSYNC
+		0xe8,       // f014 inx
+		0xd0, 0xf7, // f015 bne $f00e
+		0x4c, 0x00, 0xf0, // f017 jmp $f000
+	}
+
+	return hardwaretest.ROM(prg)
+}
+
+func TestSeededEmulation(t *testing.T) {
+	prefs.DisableSaving = true
+
+	run := func(seed int64) uint64 {
+		t.Helper()
+
+		vcs := hardwaretest.NewVCS(t, nil)
+
+		// the random state on reset is dependent on the seed
+		vcs.Env.Prefs.RandomState.Set(true)
+		vcs.Env.Random.SetSeed(seed)
+
+		cartload, err := cartridgeloader.NewLoaderFromData("seed", seedROM(), "4K", "", nil)
+		test.DemandSuccess(t, err)
+		test.DemandSuccess(t, vcs.AttachCartridge(cartload, true))
+		test.DemandSuccess(t, vcs.RunForFrameCount(5, nil))
+
+		return vcs.TV.FrameHash()
+	}
+
+	// the same seed produces identical runs
+	test.ExpectEquality(t, run(1234), run(1234))
+
+	// a different seed produces a different run
+	test.ExpectInequality(t, run(1234), run(5678))
+}
@@ -0,0 +1,167 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package rewind
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/plugging"
+	"github.com/jetsetilly/gopher2600/hardware/television/coords"
+)
+
+// ExportCPU is the state of the CPU registers in an Export.
+type ExportCPU struct {
+	PC     uint16
+	A      uint8
+	X      uint8
+	Y      uint8
+	SP     uint8
+	Status uint8
+}
+
+// ExportInput is a user input event in an Export. The event data is stored as
+// a string in the same way as it is for playback files.
+type ExportInput struct {
+	Time coords.TelevisionCoords
+	Port plugging.PortID
+	Ev   ports.Event
+	D    string
+}
+
+// Export is a serialisable copy of the most recent state in the rewind history
+// and the user input recorded since the earliest state in the history.
+//
+// Only the CPU registers and RAM are exported. The state of the TIA, the RIOT
+// timer and the cartridge are not part of the Export.
+type Export struct {
+	Coords coords.TelevisionCoords
+	CPU    ExportCPU
+	RAM    []uint8
+	Input  []ExportInput
+}
+
+// Export the most recent state in the rewind history along with the user input.
+func (r *Rewind) Export() Export {
+	var exp Export
+
+	s := r.entries[r.lastEntryIdx()]
+	if s == nil {
+		return exp
+	}
+
+	exp.Coords = s.TV.GetCoords()
+	exp.CPU = ExportCPU{
+		PC:     s.VCS.CPU.PC.Value(),
+		A:      s.VCS.CPU.A.Value(),
+		X:      s.VCS.CPU.X.Value(),
+		Y:      s.VCS.CPU.Y.Value(),
+		SP:     s.VCS.CPU.SP.Value(),
+		Status: s.VCS.CPU.Status.Value(),
+	}
+	exp.RAM = make([]uint8, len(s.VCS.Mem.RAM.RAM))
+	copy(exp.RAM, s.VCS.Mem.RAM.RAM)
+
+	for _, ev := range r.userinput.queue {
+		exp.Input = append(exp.Input, ExportInput{
+			Time: ev.Time,
+			Port: ev.Port,
+			Ev:   ev.Ev,
+			D:    fmt.Sprintf("%v", ev.D),
+		})
+	}
+
+	return exp
+}
+
+// Restore the CPU registers and RAM in the Export to the VCS. The user input
+// in the Export is attached to the VCS as a playback and will be inserted into
+// the emulation as it is run.
+//
+// The frame number of the VCS is unlikely to be the same as the frame number
+// of the Export. The frame number of each input event is therefore adjusted by
+// the difference. Input events that occurred before the Export coordinates are
+// not inserted.
+func (exp Export) Restore(vcs *hardware.VCS) error {
+	if len(exp.RAM) != len(vcs.Mem.RAM.RAM) {
+		return fmt.Errorf("rewind: export has %d bytes of RAM, expected %d", len(exp.RAM), len(vcs.Mem.RAM.RAM))
+	}
+
+	vcs.CPU.PC.Load(exp.CPU.PC)
+	vcs.CPU.A.Load(exp.CPU.A)
+	vcs.CPU.X.Load(exp.CPU.X)
+	vcs.CPU.Y.Load(exp.CPU.Y)
+	vcs.CPU.SP.Load(exp.CPU.SP)
+	vcs.CPU.Status.Load(exp.CPU.Status)
+	copy(vcs.Mem.RAM.RAM, exp.RAM)
+
+	pb := &exportPlayback{
+		tv:     vcs.TV,
+		input:  exp.Input,
+		offset: exp.Coords.Frame - vcs.TV.GetCoords().Frame,
+	}
+
+	// skip input events that occurred before the exported state
+	for pb.idx < len(pb.input) && coords.GreaterThan(exp.Coords, pb.input[pb.idx].Time) {
+		pb.idx++
+	}
+
+	return vcs.Input.AttachPlayback(pb)
+}
+
+// exportPlayback implements the input.EventPlayback interface for the user
+// input in an Export
+type exportPlayback struct {
+	tv interface {
+		GetCoords() coords.TelevisionCoords
+	}
+	input []ExportInput
+	idx   int
+
+	// the difference between the frame number of the Export and the frame
+	// number of the VCS when the Export was restored
+	offset int
+}
+
+// GetPlayback implements input.EventPlayback interface
+func (pb *exportPlayback) GetPlayback() (ports.TimedInputEvent, error) {
+	c := pb.tv.GetCoords()
+	c.Frame += pb.offset
+
+	// the coordinates of the VCS are unlikely to match the coordinates of the
+	// input event exactly so the event is inserted as soon as the coordinates
+	// of the VCS reach or pass it
+	if pb.idx < len(pb.input) && coords.GreaterThanOrEqual(c, pb.input[pb.idx].Time) {
+		ev := pb.input[pb.idx]
+		pb.idx++
+		return ports.TimedInputEvent{
+			Time: pb.tv.GetCoords(),
+			InputEvent: ports.InputEvent{
+				Port: ev.Port,
+				Ev:   ev.Ev,
+				D:    ports.EventDataPlayback(ev.D),
+			},
+		}, nil
+	}
+
+	return ports.TimedInputEvent{
+		Time: pb.tv.GetCoords(),
+		InputEvent: ports.InputEvent{
+			Ev: ports.NoEvent,
+		},
+	}, nil
+}